@@ -17,9 +17,11 @@ import (
 	"github.com/v2fly/v2ray-core/v4/common/session"
 	"github.com/v2fly/v2ray-core/v4/features/outbound"
 	"github.com/v2fly/v2ray-core/v4/features/policy"
+	"github.com/v2fly/v2ray-core/v4/features/quota"
 	"github.com/v2fly/v2ray-core/v4/features/routing"
 	routing_session "github.com/v2fly/v2ray-core/v4/features/routing/session"
 	"github.com/v2fly/v2ray-core/v4/features/stats"
+	"github.com/v2fly/v2ray-core/v4/features/tracing"
 	"github.com/v2fly/v2ray-core/v4/transport"
 	"github.com/v2fly/v2ray-core/v4/transport/pipe"
 )
@@ -91,11 +93,14 @@ type DefaultDispatcher struct {
 	router routing.Router
 	policy policy.Manager
 	stats  stats.Manager
+	ctx    context.Context
+	quota  quota.Manager
+	tracer tracing.Tracer
 }
 
 func init() {
 	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
-		d := new(DefaultDispatcher)
+		d := &DefaultDispatcher{ctx: ctx}
 		if err := core.RequireFeatures(ctx, func(om outbound.Manager, router routing.Router, pm policy.Manager, sm stats.Manager) error {
 			return d.Init(config.(*Config), om, router, pm, sm)
 		}); err != nil {
@@ -119,18 +124,40 @@ func (*DefaultDispatcher) Type() interface{} {
 	return routing.DispatcherType()
 }
 
-// Start implements common.Runnable.
-func (*DefaultDispatcher) Start() error {
+// Start implements common.Runnable. Traffic quotas are enforced if there is a quota manager, and sessions are traced
+// if there is a tracer.
+func (d *DefaultDispatcher) Start() error {
+	if instance := core.FromContext(d.ctx); instance != nil {
+		d.quota, _ = instance.GetFeature(quota.ManagerType()).(quota.Manager)
+		d.tracer, _ = instance.GetFeature(tracing.TracerType()).(tracing.Tracer)
+	}
 	return nil
 }
 
 // Close implements common.Closable.
 func (*DefaultDispatcher) Close() error { return nil }
 
-func (d *DefaultDispatcher) getLink(ctx context.Context) (*transport.Link, *transport.Link) {
-	opt := pipe.OptionsFromContext(ctx)
-	uplinkReader, uplinkWriter := pipe.New(opt...)
-	downlinkReader, downlinkWriter := pipe.New(opt...)
+func (d *DefaultDispatcher) getLink(ctx context.Context, usage quota.Usage, connStats *connectionStats) (*transport.Link, *transport.Link) {
+	sessionInbound := session.InboundFromContext(ctx)
+	var user *protocol.MemoryUser
+	if sessionInbound != nil {
+		user = sessionInbound.User
+	}
+
+	uplinkOpt := pipe.OptionsFromContext(ctx)
+	downlinkOpt := pipe.OptionsFromContext(ctx)
+	if bm, ok := d.policy.(policy.BandwidthManager); ok && sessionInbound != nil {
+		for _, limiters := range []policy.RateLimiters{bm.ForUser(user), bm.ForInbound(sessionInbound.Tag)} {
+			if limiters.Uplink != nil {
+				uplinkOpt = append(uplinkOpt, pipe.WithRateLimiter(limiters.Uplink))
+			}
+			if limiters.Downlink != nil {
+				downlinkOpt = append(downlinkOpt, pipe.WithRateLimiter(limiters.Downlink))
+			}
+		}
+	}
+	uplinkReader, uplinkWriter := pipe.New(uplinkOpt...)
+	downlinkReader, downlinkWriter := pipe.New(downlinkOpt...)
 
 	inboundLink := &transport.Link{
 		Reader: downlinkReader,
@@ -142,12 +169,6 @@ func (d *DefaultDispatcher) getLink(ctx context.Context) (*transport.Link, *tran
 		Writer: downlinkWriter,
 	}
 
-	sessionInbound := session.InboundFromContext(ctx)
-	var user *protocol.MemoryUser
-	if sessionInbound != nil {
-		user = sessionInbound.User
-	}
-
 	if user != nil && len(user.Email) > 0 {
 		p := d.policy.ForLevel(user.Level)
 		if p.Stats.UserUplink {
@@ -170,6 +191,31 @@ func (d *DefaultDispatcher) getLink(ctx context.Context) (*transport.Link, *tran
 		}
 	}
 
+	if connStats != nil {
+		inboundLink.Writer = &SizeStatWriter{
+			Counter: &connStats.uplink,
+			Writer:  inboundLink.Writer,
+		}
+		outboundLink.Writer = &connectionCloseWriter{
+			stats: connStats,
+			Writer: &SizeStatWriter{
+				Counter: &connStats.downlink,
+				Writer:  outboundLink.Writer,
+			},
+		}
+	}
+
+	if usage != nil {
+		inboundLink.Writer = &QuotaWriter{
+			Usage:  usage,
+			Writer: inboundLink.Writer,
+		}
+		outboundLink.Writer = &QuotaWriter{
+			Usage:  usage,
+			Writer: outboundLink.Writer,
+		}
+	}
+
 	return inboundLink, outboundLink
 }
 
@@ -201,7 +247,28 @@ func (d *DefaultDispatcher) Dispatch(ctx context.Context, destination net.Destin
 	}
 	ctx = session.ContextWithOutbound(ctx, ob)
 
-	inbound, outbound := d.getLink(ctx)
+	var usage quota.Usage
+	if d.quota != nil {
+		var err error
+		if usage, err = d.quota.Track(ctx); err != nil {
+			return nil, newError("connection to ", destination, " rejected").Base(err)
+		}
+	}
+
+	// Connections accepted in the access log are recorded again when they are closed.
+	var connStats *connectionStats
+	if accessMessage := log.AccessMessageFromContext(ctx); accessMessage != nil {
+		connStats = newConnectionStats(accessMessage)
+	}
+
+	inbound, outbound := d.getLink(ctx, usage, connStats)
+	ctx, span := d.startSession(ctx, destination)
+	if span != nil {
+		outbound.Writer = &spanCloseWriter{
+			span:   span,
+			Writer: outbound.Writer,
+		}
+	}
 	content := session.ContentFromContext(ctx)
 	if content == nil {
 		content = new(session.Content)
@@ -211,13 +278,14 @@ func (d *DefaultDispatcher) Dispatch(ctx context.Context, destination net.Destin
 	sniffingRequest := content.SniffingRequest
 	switch {
 	case !sniffingRequest.Enabled:
-		go d.routedDispatch(ctx, outbound, destination)
+		go d.routedDispatch(ctx, outbound, destination, connStats)
 
 	case destination.Network != net.Network_TCP:
 		// Only metadata sniff will be used for non tcp connection
-		result, err := sniffer(ctx, nil, true)
+		result, err := sniffTraced(ctx, nil, true)
 		if err == nil {
 			content.Protocol = result.Protocol()
+			recordSniffedDomain(ctx, result)
 			if shouldOverride(result, sniffingRequest.OverrideDestinationForProtocol) {
 				domain := result.Domain()
 				newError("sniffed domain: ", domain).WriteToLog(session.ExportIDToError(ctx))
@@ -225,16 +293,17 @@ func (d *DefaultDispatcher) Dispatch(ctx context.Context, destination net.Destin
 				ob.Target = destination
 			}
 		}
-		go d.routedDispatch(ctx, outbound, destination)
+		go d.routedDispatch(ctx, outbound, destination, connStats)
 	default:
 		go func() {
 			cReader := &cachedReader{
 				reader: outbound.Reader.(*pipe.Reader),
 			}
 			outbound.Reader = cReader
-			result, err := sniffer(ctx, cReader, sniffingRequest.MetadataOnly)
+			result, err := sniffTraced(ctx, cReader, sniffingRequest.MetadataOnly)
 			if err == nil {
 				content.Protocol = result.Protocol()
+				recordSniffedDomain(ctx, result)
 			}
 			if err == nil && shouldOverride(result, sniffingRequest.OverrideDestinationForProtocol) {
 				domain := result.Domain()
@@ -242,12 +311,20 @@ func (d *DefaultDispatcher) Dispatch(ctx context.Context, destination net.Destin
 				destination.Address = net.ParseAddress(domain)
 				ob.Target = destination
 			}
-			d.routedDispatch(ctx, outbound, destination)
+			d.routedDispatch(ctx, outbound, destination, connStats)
 		}()
 	}
 	return inbound, nil
 }
 
+// recordSniffedDomain records the domain and protocol sniffed in the access message of the connection.
+func recordSniffedDomain(ctx context.Context, result SniffResult) {
+	if accessMessage := log.AccessMessageFromContext(ctx); accessMessage != nil {
+		accessMessage.Domain = result.Domain()
+		accessMessage.Protocol = result.Protocol()
+	}
+}
+
 func sniffer(ctx context.Context, cReader *cachedReader, metadataOnly bool) (SniffResult, error) {
 	payload := buf.New()
 	defer payload.Release()
@@ -293,7 +370,7 @@ func sniffer(ctx context.Context, cReader *cachedReader, metadataOnly bool) (Sni
 	}
 	return contentResult, contentErr
 }
-func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.Link, destination net.Destination) {
+func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.Link, destination net.Destination, connStats *connectionStats) {
 	var handler outbound.Handler
 
 	if forcedOutboundTag := session.GetForcedOutboundTagFromContext(ctx); forcedOutboundTag != "" {
@@ -336,8 +413,28 @@ func (d *DefaultDispatcher) routedDispatch(ctx context.Context, link *transport.
 		if tag := handler.Tag(); tag != "" {
 			accessMessage.Detour = tag
 		}
-		log.Record(accessMessage)
+		if inbound := session.InboundFromContext(ctx); inbound != nil {
+			accessMessage.InboundTag = inbound.Tag
+		}
+		if connStats != nil {
+			connStats.accept()
+		} else {
+			log.Record(accessMessage)
+		}
 	}
 
+	if bm, ok := d.policy.(policy.BandwidthManager); ok {
+		limiters := bm.ForOutbound(handler.Tag())
+		if limiters.Uplink != nil {
+			link.Reader = buf.NewRateLimitedReader(link.Reader, limiters.Uplink)
+		}
+		if limiters.Downlink != nil {
+			link.Writer = buf.NewRateLimitedWriter(link.Writer, limiters.Downlink)
+		}
+	}
+
+	if connStats != nil {
+		ctx = session.TrackedConnectionError(ctx, connStats.errorTracker(ctx))
+	}
 	handler.Dispatch(ctx, link)
 }
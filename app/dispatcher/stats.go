@@ -1,8 +1,16 @@
 package dispatcher
 
 import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
 	"github.com/v2fly/v2ray-core/v4/common"
 	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/log"
+	"github.com/v2fly/v2ray-core/v4/common/session"
+	"github.com/v2fly/v2ray-core/v4/features/quota"
 	"github.com/v2fly/v2ray-core/v4/features/stats"
 )
 
@@ -23,3 +31,133 @@ func (w *SizeStatWriter) Close() error {
 func (w *SizeStatWriter) Interrupt() {
 	common.Interrupt(w.Writer)
 }
+
+// QuotaWriter accounts the traffic written against quotas, and fails once the connection has to be cut.
+type QuotaWriter struct {
+	Usage  quota.Usage
+	Writer buf.Writer
+}
+
+func (w *QuotaWriter) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	if err := w.Usage.Add(int64(mb.Len())); err != nil {
+		buf.ReleaseMulti(mb)
+		return err
+	}
+	return w.Writer.WriteMultiBuffer(mb)
+}
+
+func (w *QuotaWriter) Close() error {
+	return common.Close(w.Writer)
+}
+
+func (w *QuotaWriter) Interrupt() {
+	common.Interrupt(w.Writer)
+}
+
+// trafficCounter is a stats.Counter of the traffic of a single connection.
+type trafficCounter struct {
+	value int64
+}
+
+func (c *trafficCounter) Value() int64 {
+	return atomic.LoadInt64(&c.value)
+}
+
+func (c *trafficCounter) Set(v int64) int64 {
+	return atomic.SwapInt64(&c.value, v)
+}
+
+func (c *trafficCounter) Add(v int64) int64 {
+	return atomic.AddInt64(&c.value, v) - v
+}
+
+// connectionStats are the traffic and lifetime of a connection, which are recorded in the access log when the
+// outbound closes its link.
+type connectionStats struct {
+	start    time.Time
+	uplink   trafficCounter
+	downlink trafficCounter
+	message  *log.AccessMessage
+
+	access   sync.Mutex
+	accepted bool
+	closed   bool
+	err      error
+}
+
+func newConnectionStats(message *log.AccessMessage) *connectionStats {
+	return &connectionStats{
+		start:   time.Now(),
+		message: message,
+	}
+}
+
+// accept records the access message of the connection accepted.
+func (s *connectionStats) accept() {
+	s.access.Lock()
+	s.accepted = true
+	s.access.Unlock()
+
+	log.Record(s.message)
+}
+
+// close records the access message of the connection closed, if it is accepted and not closed yet.
+func (s *connectionStats) close() {
+	s.access.Lock()
+	if !s.accepted || s.closed {
+		s.access.Unlock()
+		return
+	}
+	s.closed = true
+	msg := *s.message
+	msg.Status = log.AccessClosed
+	msg.Reason = ""
+	if s.err != nil {
+		msg.Reason = s.err
+	}
+	s.access.Unlock()
+
+	msg.Uplink = s.uplink.Value()
+	msg.Downlink = s.downlink.Value()
+	msg.Duration = time.Since(s.start)
+	log.Record(&msg)
+}
+
+// errorTracker returns an error feedback recording the error the outbound fails with, which is also submitted to the
+// originator of the context, if any.
+func (s *connectionStats) errorTracker(ctx context.Context) session.TrackedRequestErrorFeedback {
+	return &connectionErrorTracker{ctx: ctx, stats: s}
+}
+
+type connectionErrorTracker struct {
+	ctx   context.Context
+	stats *connectionStats
+}
+
+func (t *connectionErrorTracker) SubmitError(err error) {
+	t.stats.access.Lock()
+	t.stats.err = err
+	t.stats.access.Unlock()
+	session.SubmitOutboundErrorToOriginator(t.ctx, err)
+}
+
+// connectionCloseWriter records the connection closed, once the writer is closed or interrupted.
+type connectionCloseWriter struct {
+	stats  *connectionStats
+	Writer buf.Writer
+}
+
+func (w *connectionCloseWriter) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	return w.Writer.WriteMultiBuffer(mb)
+}
+
+func (w *connectionCloseWriter) Close() error {
+	err := common.Close(w.Writer)
+	w.stats.close()
+	return err
+}
+
+func (w *connectionCloseWriter) Interrupt() {
+	common.Interrupt(w.Writer)
+	w.stats.close()
+}
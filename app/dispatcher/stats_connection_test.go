@@ -0,0 +1,61 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/log"
+)
+
+type accessRecorder struct {
+	messages []*log.AccessMessage
+}
+
+func (r *accessRecorder) Handle(msg log.Message) {
+	if msg, ok := msg.(*log.AccessMessage); ok {
+		r.messages = append(r.messages, msg)
+	}
+}
+
+func TestConnectionClosedRecord(t *testing.T) {
+	recorder := &accessRecorder{}
+	log.RegisterHandler(recorder)
+
+	stats := newConnectionStats(&log.AccessMessage{
+		From:   "127.0.0.1:1080",
+		To:     "tcp:v2fly.org:443",
+		Status: log.AccessAccepted,
+	})
+	uplink := &SizeStatWriter{Counter: &stats.uplink, Writer: buf.Discard}
+	downlink := &connectionCloseWriter{
+		stats:  stats,
+		Writer: &SizeStatWriter{Counter: &stats.downlink, Writer: buf.Discard},
+	}
+
+	// Connections not accepted are not recorded as closed.
+	common.Close(downlink)
+	if len(recorder.messages) != 0 {
+		t.Fatal("expected no record, but got ", recorder.messages)
+	}
+
+	stats.accept()
+	common.Must(uplink.WriteMultiBuffer(buf.MergeBytes(nil, []byte("abcd"))))
+	common.Must(downlink.WriteMultiBuffer(buf.MergeBytes(nil, []byte("efg"))))
+	stats.errorTracker(context.Background()).SubmitError(errors.New("connection reset"))
+	common.Interrupt(downlink)
+	common.Close(downlink)
+
+	if len(recorder.messages) != 2 {
+		t.Fatal("expected accepted and closed records, but got ", recorder.messages)
+	}
+	closed := recorder.messages[1]
+	if closed.Status != log.AccessClosed || closed.Uplink != 4 || closed.Downlink != 3 || closed.To != "tcp:v2fly.org:443" {
+		t.Error("unexpected closed record: ", closed)
+	}
+	if err, ok := closed.Reason.(error); !ok || err.Error() != "connection reset" {
+		t.Error("unexpected close reason: ", closed.Reason)
+	}
+}
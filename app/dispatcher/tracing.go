@@ -0,0 +1,73 @@
+package dispatcher
+
+import (
+	"context"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/session"
+	"github.com/v2fly/v2ray-core/v4/features/tracing"
+)
+
+// startSession starts the span of the session dispatched to the destination, and returns a context carrying it.
+// Sessions dispatched within a traced context, such as DNS queries, are traced under the span of the context. The
+// span is nil if the session is not traced.
+func (d *DefaultDispatcher) startSession(ctx context.Context, destination net.Destination) (context.Context, tracing.Span) {
+	attributes := []tracing.Attribute{
+		tracing.String("target", destination.NetAddr()),
+		tracing.String("network", destination.Network.SystemString()),
+	}
+	if inbound := session.InboundFromContext(ctx); inbound != nil {
+		attributes = append(attributes, tracing.String("inbound.tag", inbound.Tag))
+		if inbound.Source.IsValid() {
+			attributes = append(attributes, tracing.String("source", inbound.Source.NetAddr()))
+		}
+	}
+
+	var span tracing.Span
+	if parent := tracing.SpanFromContext(ctx); parent != nil {
+		span = parent.StartChild("session", attributes...)
+	} else if d.tracer != nil {
+		span = d.tracer.StartSession(ctx, "session", attributes...)
+	}
+	if span == nil {
+		return ctx, nil
+	}
+	return tracing.ContextWithSpan(ctx, span), span
+}
+
+// sniffTraced is sniffer traced under the span of the session.
+func sniffTraced(ctx context.Context, cReader *cachedReader, metadataOnly bool) (SniffResult, error) {
+	_, span := tracing.Start(ctx, "sniff")
+	defer span.End()
+
+	result, err := sniffer(ctx, cReader, metadataOnly)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	span.SetAttributes(tracing.String("protocol", result.Protocol()), tracing.String("domain", result.Domain()))
+	return result, nil
+}
+
+// spanCloseWriter ends the span of the session, once the writer is closed or interrupted.
+type spanCloseWriter struct {
+	span   tracing.Span
+	Writer buf.Writer
+}
+
+func (w *spanCloseWriter) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	return w.Writer.WriteMultiBuffer(mb)
+}
+
+func (w *spanCloseWriter) Close() error {
+	err := common.Close(w.Writer)
+	w.span.End()
+	return err
+}
+
+func (w *spanCloseWriter) Interrupt() {
+	common.Interrupt(w.Writer)
+	w.span.End()
+}
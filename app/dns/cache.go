@@ -0,0 +1,389 @@
+package dns
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	dns_feature "github.com/v2fly/v2ray-core/v4/features/dns"
+	"github.com/v2fly/v2ray-core/v4/features/extension/storage"
+)
+
+const (
+	// staleTTL is how long a stale answer is valid, as recommended by RFC 8767.
+	staleTTL = 30 * time.Second
+	// refreshRetry is how long to wait before refreshing a record again, if the last refresh is not answered.
+	refreshRetry = 10 * time.Second
+	// prefetchHits is how many times a record is hit before it is considered popular.
+	prefetchHits = 2
+)
+
+// cacheOptions are how a name server caches its records.
+type cacheOptions struct {
+	// serveStale is how long expired records are served after they expire.
+	serveStale time.Duration
+	prefetch   bool
+	minTTL     time.Duration
+	maxTTL     time.Duration
+}
+
+type cachedRecord struct {
+	*IPRecord
+	updated time.Time
+	hits    uint32
+	// refreshed is when the record was last scheduled for refresh.
+	refreshed time.Time
+}
+
+type cacheEntry struct {
+	A    *cachedRecord
+	AAAA *cachedRecord
+}
+
+// recordCache is the cache of IP records of a name server.
+type recordCache struct {
+	sync.Mutex
+	entries map[string]*cacheEntry
+	options cacheOptions
+
+	storage storage.ScopedPersistentStorage
+	key     []byte
+	// generation counts the snapshots of the records taken to be saved.
+	generation uint64
+
+	// saveAccess serializes writes to the storage, which are done without holding the cache locked. saved is the
+	// generation of the snapshot last written, so that an older one never overwrites it.
+	saveAccess sync.Mutex
+	saved      uint64
+}
+
+// cachedServer is a name server keeping its records in a recordCache.
+type cachedServer interface {
+	getCache() *recordCache
+}
+
+func newRecordCache() *recordCache {
+	return &recordCache{
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+func (c *recordCache) setOptions(options cacheOptions) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.options = options
+}
+
+func (c *recordCache) empty() bool {
+	c.Lock()
+	defer c.Unlock()
+
+	return len(c.entries) == 0
+}
+
+// clamp returns a copy of rec whose TTL is clamped within the limits of the options.
+func (c *recordCache) clamp(rec *IPRecord, now time.Time) *IPRecord {
+	ttl := rec.Expire.Sub(now)
+	if c.options.minTTL > 0 && ttl < c.options.minTTL {
+		ttl = c.options.minTTL
+	}
+	if c.options.maxTTL > 0 && ttl > c.options.maxTTL {
+		ttl = c.options.maxTTL
+	}
+	clamped := *rec
+	clamped.Expire = now.Add(ttl)
+	return &clamped
+}
+
+// update stores the records of the domain that are newer than the cached ones. It returns whether any is stored.
+func (c *recordCache) update(domain string, rec record) bool {
+	now := time.Now()
+
+	c.Lock()
+	defer c.Unlock()
+
+	entry := c.entries[domain]
+	if entry == nil {
+		entry = &cacheEntry{}
+	}
+	updated := false
+	if rec.A != nil {
+		if a := c.clamp(rec.A, now); c.replaces(entry.A, a, now) {
+			entry.A = &cachedRecord{IPRecord: a, updated: now}
+			updated = true
+		}
+	}
+	if rec.AAAA != nil {
+		if aaaa := c.clamp(rec.AAAA, now); c.replaces(entry.AAAA, aaaa, now) {
+			entry.AAAA = &cachedRecord{IPRecord: aaaa, updated: now}
+			updated = true
+		}
+	}
+	if entry.A != nil || entry.AAAA != nil {
+		c.entries[domain] = entry
+	}
+	return updated
+}
+
+// replaces returns whether the record replaces the cached one. A failed answer does not replace a successful one that
+// can still be served, so that it is served stale while the server fails (RFC 8767).
+func (c *recordCache) replaces(cached *cachedRecord, rec *IPRecord, now time.Time) bool {
+	if cached == nil {
+		return true
+	}
+	if rec.RCode != dnsmessage.RCodeSuccess && cached.RCode == dnsmessage.RCodeSuccess && c.servable(cached, now) {
+		return false
+	}
+	return isNewer(cached.IPRecord, rec)
+}
+
+// get returns the records of the domain. A record expired within the serve-stale window is returned as a fresh one
+// with the stale TTL, so it is answered until the refresh arrives.
+func (c *recordCache) get(domain string) (record, bool) {
+	now := time.Now()
+
+	c.Lock()
+	defer c.Unlock()
+
+	entry, found := c.entries[domain]
+	if !found {
+		return record{}, false
+	}
+	return record{
+		A:    c.serve(entry.A, now),
+		AAAA: c.serve(entry.AAAA, now),
+	}, true
+}
+
+func (c *recordCache) serve(r *cachedRecord, now time.Time) *IPRecord {
+	if r == nil {
+		return nil
+	}
+	r.hits++
+	if r.Expire.After(now) {
+		return r.IPRecord
+	}
+	if c.stale(r, now) {
+		stale := *r.IPRecord
+		stale.Expire = now.Add(staleTTL)
+		return &stale
+	}
+	return nil
+}
+
+// stale returns whether the expired record may still be served.
+func (c *recordCache) stale(r *cachedRecord, now time.Time) bool {
+	return c.options.serveStale > 0 && r.RCode == dnsmessage.RCodeSuccess && r.DNSSEC != dns_feature.DNSSECBogus &&
+		now.Before(r.Expire.Add(c.options.serveStale))
+}
+
+// dnssec returns the DNSSEC status of the records of the domain. The less trusted one is returned, if the statuses
+// of A and AAAA records differ.
+func (c *recordCache) dnssec(domain string) (dns_feature.DNSSECStatus, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	entry, found := c.entries[domain]
+	if !found {
+		return dns_feature.DNSSECIndeterminate, false
+	}
+	switch {
+	case entry.A == nil:
+		return entry.AAAA.DNSSEC, true
+	case entry.AAAA == nil || weaker(entry.A.DNSSEC, entry.AAAA.DNSSEC):
+		return entry.A.DNSSEC, true
+	default:
+		return entry.AAAA.DNSSEC, true
+	}
+}
+
+// markRefresh returns whether the records of the domain should be refreshed, which is when they are served stale, or
+// with prefetch, when they are popular and about to expire. The records are marked, so only one refresh is scheduled
+// at a time.
+func (c *recordCache) markRefresh(domain string, option dns_feature.IPOption) bool {
+	now := time.Now()
+
+	c.Lock()
+	defer c.Unlock()
+
+	entry, found := c.entries[domain]
+	if !found {
+		return false
+	}
+	refresh := false
+	if option.IPv4Enable && c.due(entry.A, now) {
+		entry.A.refreshed = now
+		refresh = true
+	}
+	if option.IPv6Enable && c.due(entry.AAAA, now) {
+		entry.AAAA.refreshed = now
+		refresh = true
+	}
+	return refresh
+}
+
+func (c *recordCache) due(r *cachedRecord, now time.Time) bool {
+	if r == nil || now.Sub(r.refreshed) < refreshRetry {
+		return false
+	}
+	if !r.Expire.After(now) {
+		return c.stale(r, now)
+	}
+	if !c.options.prefetch || r.hits < prefetchHits {
+		return false
+	}
+	ttl := r.Expire.Sub(r.updated)
+	return r.Expire.Sub(now) <= ttl/10
+}
+
+// cleanup removes records that can no longer be served, and saves the others if the cache is persisted. They are
+// written in the background, as name servers clean up their caches while holding their locks.
+func (c *recordCache) cleanup() {
+	now := time.Now()
+
+	c.Lock()
+	for domain, entry := range c.entries {
+		if entry.A != nil && !c.servable(entry.A, now) {
+			entry.A = nil
+		}
+		if entry.AAAA != nil && !c.servable(entry.AAAA, now) {
+			entry.AAAA = nil
+		}
+		if entry.A == nil && entry.AAAA == nil {
+			delete(c.entries, domain)
+		}
+	}
+	snapshot := c.snapshot()
+	c.Unlock()
+
+	if snapshot != nil {
+		go c.write(snapshot)
+	}
+}
+
+func (c *recordCache) servable(r *cachedRecord, now time.Time) bool {
+	return r.Expire.After(now) || c.stale(r, now)
+}
+
+// persist restores the records kept in the storage under the key, and keeps the records there from now on.
+func (c *recordCache) persist(scope storage.ScopedPersistentStorage, key string) error {
+	c.Lock()
+	defer c.Unlock()
+
+	c.storage = scope
+	c.key = []byte(key)
+
+	data, err := scope.Get(context.Background(), c.key)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	records := new(CachedRecords)
+	if err := proto.Unmarshal(data, records); err != nil {
+		return newError("failed to decode cached records of ", key).Base(err)
+	}
+	now := time.Now()
+	for _, r := range records.Record {
+		rec := &cachedRecord{
+			IPRecord: &IPRecord{
+				RCode:  dnsmessage.RCode(r.Rcode),
+				Expire: time.Unix(r.Expire, 0),
+				DNSSEC: dns_feature.DNSSECStatus(r.Dnssec),
+			},
+			updated: time.Unix(r.Updated, 0),
+		}
+		for _, ip := range r.Ip {
+			rec.IP = append(rec.IP, net.IPAddress(ip))
+		}
+		if !c.servable(rec, now) {
+			continue
+		}
+		entry := c.entries[r.Domain]
+		if entry == nil {
+			entry = &cacheEntry{}
+			c.entries[r.Domain] = entry
+		}
+		if r.Ipv6 {
+			entry.AAAA = rec
+		} else {
+			entry.A = rec
+		}
+	}
+	return nil
+}
+
+// cacheSnapshot is the encoded records of a cache to save in its storage.
+type cacheSnapshot struct {
+	storage    storage.ScopedPersistentStorage
+	key        []byte
+	data       []byte
+	generation uint64
+}
+
+// snapshot returns the records to save, or nil if the cache is not persisted. c must be locked.
+func (c *recordCache) snapshot() *cacheSnapshot {
+	if c.storage == nil {
+		return nil
+	}
+	records := new(CachedRecords)
+	for domain, entry := range c.entries {
+		for _, r := range []*cachedRecord{entry.A, entry.AAAA} {
+			if r == nil {
+				continue
+			}
+			persisted := &CachedRecords_Record{
+				Domain:  domain,
+				Ipv6:    r == entry.AAAA,
+				Rcode:   uint32(r.RCode),
+				Updated: r.updated.Unix(),
+				Expire:  r.Expire.Unix(),
+				Dnssec:  uint32(r.DNSSEC),
+			}
+			for _, ip := range r.IP {
+				persisted.Ip = append(persisted.Ip, []byte(ip.IP()))
+			}
+			records.Record = append(records.Record, persisted)
+		}
+	}
+	data, err := proto.Marshal(records)
+	if err != nil {
+		return nil
+	}
+	c.generation++
+	return &cacheSnapshot{
+		storage:    c.storage,
+		key:        c.key,
+		data:       data,
+		generation: c.generation,
+	}
+}
+
+// write saves the snapshot in the storage, unless a newer one is saved.
+func (c *recordCache) write(snapshot *cacheSnapshot) {
+	c.saveAccess.Lock()
+	defer c.saveAccess.Unlock()
+
+	if snapshot.generation <= c.saved {
+		return
+	}
+	c.saved = snapshot.generation
+	if err := snapshot.storage.Put(context.Background(), snapshot.key, snapshot.data); err != nil {
+		newError("failed to persist cached records of ", string(snapshot.key)).Base(err).AtWarning().WriteToLog()
+	}
+}
+
+// close saves the records, if the cache is persisted, and stops persisting them.
+func (c *recordCache) close() {
+	c.Lock()
+	snapshot := c.snapshot()
+	c.storage = nil
+	c.Unlock()
+
+	if snapshot != nil {
+		c.write(snapshot)
+	}
+}
@@ -0,0 +1,267 @@
+package dns
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/strmatcher"
+	dns_feature "github.com/v2fly/v2ray-core/v4/features/dns"
+	"github.com/v2fly/v2ray-core/v4/features/extension/storage"
+)
+
+type memoryStorage map[string][]byte
+
+func (memoryStorage) ScopedPersistentStorageEngine() {}
+
+func (s memoryStorage) Put(ctx context.Context, key []byte, value []byte) error {
+	s[string(key)] = value
+	return nil
+}
+
+func (s memoryStorage) Get(ctx context.Context, key []byte) ([]byte, error) {
+	return s[string(key)], nil
+}
+
+func (s memoryStorage) List(ctx context.Context, keyPrefix []byte) ([][]byte, error) {
+	return nil, nil
+}
+
+func (s memoryStorage) ClearIfCharacteristicMismatch(ctx context.Context, characteristic []byte) error {
+	return nil
+}
+
+func (s memoryStorage) NarrowScope(ctx context.Context, key []byte) (storage.ScopedPersistentStorage, error) {
+	return s, nil
+}
+
+func newTestRecord(ip string, ttl time.Duration) *IPRecord {
+	return &IPRecord{
+		IP:     []net.Address{net.ParseAddress(ip)},
+		Expire: time.Now().Add(ttl),
+		RCode:  dnsmessage.RCodeSuccess,
+	}
+}
+
+func TestRecordCacheClampTTL(t *testing.T) {
+	cache := newRecordCache()
+	cache.setOptions(cacheOptions{minTTL: time.Minute, maxTTL: time.Hour})
+
+	cache.update("short.v2fly.org.", record{A: newTestRecord("1.1.1.1", time.Second)})
+	cache.update("long.v2fly.org.", record{A: newTestRecord("2.2.2.2", 24*time.Hour)})
+
+	if r, _ := cache.get("short.v2fly.org."); time.Until(r.A.Expire) < 59*time.Second {
+		t.Error("expected TTL to be raised to min TTL, but expires in ", time.Until(r.A.Expire))
+	}
+	if r, _ := cache.get("long.v2fly.org."); time.Until(r.A.Expire) > time.Hour {
+		t.Error("expected TTL to be lowered to max TTL, but expires in ", time.Until(r.A.Expire))
+	}
+}
+
+func TestRecordCacheServeStale(t *testing.T) {
+	cache := newRecordCache()
+	option := dns_feature.IPOption{IPv4Enable: true}
+
+	cache.update("v2fly.org.", record{A: newTestRecord("1.1.1.1", -time.Second)})
+	if r, _ := cache.get("v2fly.org."); r.A != nil {
+		t.Error("expected expired record not to be served without serve-stale")
+	}
+	if cache.markRefresh("v2fly.org.", option) {
+		t.Error("expected expired record not to be refreshed without serve-stale")
+	}
+
+	cache.setOptions(cacheOptions{serveStale: time.Hour})
+	r, _ := cache.get("v2fly.org.")
+	ips, err := r.A.getIPs()
+	common.Must(err)
+	if len(ips) != 1 || ips[0].String() != "1.1.1.1" {
+		t.Error("unexpected stale IPs: ", ips)
+	}
+	if !cache.markRefresh("v2fly.org.", option) {
+		t.Error("expected stale record to be refreshed")
+	}
+	if cache.markRefresh("v2fly.org.", option) {
+		t.Error("expected stale record to be refreshed only once")
+	}
+
+	failed := newTestRecord("", time.Minute)
+	failed.IP = nil
+	failed.RCode = dnsmessage.RCodeServerFailure
+	if cache.update("v2fly.org.", record{A: failed}) {
+		t.Error("expected failed refresh not to replace stale record")
+	}
+	if r, _ := cache.get("v2fly.org."); r.A == nil || r.A.RCode != dnsmessage.RCodeSuccess {
+		t.Error("expected stale record to be served after failed refresh, but got ", r.A)
+	}
+
+	if !cache.update("v2fly.org.", record{A: newTestRecord("2.2.2.2", time.Minute)}) {
+		t.Error("expected refreshed record to replace stale one")
+	}
+	cache.cleanup()
+	if r, _ := cache.get("v2fly.org."); r.A == nil || r.A.IP[0].String() != "2.2.2.2" {
+		t.Error("unexpected record after refresh: ", r.A)
+	}
+}
+
+func TestRecordCachePrefetch(t *testing.T) {
+	cache := newRecordCache()
+	cache.setOptions(cacheOptions{prefetch: true})
+	option := dns_feature.IPOption{IPv4Enable: true, IPv6Enable: true}
+
+	cache.update("v2fly.org.", record{AAAA: newTestRecord("::1", time.Second)})
+	cache.entries["v2fly.org."].AAAA.updated = time.Now().Add(-time.Minute)
+	cache.get("v2fly.org.")
+	if cache.markRefresh("v2fly.org.", option) {
+		t.Error("expected record hit once not to be prefetched")
+	}
+	cache.get("v2fly.org.")
+	if !cache.markRefresh("v2fly.org.", option) {
+		t.Error("expected popular record about to expire to be prefetched")
+	}
+
+	cache.update("example.com.", record{A: newTestRecord("1.1.1.1", time.Hour)})
+	cache.get("example.com.")
+	cache.get("example.com.")
+	if cache.markRefresh("example.com.", option) {
+		t.Error("expected record far from expiry not to be prefetched")
+	}
+}
+
+func TestRecordCachePersist(t *testing.T) {
+	storage := make(memoryStorage)
+
+	cache := newRecordCache()
+	common.Must(cache.persist(storage, "UDP:1.1.1.1:53"))
+	cache.update("v2fly.org.", record{
+		A:    newTestRecord("1.1.1.1", time.Hour),
+		AAAA: newTestRecord("::1", time.Hour),
+	})
+	cache.close()
+
+	restored := newRecordCache()
+	common.Must(restored.persist(storage, "UDP:1.1.1.1:53"))
+	r, found := restored.get("v2fly.org.")
+	if !found || r.A == nil || r.AAAA == nil {
+		t.Fatal("expected records to be restored, but got ", r)
+	}
+	if r.A.IP[0].IP().String() != "1.1.1.1" || r.AAAA.IP[0].IP().String() != "::1" {
+		t.Error("unexpected restored records: ", r.A.IP, r.AAAA.IP)
+	}
+}
+
+// blockingStorage blocks each Put until it is released.
+type blockingStorage struct {
+	memoryStorage
+	access  sync.Mutex
+	put     chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingStorage) Put(ctx context.Context, key []byte, value []byte) error {
+	s.put <- struct{}{}
+	<-s.release
+	s.access.Lock()
+	defer s.access.Unlock()
+	return s.memoryStorage.Put(ctx, key, value)
+}
+
+func TestRecordCacheSaveUnlocked(t *testing.T) {
+	storage := &blockingStorage{
+		memoryStorage: make(memoryStorage),
+		put:           make(chan struct{}),
+		release:       make(chan struct{}),
+	}
+
+	cache := newRecordCache()
+	common.Must(cache.persist(storage, "UDP:1.1.1.1:53"))
+	cache.update("v2fly.org.", record{A: newTestRecord("1.1.1.1", time.Hour)})
+	cache.cleanup()
+	<-storage.put
+
+	// The cache is usable while the records are being written.
+	cache.update("www.v2fly.org.", record{A: newTestRecord("2.2.2.2", time.Hour)})
+	if _, found := cache.get("www.v2fly.org."); !found {
+		t.Error("expected record to be cached")
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		cache.close()
+		close(closed)
+	}()
+	storage.release <- struct{}{}
+	<-storage.put
+	storage.release <- struct{}{}
+	<-closed
+
+	// A closed cache no longer saves its records.
+	cache.update("example.com.", record{A: newTestRecord("3.3.3.3", time.Hour)})
+	cache.cleanup()
+
+	restored := newRecordCache()
+	common.Must(restored.persist(storage.memoryStorage, "UDP:1.1.1.1:53"))
+	if _, found := restored.get("www.v2fly.org."); !found {
+		t.Error("expected records saved on close to be restored")
+	}
+	if _, found := restored.get("example.com."); found {
+		t.Error("unexpected record saved after close")
+	}
+}
+
+// cachedTestServer is a name server answering from its cache only.
+type cachedTestServer struct {
+	name  string
+	cache *recordCache
+}
+
+func (s *cachedTestServer) Name() string {
+	return s.name
+}
+
+func (s *cachedTestServer) QueryIP(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption, disableCache bool) ([]net.IP, error) {
+	return nil, dns_feature.ErrEmptyResponse
+}
+
+func (s *cachedTestServer) getCache() *recordCache {
+	return s.cache
+}
+
+func TestLookupDNSSECPrioritizedServer(t *testing.T) {
+	newServer := func(name string, status dns_feature.DNSSECStatus) *cachedTestServer {
+		s := &cachedTestServer{name: name, cache: newRecordCache()}
+		rec := newTestRecord("1.2.3.4", time.Minute)
+		rec.DNSSEC = status
+		s.cache.update("www.v2fly.org.", record{A: rec})
+		return s
+	}
+	hosts, err := NewStaticHosts(nil, nil)
+	common.Must(err)
+	matcher := &strmatcher.MatcherGroup{}
+	rule, err := toStrMatcher(DomainMatchingType_Subdomain, "v2fly.org")
+	common.Must(err)
+	matcherInfos := make([]DomainMatcherInfo, 2)
+	matcherInfos[matcher.Add(rule)] = DomainMatcherInfo{clientIdx: 1}
+
+	s := &DNS{
+		ipOption: &dns_feature.IPOption{IPv4Enable: true, IPv6Enable: true},
+		hosts:    hosts,
+		clients: []*Client{
+			{server: newServer("fallback", dns_feature.DNSSECInsecure)},
+			{
+				server:    newServer("prioritized", dns_feature.DNSSECSecure),
+				domains:   []string{"v2fly.org"},
+				overrides: []*domainOverride{nil},
+			},
+		},
+		domainMatcher: matcher,
+		matcherInfos:  matcherInfos,
+	}
+	if status := s.LookupDNSSEC("www.v2fly.org"); status != dns_feature.DNSSECSecure {
+		t.Error("expected status of the prioritized name server, but got ", status)
+	}
+}
@@ -14,15 +14,20 @@ import (
 	"github.com/v2fly/v2ray-core/v4/infra/conf/geodata"
 	"strings"
 	"sync"
+	"time"
 
+	core "github.com/v2fly/v2ray-core/v4"
 	"github.com/v2fly/v2ray-core/v4/app/router"
 	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/environment"
+	"github.com/v2fly/v2ray-core/v4/common/environment/envctx"
 	"github.com/v2fly/v2ray-core/v4/common/errors"
 	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/common/session"
 	"github.com/v2fly/v2ray-core/v4/common/strmatcher"
 	"github.com/v2fly/v2ray-core/v4/features"
 	"github.com/v2fly/v2ray-core/v4/features/dns"
+	"github.com/v2fly/v2ray-core/v4/features/tracing"
 )
 
 // DNS is a DNS rely server.
@@ -38,6 +43,10 @@ type DNS struct {
 	ctx                    context.Context
 	domainMatcher          strmatcher.IndexMatcher
 	matcherInfos           []DomainMatcherInfo
+	persistCache           bool
+
+	access      sync.RWMutex
+	replacement *DNS
 }
 
 // DomainMatcherInfo contains information attached to index returned by Server.domainMatcher
@@ -138,6 +147,33 @@ func New(ctx context.Context, config *Config) (*DNS, error) {
 		clients = append(clients, NewLocalDNSClient())
 	}
 
+	cache := cacheOptions{
+		serveStale: time.Duration(config.ServeStale) * time.Second,
+		prefetch:   config.Prefetch,
+		minTTL:     time.Duration(config.MinTtl) * time.Second,
+		maxTTL:     time.Duration(config.MaxTtl) * time.Second,
+	}
+	if cache.maxTTL > 0 && cache.minTTL > cache.maxTTL {
+		return nil, newError("min TTL ", config.MinTtl, " is greater than max TTL ", config.MaxTtl)
+	}
+	for _, client := range clients {
+		if server, ok := client.server.(cachedServer); ok {
+			server.getCache().setOptions(cache)
+		}
+	}
+
+	if config.Dnssec {
+		anchors, err := parseTrustAnchors(config.TrustAnchor)
+		if err != nil {
+			return nil, err
+		}
+		for _, client := range clients {
+			if server, ok := client.server.(validatingServer); ok {
+				server.enableDNSSEC(anchors)
+			}
+		}
+	}
+
 	return &DNS{
 		tag:                    tag,
 		hosts:                  hosts,
@@ -149,6 +185,7 @@ func New(ctx context.Context, config *Config) (*DNS, error) {
 		disableCache:           config.DisableCache,
 		disableFallback:        config.DisableFallback,
 		disableFallbackIfMatch: config.DisableFallbackIfMatch,
+		persistCache:           config.PersistCache,
 	}, nil
 }
 
@@ -157,48 +194,174 @@ func (*DNS) Type() interface{} {
 	return dns.ClientType()
 }
 
-// Start implements common.Runnable.
+// Start implements common.Runnable. It restores the cached records, if the cache is persisted.
 func (s *DNS) Start() error {
+	if s.persistCache {
+		return s.openCache()
+	}
 	return nil
 }
 
-// Close implements common.Closable.
+// Close implements common.Closable. Cached records are saved, if the cache is persisted.
 func (s *DNS) Close() error {
+	s.closeCache()
+	if next := s.current(); next != s {
+		next.closeCache()
+	}
 	return nil
 }
 
+// openCache restores the cached records of each name server from persistent storage, and keeps them there from now on.
+func (s *DNS) openCache() error {
+	env, ok := envctx.EnvironmentFromContext(s.ctx).(environment.AppEnvironment)
+	if !ok {
+		return newError("cache persistence requires an app environment")
+	}
+	scope := env.PersistentStorage()
+	if scope == nil {
+		return newError("cache persistence is enabled, but there is no persistent storage")
+	}
+	for _, client := range s.clients {
+		if server, ok := client.server.(cachedServer); ok {
+			if err := server.getCache().persist(scope, client.Name()); err != nil {
+				newError("failed to restore DNS cache").Base(err).AtWarning().WriteToLog()
+			}
+		}
+	}
+	return nil
+}
+
+func (s *DNS) closeCache() {
+	for _, client := range s.clients {
+		if server, ok := client.server.(cachedServer); ok {
+			server.getCache().close()
+		}
+	}
+}
+
+// ReplaceWith implements features.Reloadable. Queries made afterwards are served by the new DNS,
+// which takes over the IP option changes made to this one at runtime, such as enabling FakeDNS.
+func (s *DNS) ReplaceWith(feature features.Feature) error {
+	next, ok := feature.(*DNS)
+	if !ok {
+		return newError("cannot replace DNS with ", feature.Type())
+	}
+	if next.ipOption != nil {
+		if option := s.current().ipOption; option != nil {
+			next.ipOption.FakeEnable = option.FakeEnable
+		}
+	}
+
+	// The records of both are kept under the same keys, so those of the replaced one are saved before the new one
+	// restores them.
+	prev := s.current()
+	prev.closeCache()
+	if next.persistCache {
+		if err := next.openCache(); err != nil {
+			if prev.persistCache {
+				if err := prev.openCache(); err != nil {
+					newError("failed to reopen DNS cache").Base(err).AtWarning().WriteToLog()
+				}
+			}
+			return err
+		}
+	}
+
+	s.access.Lock()
+	defer s.access.Unlock()
+	s.replacement = next
+	return nil
+}
+
+// current returns the DNS serving queries, which is s itself unless it has been replaced.
+func (s *DNS) current() *DNS {
+	s.access.RLock()
+	defer s.access.RUnlock()
+	if s.replacement != nil {
+		return s.replacement
+	}
+	return s
+}
+
 // IsOwnLink implements proxy.dns.ownLinkVerifier
 func (s *DNS) IsOwnLink(ctx context.Context) bool {
 	inbound := session.InboundFromContext(ctx)
-	return inbound != nil && inbound.Tag == s.tag
+	if inbound == nil {
+		return false
+	}
+	// Queries sent before a reload carry the old tag.
+	return inbound.Tag == s.tag || inbound.Tag == s.current().tag
 }
 
 // LookupIP implements dns.Client.
 func (s *DNS) LookupIP(domain string) ([]net.IP, error) {
-	return s.lookupIPInternal(domain, *s.ipOption)
+	s = s.current()
+	return s.lookupIPInternal(context.Background(), domain, *s.ipOption)
+}
+
+// LookupIPContext implements dns.ContextIPLookup.
+func (s *DNS) LookupIPContext(ctx context.Context, domain string, option dns.IPOption) ([]net.IP, error) {
+	ctx, span := tracing.Start(ctx, "dns.lookup", tracing.String("domain", domain))
+	defer span.End()
+
+	ips, err := s.current().lookupIPInternal(ctx, domain, option)
+	span.RecordError(err)
+	span.SetAttributes(tracing.Int("dns.answers", int64(len(ips))))
+	return ips, err
+}
+
+// LookupDNSSEC implements dns.DNSSECLookup. The status is of the cached answer from the name server the domain is
+// resolved with, walking the name servers in the order they are queried.
+func (s *DNS) LookupDNSSEC(domain string) dns.DNSSECStatus {
+	s = s.current()
+	domain = strings.TrimSuffix(domain, ".")
+	switch addrs := s.hosts.Lookup(domain, *s.ipOption); {
+	case addrs == nil:
+	case len(addrs) == 1 && addrs[0].Family().IsDomain():
+		domain = addrs[0].Domain()
+	default: // Static hosts are not validated
+		return dns.DNSSECIndeterminate
+	}
+
+	fqdn := Fqdn(domain)
+	clients, _ := s.sortClients(domain)
+	for _, client := range clients {
+		if !s.ipOption.FakeEnable && strings.EqualFold(client.Name(), "FakeDNS") {
+			continue
+		}
+		if server, ok := client.server.(cachedServer); ok {
+			if status, found := server.getCache().dnssec(fqdn); found {
+				return status
+			}
+		}
+	}
+	return dns.DNSSECIndeterminate
 }
 
 // LookupIPv4 implements dns.IPv4Lookup.
 func (s *DNS) LookupIPv4(domain string) ([]net.IP, error) {
+	s = s.current()
 	if !s.ipOption.IPv4Enable {
 		return nil, dns.ErrEmptyResponse
 	}
 	o := *s.ipOption
 	o.IPv6Enable = false
-	return s.lookupIPInternal(domain, o)
+	return s.lookupIPInternal(context.Background(), domain, o)
 }
 
 // LookupIPv6 implements dns.IPv6Lookup.
 func (s *DNS) LookupIPv6(domain string) ([]net.IP, error) {
+	s = s.current()
 	if !s.ipOption.IPv6Enable {
 		return nil, dns.ErrEmptyResponse
 	}
 	o := *s.ipOption
 	o.IPv4Enable = false
-	return s.lookupIPInternal(domain, o)
+	return s.lookupIPInternal(context.Background(), domain, o)
 }
 
-func (s *DNS) lookupIPInternal(domain string, option dns.IPOption) ([]net.IP, error) {
+// lookupIPInternal looks up the domain, tracing the query of each name server under the span in the context.
+func (s *DNS) lookupIPInternal(parent context.Context, domain string, option dns.IPOption) ([]net.IP, error) {
 	if domain == "" {
 		return nil, newError("empty domain name")
 	}
@@ -223,12 +386,19 @@ func (s *DNS) lookupIPInternal(domain string, option dns.IPOption) ([]net.IP, er
 	// Name servers lookup
 	errs := []error{}
 	ctx := session.ContextWithInbound(s.ctx, &session.Inbound{Tag: s.tag})
-	for _, client := range s.sortClients(domain) {
+	if span := tracing.SpanFromContext(parent); span != nil {
+		ctx = tracing.ContextWithSpan(ctx, span)
+	}
+	clients, overrides := s.sortClients(domain)
+	for i, client := range clients {
 		if !option.FakeEnable && strings.EqualFold(client.Name(), "FakeDNS") {
 			newError("skip DNS resolution for domain ", domain, " at server ", client.Name()).AtDebug().WriteToLog()
 			continue
 		}
-		ips, err := client.QueryIP(ctx, domain, option, s.disableCache)
+		queryCtx, span := tracing.Start(ctx, "dns.query", tracing.String("dns.server", client.Name()))
+		ips, err := client.queryIP(queryCtx, domain, overrides[i], option, s.disableCache)
+		span.RecordError(err)
+		span.End()
 		if len(ips) > 0 {
 			return ips, nil
 		}
@@ -244,24 +414,99 @@ func (s *DNS) lookupIPInternal(domain string, option dns.IPOption) ([]net.IP, er
 	return nil, newError("returning nil for domain ", domain).Base(errors.Combine(errs...))
 }
 
+// LookupCNAME implements dns.CNAMELookup. Domains proxied by static hosts are aliases of their proxied domains.
+func (s *DNS) LookupCNAME(domain string) string {
+	s = s.current()
+	return s.hosts.LookupAlias(strings.TrimSuffix(domain, "."))
+}
+
+// LookupPTR implements dns.PTRLookup. Domains are looked up in static hosts and FakeDNS.
+func (s *DNS) LookupPTR(ip net.IP) []string {
+	s = s.current()
+	addr := net.IPAddress(ip)
+	if addr == nil {
+		return nil
+	}
+	domains := s.hosts.LookupDomains(addr)
+	if v := core.FromContext(s.ctx); v != nil {
+		if fakeDNS, ok := v.GetFeature((*dns.FakeDNSEngine)(nil)).(dns.FakeDNSEngine); ok {
+			if domain := fakeDNS.GetDomainFromFakeDNS(addr); domain != "" {
+				domains = append(domains, domain)
+			}
+		}
+	}
+	return domains
+}
+
+// LookupTXT implements dns.TXTLookup. Domains in static hosts have no text records, unless they are proxied to other
+// domains, whose text records are looked up instead.
+func (s *DNS) LookupTXT(ctx context.Context, domain string) ([]string, error) {
+	s = s.current()
+	domain = strings.TrimSuffix(domain, ".")
+	if domain == "" {
+		return nil, newError("empty domain name")
+	}
+	switch addrs := s.hosts.Lookup(domain, dns.IPOption{IPv4Enable: true, IPv6Enable: true}); {
+	case addrs == nil:
+	case len(addrs) == 1 && addrs[0].Family().IsDomain():
+		domain = addrs[0].Domain()
+	default:
+		return nil, dns.ErrEmptyResponse
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(time.Second * 5)
+	}
+	errs := []error{}
+	clients, _ := s.sortClients(domain)
+	for _, client := range clients {
+		queryCtx, cancel := context.WithDeadline(session.ContextWithInbound(s.ctx, &session.Inbound{Tag: s.tag}), deadline)
+		var txts []string
+		var err error
+		switch server := client.server.(type) {
+		case rawServer:
+			txts, err = queryTXT(queryCtx, domain, server.rawExchange)
+		case *LocalNameServer:
+			txts, err = server.queryTXT(queryCtx, domain)
+		default:
+			cancel()
+			continue
+		}
+		cancel()
+		if err == nil || dns.RCodeFromError(err) != 0 || err == dns.ErrEmptyResponse {
+			return txts, err
+		}
+		newError("failed to lookup TXT for domain ", domain, " at server ", client.Name()).Base(err).WriteToLog()
+		errs = append(errs, err)
+	}
+	return nil, newError("returning nil for TXT of domain ", domain).Base(errors.Combine(errs...))
+}
+
 // GetIPOption implements ClientWithIPOption.
 func (s *DNS) GetIPOption() *dns.IPOption {
+	s = s.current()
 	return s.ipOption
 }
 
 // SetQueryOption implements ClientWithIPOption.
 func (s *DNS) SetQueryOption(isIPv4Enable, isIPv6Enable bool) {
+	s = s.current()
 	s.ipOption.IPv4Enable = isIPv4Enable
 	s.ipOption.IPv6Enable = isIPv6Enable
 }
 
 // SetFakeDNSOption implements ClientWithIPOption.
 func (s *DNS) SetFakeDNSOption(isFakeEnable bool) {
+	s = s.current()
 	s.ipOption.FakeEnable = isFakeEnable
 }
 
-func (s *DNS) sortClients(domain string) []*Client {
+// sortClients returns the clients to query the domain with in order, along with the overrides of the domain rules
+// matched, which are nil for clients not matched.
+func (s *DNS) sortClients(domain string) ([]*Client, []*domainOverride) {
 	clients := make([]*Client, 0, len(s.clients))
+	overrides := make([]*domainOverride, 0, len(s.clients))
 	clientUsed := make([]bool, len(s.clients))
 	clientNames := make([]string, 0, len(s.clients))
 	domainRules := []string{}
@@ -278,6 +523,7 @@ func (s *DNS) sortClients(domain string) []*Client {
 		}
 		clientUsed[info.clientIdx] = true
 		clients = append(clients, client)
+		overrides = append(overrides, client.overrides[info.domainRuleIdx])
 		clientNames = append(clientNames, client.Name())
 		hasMatch = true
 	}
@@ -290,6 +536,7 @@ func (s *DNS) sortClients(domain string) []*Client {
 			}
 			clientUsed[idx] = true
 			clients = append(clients, client)
+			overrides = append(overrides, nil)
 			clientNames = append(clientNames, client.Name())
 		}
 	}
@@ -303,11 +550,12 @@ func (s *DNS) sortClients(domain string) []*Client {
 
 	if len(clients) == 0 {
 		clients = append(clients, s.clients[0])
+		overrides = append(overrides, nil)
 		clientNames = append(clientNames, s.clients[0].Name())
 		newError("domain ", domain, " will use the first DNS: ", clientNames).AtDebug().WriteToLog()
 	}
 
-	return clients
+	return clients, overrides
 }
 
 func init() {
@@ -366,6 +614,14 @@ func init() {
 					Domain: prioritizedDomain.Domain,
 				})
 			}
+			for _, originalRule := range v.OriginalRules {
+				nameserver.OriginalRules = append(nameserver.OriginalRules, &NameServer_OriginalRule{
+					Rule:          originalRule.Rule,
+					Size:          originalRule.Size,
+					ClientIp:      net.ParseIP(originalRule.ClientIp),
+					QueryStrategy: originalRule.QueryStrategy,
+				})
+			}
 			nameservers = append(nameservers, nameserver)
 		}
 
@@ -377,6 +633,13 @@ func init() {
 			DisableCache:    simplifiedConfig.DisableCache,
 			QueryStrategy:   simplifiedConfig.QueryStrategy,
 			DisableFallback: simplifiedConfig.DisableFallback,
+			ServeStale:      simplifiedConfig.ServeStale,
+			Prefetch:        simplifiedConfig.Prefetch,
+			MinTtl:          simplifiedConfig.MinTtl,
+			MaxTtl:          simplifiedConfig.MaxTtl,
+			PersistCache:    simplifiedConfig.PersistCache,
+			Dnssec:          simplifiedConfig.Dnssec,
+			TrustAnchor:     simplifiedConfig.TrustAnchor,
 		}
 		return common.CreateObject(ctx, fullConfig)
 	}))
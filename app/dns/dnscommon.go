@@ -1,6 +1,7 @@
 package dns
 
 import (
+	"context"
 	"encoding/binary"
 	"strings"
 	"time"
@@ -32,12 +33,16 @@ type IPRecord struct {
 	IP     []net.Address
 	Expire time.Time
 	RCode  dnsmessage.RCode
+	DNSSEC dns_feature.DNSSECStatus
 }
 
 func (r *IPRecord) getIPs() ([]net.Address, error) {
 	if r == nil || r.Expire.Before(time.Now()) {
 		return nil, errRecordNotFound
 	}
+	if r.DNSSEC == dns_feature.DNSSECBogus {
+		return nil, dns_feature.RCodeBogus
+	}
 	if r.RCode != dnsmessage.RCodeSuccess {
 		return nil, dns_feature.RCodeError(r.RCode)
 	}
@@ -163,13 +168,18 @@ func buildReqMsgs(domain string, option dns_feature.IPOption, reqIDGen func() ui
 	return reqs
 }
 
-// parseResponse parse DNS answers from the returned payload
+// parseResponse parse DNS answers from the returned payload. Only the addresses of the question name, or of the names
+// it is an alias of, are taken.
 func parseResponse(payload []byte) (*IPRecord, error) {
 	var parser dnsmessage.Parser
 	h, err := parser.Start(payload)
 	if err != nil {
 		return nil, newError("failed to parse DNS response").Base(err).AtWarning()
 	}
+	question, err := parser.Question()
+	if err != nil && err != dnsmessage.ErrSectionDone {
+		return nil, newError("failed to parse question in DNS response").Base(err).AtWarning()
+	}
 	if err := parser.SkipAllQuestions(); err != nil {
 		return nil, newError("failed to skip questions in DNS response").Base(err).AtWarning()
 	}
@@ -181,6 +191,7 @@ func parseResponse(payload []byte) (*IPRecord, error) {
 		Expire: now.Add(time.Second * 600),
 	}
 
+	var answers []dnsmessage.Resource
 L:
 	for {
 		ah, err := parser.AnswerHeader()
@@ -191,15 +202,6 @@ L:
 			break
 		}
 
-		ttl := ah.TTL
-		if ttl == 0 {
-			ttl = 600
-		}
-		expire := now.Add(time.Duration(ttl) * time.Second)
-		if ipRecord.Expire.After(expire) {
-			ipRecord.Expire = expire
-		}
-
 		switch ah.Type {
 		case dnsmessage.TypeA:
 			ans, err := parser.AResource()
@@ -207,22 +209,124 @@ L:
 				newError("failed to parse A record for domain: ", ah.Name).Base(err).WriteToLog()
 				break L
 			}
-			ipRecord.IP = append(ipRecord.IP, net.IPAddress(ans.A[:]))
+			answers = append(answers, dnsmessage.Resource{Header: ah, Body: &ans})
 		case dnsmessage.TypeAAAA:
 			ans, err := parser.AAAAResource()
 			if err != nil {
 				newError("failed to parse AAAA record for domain: ", ah.Name).Base(err).WriteToLog()
 				break L
 			}
-			ipRecord.IP = append(ipRecord.IP, net.IPAddress(ans.AAAA[:]))
+			answers = append(answers, dnsmessage.Resource{Header: ah, Body: &ans})
+		case dnsmessage.TypeCNAME:
+			ans, err := parser.CNAMEResource()
+			if err != nil {
+				newError("failed to parse CNAME record for domain: ", ah.Name).Base(err).WriteToLog()
+				break L
+			}
+			answers = append(answers, dnsmessage.Resource{Header: ah, Body: &ans})
 		default:
 			if err := parser.SkipAnswer(); err != nil {
 				newError("failed to skip answer").Base(err).WriteToLog()
 				break L
 			}
+		}
+	}
+
+	owners := aliasesOf(question.Name, answers)
+	for _, answer := range answers {
+		if !owners[strings.ToLower(answer.Header.Name.String())] {
+			newError("ignore answer of ", answer.Header.Name, " for domain: ", question.Name).AtDebug().WriteToLog()
 			continue
 		}
+
+		ttl := answer.Header.TTL
+		if ttl == 0 {
+			ttl = 600
+		}
+		expire := now.Add(time.Duration(ttl) * time.Second)
+		if ipRecord.Expire.After(expire) {
+			ipRecord.Expire = expire
+		}
+
+		switch body := answer.Body.(type) {
+		case *dnsmessage.AResource:
+			ipRecord.IP = append(ipRecord.IP, net.IPAddress(body.A[:]))
+		case *dnsmessage.AAAAResource:
+			ipRecord.IP = append(ipRecord.IP, net.IPAddress(body.AAAA[:]))
+		}
 	}
 
 	return ipRecord, nil
 }
+
+// aliasesOf follows the CNAME chain from the name in the answers, and returns the names in it in lower case. Records of
+// other names are not answers of the name.
+func aliasesOf(name dnsmessage.Name, answers []dnsmessage.Resource) map[string]bool {
+	owners := make(map[string]bool)
+	if name.Length == 0 {
+		return owners
+	}
+	owners[strings.ToLower(name.String())] = true
+	for i := 0; i < len(answers); i++ {
+		changed := false
+		for _, answer := range answers {
+			if cname, ok := answer.Body.(*dnsmessage.CNAMEResource); ok && owners[strings.ToLower(answer.Header.Name.String())] {
+				target := strings.ToLower(cname.CNAME.String())
+				if !owners[target] {
+					owners[target] = true
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return owners
+}
+
+// queryTXT sends a TXT query of the domain with the exchange function, and returns the text records of the domain, or
+// of the names it is an alias of, in the response.
+func queryTXT(ctx context.Context, domain string, exchange func(ctx context.Context, query []byte) ([]byte, error)) ([]string, error) {
+	name, err := dnsmessage.NewName(Fqdn(domain))
+	if err != nil {
+		return nil, newError("invalid domain ", domain).Base(err)
+	}
+	query := &dnsmessage.Message{
+		Header: dnsmessage.Header{
+			RecursionDesired: true,
+		},
+		Questions: []dnsmessage.Question{{
+			Name:  name,
+			Type:  dnsmessage.TypeTXT,
+			Class: dnsmessage.ClassINET,
+		}},
+	}
+	b, err := query.Pack()
+	if err != nil {
+		return nil, newError("failed to pack TXT query").Base(err)
+	}
+	b, err = exchange(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	var resp dnsmessage.Message
+	if err := resp.Unpack(b); err != nil {
+		return nil, newError("failed to parse TXT response").Base(err)
+	}
+	if resp.RCode != dnsmessage.RCodeSuccess {
+		return nil, dns_feature.RCodeError(resp.RCode)
+	}
+
+	owners := aliasesOf(name, resp.Answers)
+	var txts []string
+	for _, answer := range resp.Answers {
+		if txt, ok := answer.Body.(*dnsmessage.TXTResource); ok && owners[strings.ToLower(answer.Header.Name.String())] {
+			txts = append(txts, strings.Join(txt.TXT, ""))
+		}
+	}
+	if len(txts) == 0 {
+		return nil, dns_feature.ErrEmptyResponse
+	}
+	return txts, nil
+}
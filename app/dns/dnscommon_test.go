@@ -24,6 +24,7 @@ func Test_parseResponse(t *testing.T) {
 	p = append(p, []byte{})
 
 	ans = new(dns.Msg)
+	ans.SetQuestion("google.com.", dns.TypeA)
 	ans.Id = 1
 	ans.Answer = append(ans.Answer,
 		common.Must2(dns.NewRR("google.com. IN CNAME m.test.google.com")).(dns.RR),
@@ -34,6 +35,7 @@ func Test_parseResponse(t *testing.T) {
 	p = append(p, common.Must2(ans.Pack()).([]byte))
 
 	ans = new(dns.Msg)
+	ans.SetQuestion("google.com.", dns.TypeAAAA)
 	ans.Id = 2
 	ans.Answer = append(ans.Answer,
 		common.Must2(dns.NewRR("google.com. IN CNAME m.test.google.com")).(dns.RR),
@@ -45,6 +47,16 @@ func Test_parseResponse(t *testing.T) {
 	)
 	p = append(p, common.Must2(ans.Pack()).([]byte))
 
+	ans = new(dns.Msg)
+	ans.SetQuestion("v2fly.org.", dns.TypeA)
+	ans.Id = 3
+	ans.Answer = append(ans.Answer,
+		common.Must2(dns.NewRR("v2fly.org. IN CNAME www.v2fly.org")).(dns.RR),
+		common.Must2(dns.NewRR("www.v2fly.org. IN A 1.1.1.1")).(dns.RR),
+		common.Must2(dns.NewRR("example.com. IN A 2.2.2.2")).(dns.RR),
+	)
+	p = append(p, common.Must2(ans.Pack()).([]byte))
+
 	tests := []struct {
 		name    string
 		want    *IPRecord
@@ -52,7 +64,7 @@ func Test_parseResponse(t *testing.T) {
 	}{
 		{
 			"empty",
-			&IPRecord{0, []net.Address(nil), time.Time{}, dnsmessage.RCodeSuccess},
+			&IPRecord{0, []net.Address(nil), time.Time{}, dnsmessage.RCodeSuccess, dns_feature.DNSSECIndeterminate},
 			false,
 		},
 		{
@@ -67,12 +79,18 @@ func Test_parseResponse(t *testing.T) {
 				[]net.Address{net.ParseAddress("8.8.8.8"), net.ParseAddress("8.8.4.4")},
 				time.Time{},
 				dnsmessage.RCodeSuccess,
+				dns_feature.DNSSECIndeterminate,
 			},
 			false,
 		},
 		{
 			"aaaa record",
-			&IPRecord{2, []net.Address{net.ParseAddress("2001::123:8888"), net.ParseAddress("2001::123:8844")}, time.Time{}, dnsmessage.RCodeSuccess},
+			&IPRecord{2, []net.Address{net.ParseAddress("2001::123:8888"), net.ParseAddress("2001::123:8844")}, time.Time{}, dnsmessage.RCodeSuccess, dns_feature.DNSSECIndeterminate},
+			false,
+		},
+		{
+			"other owner",
+			&IPRecord{3, []net.Address{net.ParseAddress("1.1.1.1")}, time.Time{}, dnsmessage.RCodeSuccess, dns_feature.DNSSECIndeterminate},
 			false,
 		},
 	}
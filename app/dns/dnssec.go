@@ -0,0 +1,696 @@
+package dns
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	mdns "github.com/miekg/dns"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	dns_feature "github.com/v2fly/v2ray-core/v4/features/dns"
+)
+
+// rootTrustAnchor is the DS record of the root zone KSK-2017, the default trust anchor.
+const rootTrustAnchor = ". 86400 IN DS 20326 8 2 E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D"
+
+const (
+	// bogusTTL is how long bogus answers are cached.
+	bogusTTL = time.Minute
+	// zoneTTL is how long the validated keys of a zone are cached at most.
+	zoneTTL = time.Hour
+	// maxZones is how many zones are cached at most by a validator.
+	maxZones = 1024
+	// nsec3OptOut is the opt-out flag of NSEC3 records.
+	nsec3OptOut = 1
+)
+
+// trustAnchors are the DS records of the zones validation starts from, keyed by the zones.
+type trustAnchors map[string][]*mdns.DS
+
+// parseTrustAnchors parses DS or DNSKEY records in presentation format. The root zone KSK is used if none is given.
+func parseTrustAnchors(records []string) (trustAnchors, error) {
+	if len(records) == 0 {
+		records = []string{rootTrustAnchor}
+	}
+	anchors := make(trustAnchors)
+	for _, record := range records {
+		rr, err := mdns.NewRR(record)
+		if err != nil || rr == nil {
+			return nil, newError("invalid trust anchor ", record).Base(err)
+		}
+		name := mdns.CanonicalName(rr.Header().Name)
+		switch rr := rr.(type) {
+		case *mdns.DS:
+			anchors[name] = append(anchors[name], rr)
+		case *mdns.DNSKEY:
+			anchors[name] = append(anchors[name], rr.ToDS(mdns.SHA256))
+		default:
+			return nil, newError("trust anchor ", record, " is neither a DS nor a DNSKEY record")
+		}
+	}
+	return anchors, nil
+}
+
+// validatingServer is a name server whose answers can be validated with DNSSEC.
+type validatingServer interface {
+	enableDNSSEC(anchors trustAnchors)
+}
+
+// zone is the validated state of the zone a name belongs to.
+type zone struct {
+	apex   string
+	status dns_feature.DNSSECStatus
+	keys   []*mdns.DNSKEY
+	expire time.Time
+}
+
+type rrsetKey struct {
+	name   string
+	rrtype uint16
+}
+
+// validator validates the answers of a name server with DNSSEC. Zones are validated from the trust anchors down, with
+// DS and DNSKEY records queried from the same name server.
+type validator struct {
+	sync.Mutex
+	anchors  trustAnchors
+	exchange func(ctx context.Context, query []byte) ([]byte, error)
+	zones    map[string]*zone
+}
+
+func newValidator(anchors trustAnchors, exchange func(ctx context.Context, query []byte) ([]byte, error)) *validator {
+	return &validator{
+		anchors:  anchors,
+		exchange: exchange,
+		zones:    make(map[string]*zone),
+	}
+}
+
+// ednsOptions returns the EDNS0 options of queries. Queries of validating name servers always request DNSSEC records.
+func (v *validator) ednsOptions(clientIP net.IP) *dnsmessage.Resource {
+	opt := genEDNS0Options(clientIP)
+	if v == nil || opt != nil {
+		return opt
+	}
+	opt = new(dnsmessage.Resource)
+	common.Must(opt.Header.SetEDNS0(1232, dnsmessage.RCodeSuccess, true))
+	opt.Body = &dnsmessage.OPTResource{}
+	return opt
+}
+
+// validate sets the DNSSEC status of the record parsed from the response. It does nothing if v is nil.
+func (v *validator) validate(ctx context.Context, resp []byte, rec *IPRecord) {
+	if v == nil {
+		return
+	}
+	msg := new(mdns.Msg)
+	if err := msg.Unpack(resp); err != nil || len(msg.Question) != 1 {
+		rec.DNSSEC = dns_feature.DNSSECBogus
+	} else {
+		status, err := v.check(ctx, msg)
+		if status == dns_feature.DNSSECBogus {
+			newError("bogus answer of ", msg.Question[0].Name, " ", mdns.TypeToString[msg.Question[0].Qtype]).Base(err).AtWarning().WriteToLog()
+		}
+		rec.DNSSEC = status
+	}
+	if rec.DNSSEC == dns_feature.DNSSECBogus {
+		if expire := time.Now().Add(bogusTTL); rec.Expire.After(expire) {
+			rec.Expire = expire
+		}
+	}
+}
+
+// check validates the answer section of the message. Records in it must be of the question name, or of the names it
+// is an alias of. If the question is not answered, the authority section must prove that the name the alias chain ends
+// at does not exist, or does not have records of the type.
+func (v *validator) check(ctx context.Context, msg *mdns.Msg) (dns_feature.DNSSECStatus, error) {
+	if msg.Rcode != mdns.RcodeSuccess && msg.Rcode != mdns.RcodeNameError {
+		return dns_feature.DNSSECIndeterminate, nil
+	}
+	q := msg.Question[0]
+	sets, sigs := splitRRsets(msg.Answer)
+	name, err := followAliases(q, sets, sigs)
+	if err != nil {
+		return dns_feature.DNSSECBogus, err
+	}
+
+	status := dns_feature.DNSSECSecure
+	for key, set := range sets {
+		s, err := v.verifyRRset(ctx, set, sigs[key])
+		if s == dns_feature.DNSSECBogus {
+			return s, err
+		}
+		if weaker(s, status) {
+			status = s
+		}
+	}
+	if _, found := sets[rrsetKey{name, q.Qtype}]; found && msg.Rcode == mdns.RcodeSuccess {
+		return status, nil
+	}
+
+	s, err := v.deny(ctx, name, q.Qtype, msg.Rcode == mdns.RcodeNameError, msg.Ns)
+	if weaker(s, status) {
+		status = s
+	}
+	return status, err
+}
+
+// followAliases follows the CNAME and DNAME records in the answer from the question name, and returns the name the
+// chain ends at. CNAME records synthesized from DNAME records are unsigned, and removed from the RRsets once they are
+// checked against the DNAME records.
+func followAliases(q mdns.Question, sets map[rrsetKey][]mdns.RR, sigs map[rrsetKey][]*mdns.RRSIG) (string, error) {
+	name := mdns.CanonicalName(q.Name)
+	chain := map[string]bool{name: true}
+	dnames := make(map[rrsetKey]bool)
+	for q.Qtype != mdns.TypeCNAME {
+		if _, found := sets[rrsetKey{name, q.Qtype}]; found {
+			break
+		}
+		var next, synthesized string
+		for key, set := range sets {
+			if key.rrtype == mdns.TypeDNAME && key.name != name && mdns.IsSubDomain(key.name, name) {
+				dnames[key] = true
+				synthesized = strings.TrimSuffix(name, key.name) + mdns.CanonicalName(set[0].(*mdns.DNAME).Target)
+				next = synthesized
+			}
+		}
+		key := rrsetKey{name, mdns.TypeCNAME}
+		if set, found := sets[key]; found {
+			next = mdns.CanonicalName(set[0].(*mdns.CNAME).Target)
+			if synthesized != "" {
+				if next != synthesized {
+					return "", newError("CNAME of ", name, " does not match DNAME")
+				}
+				if len(sigs[key]) == 0 {
+					delete(sets, key)
+				}
+			}
+		}
+		if next == "" || chain[next] {
+			break
+		}
+		name = next
+		chain[name] = true
+	}
+	for key := range sets {
+		if !chain[key.name] && !dnames[key] {
+			return "", newError("unexpected ", mdns.TypeToString[key.rrtype], " of ", key.name, " in answer of ", q.Name)
+		}
+	}
+	return name, nil
+}
+
+// deny checks the proof in the authority section that the name does not exist, or does not have records of the type.
+// The proof is required if the zone of the name is signed.
+func (v *validator) deny(ctx context.Context, name string, rrtype uint16, nxdomain bool, authority []mdns.RR) (dns_feature.DNSSECStatus, error) {
+	z, err := v.zone(ctx, name)
+	if err != nil {
+		return dns_feature.DNSSECBogus, err
+	}
+	if z.status != dns_feature.DNSSECSecure {
+		return z.status, nil
+	}
+
+	var soa bool
+	var nsecs []*mdns.NSEC
+	var nsec3s []*mdns.NSEC3
+	sets, sigs := splitRRsets(authority)
+	for key, set := range sets {
+		switch key.rrtype {
+		case mdns.TypeSOA, mdns.TypeNSEC, mdns.TypeNSEC3:
+		default:
+			continue
+		}
+		if err := z.verify(set, sigs[key]); err != nil {
+			return dns_feature.DNSSECBogus, newError("failed to verify proof of nonexistence of ", name).Base(err)
+		}
+		for _, rr := range set {
+			switch rr := rr.(type) {
+			case *mdns.SOA:
+				soa = key.name == z.apex
+			case *mdns.NSEC:
+				nsecs = append(nsecs, rr)
+			case *mdns.NSEC3:
+				nsec3s = append(nsec3s, rr)
+			}
+		}
+	}
+	if !soa {
+		return dns_feature.DNSSECBogus, newError("missing SOA of ", z.apex, " in proof of nonexistence of ", name)
+	}
+
+	var proven bool
+	switch {
+	case nxdomain && len(nsecs) > 0:
+		proven = nsecDenyName(nsecs, name)
+	case nxdomain:
+		proven = nsec3DenyName(nsec3s, name)
+	case len(nsecs) > 0:
+		proven = nsecDenyType(nsecs, name, rrtype)
+	default:
+		proven = nsec3DenyType(nsec3s, name, rrtype)
+	}
+	if !proven {
+		return dns_feature.DNSSECBogus, newError("missing proof of nonexistence of ", name, " ", mdns.TypeToString[rrtype])
+	}
+	return dns_feature.DNSSECSecure, nil
+}
+
+// nsecDenyName returns whether the NSEC records prove that neither the name nor a wildcard matching it exists.
+func nsecDenyName(nsecs []*mdns.NSEC, name string) bool {
+	for _, rr := range nsecs {
+		if !nsecCovers(rr, name) {
+			continue
+		}
+		// The closest encloser is the longest ancestor of the name that the covering record proves to exist.
+		encloser := parentName(name)
+		for encloser != "." && !mdns.IsSubDomain(encloser, mdns.CanonicalName(rr.Hdr.Name)) && !mdns.IsSubDomain(encloser, mdns.CanonicalName(rr.NextDomain)) {
+			encloser = parentName(encloser)
+		}
+		wildcard := "*." + encloser
+		if encloser == "." {
+			wildcard = "*."
+		}
+		for _, w := range nsecs {
+			if nsecCovers(w, wildcard) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nsecDenyType returns whether the NSEC records prove that the name exists but does not have records of the type.
+func nsecDenyType(nsecs []*mdns.NSEC, name string, rrtype uint16) bool {
+	for _, rr := range nsecs {
+		if mdns.CanonicalName(rr.Hdr.Name) == name {
+			return !hasType(rr.TypeBitMap, rrtype)
+		}
+	}
+	return false
+}
+
+// nsec3DenyName returns whether the NSEC3 records prove the closest encloser of the name, that the next closer name
+// does not exist, and that no wildcard of the closest encloser exists.
+func nsec3DenyName(nsec3s []*mdns.NSEC3, name string) bool {
+	for next, encloser := name, parentName(name); next != "."; next, encloser = encloser, parentName(encloser) {
+		if !nsec3Match(nsec3s, encloser) {
+			continue
+		}
+		return nsec3Cover(nsec3s, next) && nsec3Cover(nsec3s, "*."+strings.TrimPrefix(encloser, "."))
+	}
+	return false
+}
+
+// nsec3DenyType returns whether the NSEC3 records prove that the name exists but does not have records of the type.
+func nsec3DenyType(nsec3s []*mdns.NSEC3, name string, rrtype uint16) bool {
+	for _, rr := range nsec3s {
+		if rr.Match(name) {
+			return !hasType(rr.TypeBitMap, rrtype)
+		}
+	}
+	return false
+}
+
+func nsec3Match(nsec3s []*mdns.NSEC3, name string) bool {
+	for _, rr := range nsec3s {
+		if rr.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func nsec3Cover(nsec3s []*mdns.NSEC3, name string) bool {
+	for _, rr := range nsec3s {
+		if rr.Cover(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasType returns whether the type, or a CNAME record which answers any type, is in the type bitmap.
+func hasType(types []uint16, rrtype uint16) bool {
+	for _, t := range types {
+		if t == rrtype || t == mdns.TypeCNAME {
+			return true
+		}
+	}
+	return false
+}
+
+// weaker returns whether status a is less trusted than b.
+func weaker(a, b dns_feature.DNSSECStatus) bool {
+	rank := func(s dns_feature.DNSSECStatus) int {
+		switch s {
+		case dns_feature.DNSSECSecure:
+			return 3
+		case dns_feature.DNSSECInsecure:
+			return 2
+		case dns_feature.DNSSECIndeterminate:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return rank(a) < rank(b)
+}
+
+// verifyRRset verifies the signatures of the RRset with the keys of the signer zone. An unsigned RRset is insecure,
+// if its zone is proven unsigned.
+func (v *validator) verifyRRset(ctx context.Context, set []mdns.RR, sigs []*mdns.RRSIG) (dns_feature.DNSSECStatus, error) {
+	owner := mdns.CanonicalName(set[0].Header().Name)
+	if len(sigs) == 0 {
+		z, err := v.zone(ctx, owner)
+		if err != nil {
+			return dns_feature.DNSSECBogus, err
+		}
+		if z.status == dns_feature.DNSSECSecure {
+			return dns_feature.DNSSECBogus, newError("missing signature of ", owner)
+		}
+		return z.status, nil
+	}
+
+	signer := mdns.CanonicalName(sigs[0].SignerName)
+	if !mdns.IsSubDomain(signer, owner) {
+		return dns_feature.DNSSECBogus, newError("signer ", signer, " is not an ancestor of ", owner)
+	}
+	z, err := v.zone(ctx, signer)
+	if err != nil {
+		return dns_feature.DNSSECBogus, err
+	}
+	if z.status != dns_feature.DNSSECSecure {
+		return z.status, nil
+	}
+	if err := z.verify(set, sigs); err != nil {
+		return dns_feature.DNSSECBogus, err
+	}
+	return dns_feature.DNSSECSecure, nil
+}
+
+// verify verifies the RRset with any of the signatures made by the keys of the zone.
+func (z *zone) verify(set []mdns.RR, sigs []*mdns.RRSIG) error {
+	if len(sigs) == 0 {
+		return newError("missing signature of ", set[0].Header().Name)
+	}
+	return verifySignatures(set, sigs, z.apex, z.keys)
+}
+
+func verifySignatures(set []mdns.RR, sigs []*mdns.RRSIG, signer string, keys []*mdns.DNSKEY) error {
+	now := time.Now()
+	err := newError("no valid signature of ", set[0].Header().Name, " by ", signer)
+	for _, sig := range sigs {
+		if mdns.CanonicalName(sig.SignerName) != signer {
+			continue
+		}
+		if !sig.ValidityPeriod(now) {
+			err = newError("signature of ", set[0].Header().Name, " is expired or not yet valid")
+			continue
+		}
+		for _, key := range keys {
+			if key.KeyTag() != sig.KeyTag || key.Algorithm != sig.Algorithm {
+				continue
+			}
+			if e := sig.Verify(key, set); e == nil {
+				return nil
+			}
+		}
+	}
+	return err
+}
+
+// zone returns the validated state of the zone the name belongs to.
+func (v *validator) zone(ctx context.Context, name string) (*zone, error) {
+	name = mdns.CanonicalName(name)
+
+	v.Lock()
+	z, found := v.zones[name]
+	if found && !time.Now().Before(z.expire) {
+		delete(v.zones, name)
+		found = false
+	}
+	v.Unlock()
+	if found {
+		return z, nil
+	}
+
+	var err error
+	if ds, found := v.anchors[name]; found {
+		z, err = v.apexZone(ctx, name, ds)
+	} else if name == "." {
+		// No trust anchor covers the name.
+		z = &zone{apex: name, status: dns_feature.DNSSECIndeterminate, expire: time.Now().Add(zoneTTL)}
+	} else {
+		var parent *zone
+		parent, err = v.zone(ctx, parentName(name))
+		if err == nil {
+			z, err = v.childZone(ctx, parent, name)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	v.store(name, z)
+	return z, nil
+}
+
+// store caches the zone the name belongs to. If the cache is full, expired zones are removed, or the one expiring
+// first if none is.
+func (v *validator) store(name string, z *zone) {
+	v.Lock()
+	defer v.Unlock()
+
+	if _, found := v.zones[name]; !found && len(v.zones) >= maxZones {
+		now := time.Now()
+		var first string
+		for n, cached := range v.zones {
+			if !now.Before(cached.expire) {
+				delete(v.zones, n)
+			} else if first == "" || cached.expire.Before(v.zones[first].expire) {
+				first = n
+			}
+		}
+		if len(v.zones) >= maxZones {
+			delete(v.zones, first)
+		}
+	}
+	v.zones[name] = z
+}
+
+func parentName(name string) string {
+	if i, end := mdns.NextLabel(name, 0); !end {
+		return name[i:]
+	}
+	return "."
+}
+
+// childZone returns the zone the name belongs to, under the parent zone. The name is the apex of a zone if there is a
+// DS record of it, or the cut of an unsigned zone if there is a delegation but no DS record. Otherwise, it belongs to
+// the parent zone.
+func (v *validator) childZone(ctx context.Context, parent *zone, name string) (*zone, error) {
+	if parent.status != dns_feature.DNSSECSecure {
+		return parent, nil
+	}
+	msg, err := v.query(ctx, name, mdns.TypeDS)
+	if err != nil {
+		return nil, err
+	}
+	sets, sigs := splitRRsets(msg.Answer)
+	key := rrsetKey{name, mdns.TypeDS}
+	if set := sets[key]; len(set) > 0 {
+		if err := parent.verify(set, sigs[key]); err != nil {
+			return nil, newError("failed to verify DS of ", name).Base(err)
+		}
+		ds := make([]*mdns.DS, 0, len(set))
+		ttl := zoneTTL
+		for _, rr := range set {
+			ds = append(ds, rr.(*mdns.DS))
+			if t := time.Duration(rr.Header().Ttl) * time.Second; t < ttl {
+				ttl = t
+			}
+		}
+		z, err := v.apexZone(ctx, name, ds)
+		if err != nil {
+			return nil, err
+		}
+		// The keys are trusted as long as the DS records are.
+		if expire := time.Now().Add(ttl); z.expire.After(expire) {
+			z.expire = expire
+		}
+		return z, nil
+	}
+
+	cut, err := parent.denyDS(name, msg.Ns)
+	if err != nil {
+		return nil, err
+	}
+	if !cut {
+		return parent, nil
+	}
+	return &zone{apex: name, status: dns_feature.DNSSECInsecure, expire: parent.expire}, nil
+}
+
+// denyDS checks the proof of absence of a DS record of the name in the authority section. It returns whether the name
+// is a delegation, that is the cut of an unsigned zone.
+func (z *zone) denyDS(name string, authority []mdns.RR) (bool, error) {
+	sets, sigs := splitRRsets(authority)
+	for key, set := range sets {
+		if key.rrtype != mdns.TypeNSEC && key.rrtype != mdns.TypeNSEC3 {
+			continue
+		}
+		if err := z.verify(set, sigs[key]); err != nil {
+			return false, newError("failed to verify proof of absence of DS of ", name).Base(err)
+		}
+		for _, rr := range set {
+			switch rr := rr.(type) {
+			case *mdns.NSEC:
+				if mdns.CanonicalName(rr.Hdr.Name) == name {
+					return isDelegation(rr.TypeBitMap)
+				}
+				if nsecCovers(rr, name) {
+					return false, nil
+				}
+			case *mdns.NSEC3:
+				if rr.Match(name) {
+					return isDelegation(rr.TypeBitMap)
+				}
+				if rr.Cover(name) {
+					return rr.Flags&nsec3OptOut != 0, nil
+				}
+			}
+		}
+	}
+	return false, newError("missing proof of absence of DS of ", name)
+}
+
+func isDelegation(types []uint16) (bool, error) {
+	var ns, soa bool
+	for _, t := range types {
+		switch t {
+		case mdns.TypeDS:
+			return false, newError("DS is denied, but present in type bitmap")
+		case mdns.TypeNS:
+			ns = true
+		case mdns.TypeSOA:
+			soa = true
+		}
+	}
+	return ns && !soa, nil
+}
+
+// nsecCovers returns whether the name is between the owner and the next name of the NSEC record in canonical order.
+func nsecCovers(rr *mdns.NSEC, name string) bool {
+	owner := mdns.CanonicalName(rr.Hdr.Name)
+	next := mdns.CanonicalName(rr.NextDomain)
+	if canonicalLess(owner, next) {
+		return canonicalLess(owner, name) && canonicalLess(name, next)
+	}
+	// The last NSEC record of the zone wraps to the apex.
+	return canonicalLess(owner, name) || canonicalLess(name, next)
+}
+
+// canonicalLess compares names in canonical order of RFC 4034, that is label by label from the rightmost.
+func canonicalLess(a, b string) bool {
+	la := mdns.SplitDomainName(a)
+	lb := mdns.SplitDomainName(b)
+	for i, j := len(la)-1, len(lb)-1; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if c := strings.Compare(strings.ToLower(la[i]), strings.ToLower(lb[j])); c != 0 {
+			return c < 0
+		}
+	}
+	return len(la) < len(lb)
+}
+
+// apexZone validates the keys of the zone with its DS records.
+func (v *validator) apexZone(ctx context.Context, name string, ds []*mdns.DS) (*zone, error) {
+	msg, err := v.query(ctx, name, mdns.TypeDNSKEY)
+	if err != nil {
+		return nil, err
+	}
+	sets, sigs := splitRRsets(msg.Answer)
+	key := rrsetKey{name, mdns.TypeDNSKEY}
+	set := sets[key]
+	if len(set) == 0 {
+		return nil, newError("missing DNSKEY of ", name)
+	}
+
+	ttl := zoneTTL
+	keys := make([]*mdns.DNSKEY, 0, len(set))
+	var trusted []*mdns.DNSKEY
+	for _, rr := range set {
+		k := rr.(*mdns.DNSKEY)
+		keys = append(keys, k)
+		if t := time.Duration(k.Hdr.Ttl) * time.Second; t < ttl {
+			ttl = t
+		}
+		for _, d := range ds {
+			if k.KeyTag() != d.KeyTag || k.Algorithm != d.Algorithm {
+				continue
+			}
+			if digest := k.ToDS(d.DigestType); digest != nil && strings.EqualFold(digest.Digest, d.Digest) {
+				trusted = append(trusted, k)
+				break
+			}
+		}
+	}
+	if len(trusted) == 0 {
+		return nil, newError("no DNSKEY of ", name, " matches its DS")
+	}
+	if err := verifySignatures(set, sigs[key], name, trusted); err != nil {
+		return nil, newError("failed to verify DNSKEY of ", name).Base(err)
+	}
+	return &zone{
+		apex:   name,
+		status: dns_feature.DNSSECSecure,
+		keys:   keys,
+		expire: time.Now().Add(ttl),
+	}, nil
+}
+
+// query sends a query with DNSSEC records requested to the name server.
+func (v *validator) query(ctx context.Context, name string, rrtype uint16) (*mdns.Msg, error) {
+	q := new(mdns.Msg)
+	q.SetQuestion(name, rrtype)
+	q.SetEdns0(1232, true)
+	b, err := q.Pack()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.exchange(ctx, b)
+	if err != nil {
+		return nil, newError("failed to query ", mdns.TypeToString[rrtype], " of ", name).Base(err)
+	}
+	msg := new(mdns.Msg)
+	if err := msg.Unpack(resp); err != nil {
+		return nil, newError("failed to parse ", mdns.TypeToString[rrtype], " of ", name).Base(err)
+	}
+	if msg.Rcode != mdns.RcodeSuccess && msg.Rcode != mdns.RcodeNameError {
+		return nil, newError("failed to query ", mdns.TypeToString[rrtype], " of ", name, ": ", mdns.RcodeToString[msg.Rcode])
+	}
+	return msg, nil
+}
+
+// splitRRsets groups the records into RRsets, and the signatures by the RRsets they cover.
+func splitRRsets(rrs []mdns.RR) (map[rrsetKey][]mdns.RR, map[rrsetKey][]*mdns.RRSIG) {
+	sets := make(map[rrsetKey][]mdns.RR)
+	sigs := make(map[rrsetKey][]*mdns.RRSIG)
+	for _, rr := range rrs {
+		name := mdns.CanonicalName(rr.Header().Name)
+		switch rr := rr.(type) {
+		case *mdns.RRSIG:
+			key := rrsetKey{name, rr.TypeCovered}
+			sigs[key] = append(sigs[key], rr)
+		case *mdns.OPT:
+		default:
+			key := rrsetKey{name, rr.Header().Rrtype}
+			sets[key] = append(sets[key], rr)
+		}
+	}
+	return sets, sigs
+}
@@ -0,0 +1,248 @@
+package dns
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"testing"
+	"time"
+
+	mdns "github.com/miekg/dns"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/errors"
+	dns_feature "github.com/v2fly/v2ray-core/v4/features/dns"
+)
+
+type testZone struct {
+	name string
+	key  *mdns.DNSKEY
+	priv crypto.Signer
+}
+
+func newTestZone(name string) *testZone {
+	key := &mdns.DNSKEY{
+		Hdr:       mdns.RR_Header{Name: name, Rrtype: mdns.TypeDNSKEY, Class: mdns.ClassINET, Ttl: 3600},
+		Flags:     257,
+		Protocol:  3,
+		Algorithm: mdns.ECDSAP256SHA256,
+	}
+	priv, err := key.Generate(256)
+	common.Must(err)
+	return &testZone{name: name, key: key, priv: priv.(crypto.Signer)}
+}
+
+// sign returns the RRset with its signature by the zone.
+func (z *testZone) sign(rrs ...mdns.RR) []mdns.RR {
+	now := time.Now()
+	sig := &mdns.RRSIG{
+		Hdr:        mdns.RR_Header{Name: rrs[0].Header().Name, Rrtype: mdns.TypeRRSIG, Class: mdns.ClassINET, Ttl: 3600},
+		Algorithm:  z.key.Algorithm,
+		Expiration: uint32(now.Add(time.Hour).Unix()),
+		Inception:  uint32(now.Add(-time.Hour).Unix()),
+		KeyTag:     z.key.KeyTag(),
+		SignerName: z.name,
+	}
+	common.Must(sig.Sign(z.priv, rrs))
+	return append(rrs, sig)
+}
+
+func (z *testZone) ds() *mdns.DS {
+	return z.key.ToDS(mdns.SHA256)
+}
+
+func mustNewRR(s string) mdns.RR {
+	return common.Must2(mdns.NewRR(s)).(mdns.RR)
+}
+
+// newTestValidator creates a validator of a hierarchy where example.com is signed and insecure.com is not.
+func newTestValidator() (*validator, *testZone) {
+	root := newTestZone(".")
+	com := newTestZone("com.")
+	example := newTestZone("example.com.")
+
+	answers := map[rrsetKey][]mdns.RR{
+		{".", mdns.TypeDNSKEY}:            root.sign(root.key),
+		{"com.", mdns.TypeDS}:             root.sign(com.ds()),
+		{"com.", mdns.TypeDNSKEY}:         com.sign(com.key),
+		{"example.com.", mdns.TypeDS}:     com.sign(example.ds()),
+		{"example.com.", mdns.TypeDNSKEY}: example.sign(example.key),
+	}
+	authorities := map[rrsetKey][]mdns.RR{
+		{"insecure.com.", mdns.TypeDS}:    com.sign(mustNewRR("insecure.com. 3600 IN NSEC z.com. NS RRSIG NSEC")),
+		{"nx.example.com.", mdns.TypeDS}:  example.sign(mustNewRR("example.com. 3600 IN NSEC web.example.com. NS SOA RRSIG NSEC DNSKEY")),
+		{"web.example.com.", mdns.TypeDS}: example.sign(mustNewRR("web.example.com. 3600 IN NSEC www.example.com. A RRSIG NSEC")),
+		{"www.example.com.", mdns.TypeDS}: example.sign(mustNewRR("www.example.com. 3600 IN NSEC example.com. A RRSIG NSEC")),
+	}
+
+	exchange := func(ctx context.Context, query []byte) ([]byte, error) {
+		q := new(mdns.Msg)
+		common.Must(q.Unpack(query))
+		key := rrsetKey{mdns.CanonicalName(q.Question[0].Name), q.Question[0].Qtype}
+		resp := new(mdns.Msg)
+		resp.SetReply(q)
+		resp.Answer = answers[key]
+		resp.Ns = authorities[key]
+		return resp.Pack()
+	}
+	anchors := trustAnchors{".": {root.ds()}}
+	return newValidator(anchors, exchange), example
+}
+
+func TestValidatorCheck(t *testing.T) {
+	v, example := newTestValidator()
+	a := mustNewRR("www.example.com. 300 IN A 1.2.3.4")
+
+	tampered := example.sign(mustNewRR("www.example.com. 300 IN A 1.2.3.4"))
+	tampered[0].(*mdns.A).A[3] = 5
+
+	cases := []struct {
+		name   string
+		answer []mdns.RR
+		status dns_feature.DNSSECStatus
+	}{
+		{"signed", example.sign(a), dns_feature.DNSSECSecure},
+		{"tampered", tampered, dns_feature.DNSSECBogus},
+		{"stripped", []mdns.RR{mustNewRR("www.example.com. 300 IN A 1.2.3.4")}, dns_feature.DNSSECBogus},
+		{"unsigned zone", []mdns.RR{mustNewRR("www.insecure.com. 300 IN A 1.2.3.4")}, dns_feature.DNSSECInsecure},
+	}
+	for _, c := range cases {
+		msg := new(mdns.Msg)
+		msg.SetQuestion(c.answer[0].Header().Name, mdns.TypeA)
+		msg.Answer = c.answer
+		status, err := v.check(context.Background(), msg)
+		if status != c.status {
+			t.Error(c.name, ": expected ", c.status, ", but got ", status, " ", err)
+		}
+	}
+}
+
+func TestValidatorRejectsBogus(t *testing.T) {
+	v, _ := newTestValidator()
+
+	msg := new(mdns.Msg)
+	msg.SetQuestion("www.example.com.", mdns.TypeA)
+	msg.Answer = []mdns.RR{mustNewRR("www.example.com. 300 IN A 1.2.3.4")}
+	resp, err := msg.Pack()
+	common.Must(err)
+
+	rec, err := parseResponse(resp)
+	common.Must(err)
+	v.validate(context.Background(), resp, rec)
+	_, err = rec.getIPs()
+	if errors.Cause(err) != dns_feature.RCodeBogus {
+		t.Error("expected bogus answer to be rejected, but got ", err)
+	}
+	if rcode := dns_feature.RCodeFromError(err); rcode != mdns.RcodeServerFailure {
+		t.Error("expected bogus answer to be answered as SERVFAIL, but got ", rcode)
+	}
+}
+
+func TestValidatorCheckOwners(t *testing.T) {
+	v, example := newTestValidator()
+
+	cases := []struct {
+		name   string
+		answer []mdns.RR
+		status dns_feature.DNSSECStatus
+	}{
+		{
+			"alias",
+			append(
+				example.sign(mustNewRR("www.example.com. 300 IN CNAME web.example.com.")),
+				example.sign(mustNewRR("web.example.com. 300 IN A 1.2.3.4"))...,
+			),
+			dns_feature.DNSSECSecure,
+		},
+		{
+			"other owner",
+			example.sign(mustNewRR("web.example.com. 300 IN A 1.2.3.4")),
+			dns_feature.DNSSECBogus,
+		},
+		{
+			"other owner after alias",
+			append(
+				append(
+					example.sign(mustNewRR("www.example.com. 300 IN CNAME web.example.com.")),
+					example.sign(mustNewRR("web.example.com. 300 IN A 1.2.3.4"))...,
+				),
+				example.sign(mustNewRR("example.com. 300 IN A 5.6.7.8"))...,
+			),
+			dns_feature.DNSSECBogus,
+		},
+	}
+	for _, c := range cases {
+		msg := new(mdns.Msg)
+		msg.SetQuestion("www.example.com.", mdns.TypeA)
+		msg.Answer = c.answer
+		status, err := v.check(context.Background(), msg)
+		if status != c.status {
+			t.Error(c.name, ": expected ", c.status, ", but got ", status, " ", err)
+		}
+	}
+}
+
+func TestValidatorCheckDenial(t *testing.T) {
+	v, example := newTestValidator()
+	soa := example.sign(mustNewRR("example.com. 300 IN SOA ns.example.com. admin.example.com. 1 3600 600 86400 300"))
+	apex := example.sign(mustNewRR("example.com. 3600 IN NSEC web.example.com. NS SOA RRSIG NSEC DNSKEY"))
+	www := example.sign(mustNewRR("www.example.com. 3600 IN NSEC example.com. A RRSIG NSEC"))
+	// The only NSEC3 record of the zone matches the apex and covers any other name.
+	hash := mdns.HashName("example.com.", mdns.SHA1, 0, "")
+	nsec3 := example.sign(mustNewRR(hash + ".example.com. 3600 IN NSEC3 1 0 0 - " + hash + " NS SOA RRSIG DNSKEY NSEC3PARAM"))
+
+	cases := []struct {
+		name      string
+		question  string
+		rrtype    uint16
+		rcode     int
+		authority []mdns.RR
+		status    dns_feature.DNSSECStatus
+	}{
+		{"nodata", "www.example.com.", mdns.TypeAAAA, mdns.RcodeSuccess, append(append([]mdns.RR{}, soa...), www...), dns_feature.DNSSECSecure},
+		{"nodata of existing type", "www.example.com.", mdns.TypeA, mdns.RcodeSuccess, append(append([]mdns.RR{}, soa...), www...), dns_feature.DNSSECBogus},
+		{"nodata without proof", "www.example.com.", mdns.TypeAAAA, mdns.RcodeSuccess, soa, dns_feature.DNSSECBogus},
+		{"nodata without soa", "www.example.com.", mdns.TypeAAAA, mdns.RcodeSuccess, www, dns_feature.DNSSECBogus},
+		{"nxdomain", "nx.example.com.", mdns.TypeA, mdns.RcodeNameError, append(append([]mdns.RR{}, soa...), apex...), dns_feature.DNSSECSecure},
+		{"nxdomain by nsec3", "nx.example.com.", mdns.TypeA, mdns.RcodeNameError, append(append([]mdns.RR{}, soa...), nsec3...), dns_feature.DNSSECSecure},
+		{"nodata by nsec3", "example.com.", mdns.TypeA, mdns.RcodeSuccess, append(append([]mdns.RR{}, soa...), nsec3...), dns_feature.DNSSECSecure},
+		{"nodata of existing type by nsec3", "example.com.", mdns.TypeSOA, mdns.RcodeSuccess, append(append([]mdns.RR{}, soa...), nsec3...), dns_feature.DNSSECBogus},
+		{"nxdomain without proof", "nx.example.com.", mdns.TypeA, mdns.RcodeNameError, soa, dns_feature.DNSSECBogus},
+		{"nxdomain with nodata proof", "nx.example.com.", mdns.TypeA, mdns.RcodeNameError, append(append([]mdns.RR{}, soa...), www...), dns_feature.DNSSECBogus},
+		{"unsigned zone", "www.insecure.com.", mdns.TypeA, mdns.RcodeNameError, nil, dns_feature.DNSSECInsecure},
+	}
+	for _, c := range cases {
+		msg := new(mdns.Msg)
+		msg.SetQuestion(c.question, c.rrtype)
+		msg.Rcode = c.rcode
+		msg.Ns = c.authority
+		status, err := v.check(context.Background(), msg)
+		if status != c.status {
+			t.Error(c.name, ": expected ", c.status, ", but got ", status, " ", err)
+		}
+	}
+}
+
+func TestValidatorZoneCache(t *testing.T) {
+	v, _ := newTestValidator()
+	ctx := context.Background()
+
+	for i := 0; i < maxZones*2; i++ {
+		if _, err := v.zone(ctx, fmt.Sprint("host", i, ".insecure.com.")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := len(v.zones); n > maxZones {
+		t.Error("expected at most ", maxZones, " cached zones, but got ", n)
+	}
+
+	// An expired zone is validated again.
+	z, err := v.zone(ctx, "example.com.")
+	common.Must(err)
+	z.expire = time.Now()
+	refreshed, err := v.zone(ctx, "example.com.")
+	common.Must(err)
+	if refreshed == z || refreshed.status != dns_feature.DNSSECSecure {
+		t.Error("expired zone is not validated again")
+	}
+}
@@ -8,20 +8,39 @@ import (
 	"math"
 	"math/big"
 	gonet "net"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
 
 	"github.com/v2fly/v2ray-core/v4/common"
 	"github.com/v2fly/v2ray-core/v4/common/cache"
+	"github.com/v2fly/v2ray-core/v4/common/environment"
+	"github.com/v2fly/v2ray-core/v4/common/environment/envctx"
 	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/task"
 	"github.com/v2fly/v2ray-core/v4/features/dns"
+	"github.com/v2fly/v2ray-core/v4/features/extension/storage"
+)
+
+const (
+	stateStorageKey   = "state"
+	stateSaveInterval = time.Minute
 )
 
 type Holder struct {
 	domainToIP cache.Lru
 	nextIP     *big.Int
+	access     sync.Mutex
 
 	ipRange *gonet.IPNet
 
 	config *FakeDnsPool
+
+	ctx      context.Context
+	storage  storage.ScopedPersistentStorage
+	modified bool
+	saveTask *task.Periodic
 }
 
 func (fkdns *Holder) IsIPInIPPool(ip net.Address) bool {
@@ -45,12 +64,21 @@ func (*Holder) Type() interface{} {
 
 func (fkdns *Holder) Start() error {
 	if fkdns.config != nil && fkdns.config.IpPool != "" && fkdns.config.LruSize != 0 {
-		return fkdns.initializeFromConfig()
+		if err := fkdns.initializeFromConfig(); err != nil {
+			return err
+		}
+		return fkdns.startPersistence()
 	}
 	return newError("invalid fakeDNS setting")
 }
 
 func (fkdns *Holder) Close() error {
+	if fkdns.saveTask != nil {
+		fkdns.saveTask.Close()
+		if err := fkdns.saveState(); err != nil {
+			newError("failed to save fake DNS state").Base(err).AtWarning().WriteToLog()
+		}
+	}
 	fkdns.domainToIP = nil
 	fkdns.nextIP = nil
 	fkdns.ipRange = nil
@@ -72,7 +100,112 @@ func NewFakeDNSHolder() (*Holder, error) {
 }
 
 func NewFakeDNSHolderConfigOnly(conf *FakeDnsPool) (*Holder, error) {
-	return &Holder{nil, nil, nil, conf}, nil
+	return &Holder{config: conf}, nil
+}
+
+// SetPersistentStorage makes the holder restore its mapping from the given storage on start,
+// and save it back periodically and on close. It must be called before Start. Without it, the
+// persistent storage of the app environment, if any, is used.
+func (fkdns *Holder) SetPersistentStorage(s storage.ScopedPersistentStorage) {
+	fkdns.storage = s
+}
+
+func (fkdns *Holder) startPersistence() error {
+	if fkdns.storage == nil && fkdns.ctx != nil {
+		fkdns.storage = persistentStorageFromEnvironment(fkdns.ctx)
+	}
+	if fkdns.storage == nil {
+		return nil
+	}
+	ctx := context.Background()
+	poolStorage, err := fkdns.storage.NarrowScope(ctx, []byte(fkdns.config.IpPool))
+	if err != nil {
+		return newError("failed to open fake DNS state storage").Base(err)
+	}
+	fkdns.storage = poolStorage
+	// The state is only meaningful for the very same pool, so a change of setting discards it.
+	characteristic, err := proto.Marshal(fkdns.config)
+	if err != nil {
+		return newError("failed to marshal fake DNS setting").Base(err)
+	}
+	if err := fkdns.storage.ClearIfCharacteristicMismatch(ctx, characteristic); err != nil {
+		return newError("failed to check fake DNS state").Base(err)
+	}
+	if err := fkdns.loadState(); err != nil {
+		newError("failed to restore fake DNS state").Base(err).AtWarning().WriteToLog()
+	}
+	fkdns.saveTask = &task.Periodic{
+		Interval: stateSaveInterval,
+		Execute: func() error {
+			if err := fkdns.saveState(); err != nil {
+				newError("failed to save fake DNS state").Base(err).AtWarning().WriteToLog()
+			}
+			return nil
+		},
+	}
+	return fkdns.saveTask.Start()
+}
+
+// persistentStorageFromEnvironment returns the persistent storage of the app environment
+// in the context, or nil if there is none.
+func persistentStorageFromEnvironment(ctx context.Context) storage.ScopedPersistentStorage {
+	env, ok := envctx.EnvironmentFromContext(ctx).(environment.AppEnvironment)
+	if !ok {
+		return nil
+	}
+	return env.PersistentStorage()
+}
+
+func (fkdns *Holder) loadState() error {
+	data, err := fkdns.storage.Get(context.Background(), []byte(stateStorageKey))
+	if err == storage.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	state := new(FakeDnsPoolState)
+	if err := proto.Unmarshal(data, state); err != nil {
+		return newError("invalid fake DNS state").Base(err)
+	}
+
+	fkdns.access.Lock()
+	defer fkdns.access.Unlock()
+	for _, mapping := range state.Mappings {
+		if !fkdns.ipRange.Contains(mapping.Ip) {
+			continue
+		}
+		fkdns.domainToIP.Put(mapping.Domain, net.IPAddress(mapping.Ip))
+	}
+	if fkdns.ipRange.Contains(state.NextIp) {
+		fkdns.nextIP = big.NewInt(0).SetBytes(state.NextIp)
+	}
+	newError("restored ", len(state.Mappings), " fake DNS mappings for ", fkdns.config.IpPool).AtInfo().WriteToLog()
+	return nil
+}
+
+func (fkdns *Holder) saveState() error {
+	fkdns.access.Lock()
+	if !fkdns.modified {
+		fkdns.access.Unlock()
+		return nil
+	}
+	state := &FakeDnsPoolState{NextIp: fkdns.nextIP.Bytes()}
+	fkdns.domainToIP.Range(func(key, value interface{}) bool {
+		state.Mappings = append(state.Mappings, &FakeDnsPoolState_Mapping{
+			Domain: key.(string),
+			Ip:     value.(net.Address).IP(),
+		})
+		return true
+	})
+	fkdns.modified = false
+	fkdns.access.Unlock()
+
+	data, err := proto.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return fkdns.storage.Put(context.Background(), []byte(stateStorageKey), data)
 }
 
 func (fkdns *Holder) initializeFromConfig() error {
@@ -107,6 +240,9 @@ func (fkdns *Holder) initialize(ipPoolCidr string, lruSize int) error {
 
 // GetFakeIPForDomain check and generate a fake IP for a domain name
 func (fkdns *Holder) GetFakeIPForDomain(domain string) []net.Address {
+	fkdns.access.Lock()
+	defer fkdns.access.Unlock()
+
 	if v, ok := fkdns.domainToIP.Get(domain); ok {
 		return []net.Address{v.(net.Address)}
 	}
@@ -125,6 +261,7 @@ func (fkdns *Holder) GetFakeIPForDomain(domain string) []net.Address {
 		}
 	}
 	fkdns.domainToIP.Put(domain, ip)
+	fkdns.modified = true
 	return []net.Address{ip}
 }
 
@@ -182,6 +319,13 @@ func (h *HolderMulti) GetDomainFromFakeDNS(ip net.Address) string {
 	return ""
 }
 
+// SetPersistentStorage sets the storage of all pools. Each pool keeps its state in its own scope.
+func (h *HolderMulti) SetPersistentStorage(s storage.ScopedPersistentStorage) {
+	for _, v := range h.holders {
+		v.SetPersistentStorage(s)
+	}
+}
+
 func (h *HolderMulti) Type() interface{} {
 	return (*dns.FakeDNSEngine)(nil)
 }
@@ -234,6 +378,7 @@ func init() {
 		if f, err = NewFakeDNSHolderConfigOnly(config.(*FakeDnsPool)); err != nil {
 			return nil, err
 		}
+		f.ctx = ctx
 		return f, nil
 	}))
 
@@ -243,6 +388,9 @@ func init() {
 		if f, err = NewFakeDNSHolderMulti(config.(*FakeDnsPoolMulti)); err != nil {
 			return nil, err
 		}
+		for _, v := range f.holders {
+			v.ctx = ctx
+		}
 		return f, nil
 	}))
 }
@@ -1,11 +1,13 @@
 package fakedns
 
 import (
+	"context"
 	gonet "net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/v2fly/v2ray-core/v4/app/persistentstorage/filesystemstorage"
 	"github.com/v2fly/v2ray-core/v4/common"
 	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/common/uuid"
@@ -190,3 +192,34 @@ func TestFakeDNSMulti(t *testing.T) {
 		})
 	})
 }
+
+func TestFakeDnsHolderPersistence(t *testing.T) {
+	storageRoot := t.TempDir()
+	newHolder := func(lruSize int64) *Holder {
+		fkdns, err := NewFakeDNSHolderConfigOnly(&FakeDnsPool{
+			IpPool:  "240.0.0.0/12",
+			LruSize: lruSize,
+		})
+		common.Must(err)
+		fkdns.SetPersistentStorage(filesystemstorage.NewFileSystemStorage(context.Background(), &filesystemstorage.Config{
+			StateStorageRoot: storageRoot,
+		}))
+		common.Must(fkdns.Start())
+		return fkdns
+	}
+
+	fkdns := newHolder(256)
+	addr := fkdns.GetFakeIPForDomain("fakednstest.v2fly.org")
+	assert.Equal(t, "240.0.0.0", addr[0].IP().String())
+	common.Must(fkdns.Close())
+
+	fkdns = newHolder(256)
+	assert.Equal(t, "fakednstest.v2fly.org", fkdns.GetDomainFromFakeDNS(net.ParseAddress("240.0.0.0")))
+	addr = fkdns.GetFakeIPForDomain("fakednstest2.v2fly.org")
+	assert.Equal(t, "240.0.0.1", addr[0].IP().String())
+	common.Must(fkdns.Close())
+
+	fkdns = newHolder(128)
+	assert.Equal(t, "", fkdns.GetDomainFromFakeDNS(net.ParseAddress("240.0.0.0")))
+	common.Must(fkdns.Close())
+}
@@ -12,6 +12,8 @@ import (
 type StaticHosts struct {
 	ips      [][]net.Address
 	matchers *strmatcher.MatcherGroup
+	// domains are the full domains mapped to each IP, for reverse lookups.
+	domains map[string][]string
 }
 
 // NewStaticHosts creates a new StaticHosts instance.
@@ -20,6 +22,7 @@ func NewStaticHosts(hosts []*HostMapping, legacy map[string]*net.IPOrDomain) (*S
 	sh := &StaticHosts{
 		ips:      make([][]net.Address, len(hosts)+len(legacy)+16),
 		matchers: g,
+		domains:  make(map[string][]string),
 	}
 
 	if legacy != nil {
@@ -36,6 +39,7 @@ func NewStaticHosts(hosts []*HostMapping, legacy map[string]*net.IPOrDomain) (*S
 			}
 
 			sh.ips[id] = []net.Address{address}
+			sh.addDomain(address, domain)
 		}
 	}
 
@@ -62,11 +66,22 @@ func NewStaticHosts(hosts []*HostMapping, legacy map[string]*net.IPOrDomain) (*S
 		}
 
 		sh.ips[id] = ips
+		if mapping.Type == DomainMatchingType_Full {
+			for _, ip := range ips {
+				sh.addDomain(ip, mapping.Domain)
+			}
+		}
 	}
 
 	return sh, nil
 }
 
+func (h *StaticHosts) addDomain(ip net.Address, domain string) {
+	if ip.Family().IsIP() {
+		h.domains[ip.String()] = append(h.domains[ip.String()], domain)
+	}
+}
+
 func filterIP(ips []net.Address, option dns.IPOption) []net.Address {
 	filtered := make([]net.Address, 0, len(ips))
 	for _, ip := range ips {
@@ -107,3 +122,16 @@ func (h *StaticHosts) lookup(domain string, option dns.IPOption, maxDepth int) [
 func (h *StaticHosts) Lookup(domain string, option dns.IPOption) []net.Address {
 	return h.lookup(domain, option, 5)
 }
+
+// LookupAlias returns the proxied domain of the given domain, or an empty string if the domain is not proxied.
+func (h *StaticHosts) LookupAlias(domain string) string {
+	if addrs := h.lookupInternal(domain); len(addrs) == 1 && addrs[0].Family().IsDomain() {
+		return addrs[0].Domain()
+	}
+	return ""
+}
+
+// LookupDomains returns the full domains that are mapped to the given IP.
+func (h *StaticHosts) LookupDomains(ip net.Address) []string {
+	return h.domains[ip.String()]
+}
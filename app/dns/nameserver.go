@@ -13,6 +13,7 @@ import (
 	"github.com/v2fly/v2ray-core/v4/common/strmatcher"
 	"github.com/v2fly/v2ray-core/v4/features/dns"
 	"github.com/v2fly/v2ray-core/v4/features/routing"
+	"github.com/v2fly/v2ray-core/v4/transport/internet/tls"
 )
 
 // Server is the interface for Name Server.
@@ -23,19 +24,72 @@ type Server interface {
 	QueryIP(ctx context.Context, domain string, clientIP net.IP, option dns.IPOption, disableCache bool) ([]net.IP, error)
 }
 
+// rawServer is a Server that sends queries of any type as they are.
+type rawServer interface {
+	rawExchange(ctx context.Context, query []byte) ([]byte, error)
+}
+
 // Client is the interface for DNS client.
 type Client struct {
 	server       Server
 	clientIP     net.IP
 	skipFallback bool
 	domains      []string
+	overrides    []*domainOverride
 	expectIPs    []*router.GeoIPMatcher
 }
 
+// domainOverride overrides how a client queries the domains matching one of its domain rules.
+type domainOverride struct {
+	// clientIP replaces the client IP of the client if set. An unspecified IP disables it.
+	clientIP net.IP
+	strategy QueryStrategy
+}
+
+func newDomainOverride(rule *NameServer_OriginalRule) (*domainOverride, error) {
+	if len(rule.ClientIp) == 0 && rule.QueryStrategy == QueryStrategy_USE_IP {
+		return nil, nil
+	}
+	switch len(rule.ClientIp) {
+	case 0, net.IPv4len, net.IPv6len:
+	default:
+		return nil, newError("unexpected client IP length ", len(rule.ClientIp), " of rule ", rule.Rule)
+	}
+	clientIP := net.IP(rule.ClientIp)
+	if ip4 := clientIP.To4(); ip4 != nil {
+		clientIP = ip4
+	}
+	return &domainOverride{
+		clientIP: clientIP,
+		strategy: rule.QueryStrategy,
+	}, nil
+}
+
+// apply returns the client IP and IP option overridden. It returns them as is if o is nil.
+func (o *domainOverride) apply(clientIP net.IP, option dns.IPOption) (net.IP, dns.IPOption) {
+	if o == nil {
+		return clientIP, option
+	}
+	if len(o.clientIP) > 0 {
+		clientIP = o.clientIP
+		if clientIP.IsUnspecified() {
+			clientIP = nil
+		}
+	}
+	switch o.strategy {
+	case QueryStrategy_USE_IP4:
+		option.IPv6Enable = false
+	case QueryStrategy_USE_IP6:
+		option.IPv4Enable = false
+	}
+	return clientIP, option
+}
+
 var errExpectedIPNonMatch = errors.New("expectIPs not match")
 
-// NewServer creates a name server object according to the network destination url.
-func NewServer(dest net.Destination, dispatcher routing.Dispatcher) (Server, error) {
+// NewServer creates a name server object according to the network destination url. The TLS config is used by DNS
+// over TLS name servers, and may be nil.
+func NewServer(dest net.Destination, dispatcher routing.Dispatcher, tlsConfig *tls.Config) (Server, error) {
 	if address := dest.Address; address.Family().IsDomain() {
 		u, err := url.Parse(address.Domain())
 		if err != nil {
@@ -54,6 +108,10 @@ func NewServer(dest net.Destination, dispatcher routing.Dispatcher) (Server, err
 			return NewTCPNameServer(u, dispatcher)
 		case strings.EqualFold(u.Scheme, "tcp+local"): // DNS-over-TCP Local mode
 			return NewTCPLocalNameServer(u)
+		case strings.EqualFold(u.Scheme, "tls"): // DNS-over-TLS Remote mode
+			return NewTLSNameServer(u, tlsConfig, dispatcher)
+		case strings.EqualFold(u.Scheme, "tls+local"): // DNS-over-TLS Local mode
+			return NewTLSLocalNameServer(u, tlsConfig)
 		case strings.EqualFold(u.String(), "fakedns"):
 			return NewFakeDNSServer(), nil
 		}
@@ -73,7 +131,7 @@ func NewClient(ctx context.Context, ns *NameServer, clientIP net.IP, container r
 
 	err := core.RequireFeatures(ctx, func(dispatcher routing.Dispatcher) error {
 		// Create a new server for each client for now
-		server, err := NewServer(ns.Address.AsDestination(), dispatcher)
+		server, err := NewServer(ns.Address.AsDestination(), dispatcher, ns.TlsSettings)
 		if err != nil {
 			return newError("failed to create nameserver").Base(err).AtWarning()
 		}
@@ -99,6 +157,7 @@ func NewClient(ctx context.Context, ns *NameServer, clientIP net.IP, container r
 
 		// Establish domain rules
 		var rules []string
+		var overrides []*domainOverride
 		ruleCurr := 0
 		ruleIter := 0
 		for _, domain := range ns.PrioritizedDomain {
@@ -110,7 +169,12 @@ func NewClient(ctx context.Context, ns *NameServer, clientIP net.IP, container r
 			if ruleCurr < len(ns.OriginalRules) {
 				rule := ns.OriginalRules[ruleCurr]
 				if ruleCurr >= len(rules) {
+					override, err := newDomainOverride(rule)
+					if err != nil {
+						return err
+					}
 					rules = append(rules, rule.Rule)
+					overrides = append(overrides, override)
 				}
 				ruleIter++
 				if ruleIter >= int(rule.Size) {
@@ -119,6 +183,7 @@ func NewClient(ctx context.Context, ns *NameServer, clientIP net.IP, container r
 				}
 			} else { // No original rule, generate one according to current domain matcher (majorly for compatibility with tests)
 				rules = append(rules, domainRule.String())
+				overrides = append(overrides, nil)
 				ruleCurr++
 			}
 			err = updateDomainRule(domainRule, originalRuleIdx, *matcherInfos)
@@ -150,6 +215,7 @@ func NewClient(ctx context.Context, ns *NameServer, clientIP net.IP, container r
 		client.clientIP = clientIP
 		client.skipFallback = ns.SkipFallback
 		client.domains = rules
+		client.overrides = overrides
 		client.expectIPs = matchers
 		return nil
 	})
@@ -160,7 +226,7 @@ func NewClient(ctx context.Context, ns *NameServer, clientIP net.IP, container r
 func NewSimpleClient(ctx context.Context, endpoint *net.Endpoint, clientIP net.IP) (*Client, error) {
 	client := &Client{}
 	err := core.RequireFeatures(ctx, func(dispatcher routing.Dispatcher) error {
-		server, err := NewServer(endpoint.AsDestination(), dispatcher)
+		server, err := NewServer(endpoint.AsDestination(), dispatcher, nil)
 		if err != nil {
 			return newError("failed to create nameserver").Base(err).AtWarning()
 		}
@@ -188,16 +254,45 @@ func (c *Client) Name() string {
 
 // QueryIP send DNS query to the name server with the client's IP.
 func (c *Client) QueryIP(ctx context.Context, domain string, option dns.IPOption, disableCache bool) ([]net.IP, error) {
-	ctx, cancel := context.WithTimeout(ctx, 4*time.Second)
-	ips, err := c.server.QueryIP(ctx, domain, c.clientIP, option, disableCache)
+	return c.queryIP(ctx, domain, nil, option, disableCache)
+}
+
+// queryIP sends DNS query to the name server, with the client IP and IP option overridden by the domain rule matched.
+func (c *Client) queryIP(ctx context.Context, domain string, override *domainOverride, option dns.IPOption, disableCache bool) ([]net.IP, error) {
+	clientIP, option := override.apply(c.clientIP, option)
+	if !option.IPv4Enable && !option.IPv6Enable {
+		return nil, dns.ErrEmptyResponse
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, 4*time.Second)
+	ips, err := c.server.QueryIP(queryCtx, domain, clientIP, option, disableCache)
 	cancel()
 
+	if !disableCache {
+		c.refreshIfDue(ctx, domain, clientIP, option)
+	}
+
 	if err != nil {
 		return ips, err
 	}
 	return c.MatchExpectedIPs(domain, ips)
 }
 
+// refreshIfDue queries the domain again in background, if its cached records are served stale or about to expire.
+func (c *Client) refreshIfDue(ctx context.Context, domain string, clientIP net.IP, option dns.IPOption) {
+	server, ok := c.server.(cachedServer)
+	if !ok || !server.getCache().markRefresh(Fqdn(domain), option) {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(core.ToBackgroundDetachedContext(ctx), 4*time.Second)
+		defer cancel()
+		if _, err := c.server.QueryIP(ctx, domain, clientIP, option, true); err != nil {
+			newError("failed to refresh ", domain, " at ", c.Name()).Base(err).AtDebug().WriteToLog()
+		}
+	}()
+}
+
 // MatchExpectedIPs matches queried domain IPs with expected IPs and returns matched ones.
 func (c *Client) MatchExpectedIPs(domain string, ips []net.IP) ([]net.IP, error) {
 	if len(c.expectIPs) == 0 {
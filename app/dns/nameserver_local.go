@@ -37,6 +37,11 @@ func (s *LocalNameServer) QueryIP(_ context.Context, domain string, _ net.IP, op
 	return ips, err
 }
 
+// queryTXT returns the text records of the domain from the system DNS.
+func (s *LocalNameServer) queryTXT(ctx context.Context, domain string) ([]string, error) {
+	return (&net.Resolver{}).LookupTXT(ctx, domain)
+}
+
 // Name implements Server.
 func (s *LocalNameServer) Name() string {
 	return "localhost"
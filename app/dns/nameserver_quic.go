@@ -2,6 +2,7 @@ package dns
 
 import (
 	"context"
+	"io"
 	"net/url"
 	"sync"
 	"sync/atomic"
@@ -12,7 +13,6 @@ import (
 	"golang.org/x/net/http2"
 
 	"github.com/v2fly/v2ray-core/v4/common"
-	"github.com/v2fly/v2ray-core/v4/common/buf"
 	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/common/protocol/dns"
 	"github.com/v2fly/v2ray-core/v4/common/session"
@@ -31,7 +31,8 @@ const handshakeIdleTimeout = time.Second * 8
 // QUICNameServer implemented DNS over QUIC
 type QUICNameServer struct {
 	sync.RWMutex
-	ips         map[string]record
+	cache       *recordCache
+	validator   *validator
 	pub         *pubsub.Service
 	cleanup     *task.Periodic
 	reqID       uint32
@@ -55,7 +56,7 @@ func NewQUICNameServer(url *url.URL) (*QUICNameServer, error) {
 	dest := net.UDPDestination(net.ParseAddress(url.Hostname()), port)
 
 	s := &QUICNameServer{
-		ips:         make(map[string]record),
+		cache:       newRecordCache(),
 		pub:         pubsub.NewService(),
 		name:        url.String(),
 		destination: dest,
@@ -73,36 +74,20 @@ func (s *QUICNameServer) Name() string {
 	return s.name
 }
 
+func (s *QUICNameServer) getCache() *recordCache {
+	return s.cache
+}
+
+func (s *QUICNameServer) enableDNSSEC(anchors trustAnchors) {
+	s.validator = newValidator(anchors, s.rawExchange)
+}
+
 // Cleanup clears expired items from cache
 func (s *QUICNameServer) Cleanup() error {
-	now := time.Now()
-	s.Lock()
-	defer s.Unlock()
-
-	if len(s.ips) == 0 {
+	if s.cache.empty() {
 		return newError("nothing to do. stopping...")
 	}
-
-	for domain, record := range s.ips {
-		if record.A != nil && record.A.Expire.Before(now) {
-			record.A = nil
-		}
-		if record.AAAA != nil && record.AAAA.Expire.Before(now) {
-			record.AAAA = nil
-		}
-
-		if record.A == nil && record.AAAA == nil {
-			newError(s.name, " cleanup ", domain).AtDebug().WriteToLog()
-			delete(s.ips, domain)
-		} else {
-			s.ips[domain] = record
-		}
-	}
-
-	if len(s.ips) == 0 {
-		s.ips = make(map[string]record)
-	}
-
+	s.cache.cleanup()
 	return nil
 }
 
@@ -110,15 +95,11 @@ func (s *QUICNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
 	elapsed := time.Since(req.start)
 
 	s.Lock()
-	rec := s.ips[req.domain]
-	updated := false
+	var rec record
 
 	switch req.reqType {
 	case dnsmessage.TypeA:
-		if isNewer(rec.A, ipRec) {
-			rec.A = ipRec
-			updated = true
-		}
+		rec.A = ipRec
 	case dnsmessage.TypeAAAA:
 		addr := make([]net.Address, 0)
 		for _, ip := range ipRec.IP {
@@ -127,16 +108,11 @@ func (s *QUICNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
 			}
 		}
 		ipRec.IP = addr
-		if isNewer(rec.AAAA, ipRec) {
-			rec.AAAA = ipRec
-			updated = true
-		}
+		rec.AAAA = ipRec
 	}
 	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", ipRec.IP, " ", elapsed).AtInfo().WriteToLog()
 
-	if updated {
-		s.ips[req.domain] = rec
-	}
+	s.cache.update(req.domain, rec)
 	switch req.reqType {
 	case dnsmessage.TypeA:
 		s.pub.Publish(req.domain+"4", nil)
@@ -154,7 +130,7 @@ func (s *QUICNameServer) newReqID() uint16 {
 func (s *QUICNameServer) sendQuery(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption) {
 	newError(s.name, " querying: ", domain).AtInfo().WriteToLog(session.ExportIDToError(ctx))
 
-	reqs := buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(clientIP))
+	reqs := buildReqMsgs(domain, option, s.newReqID, s.validator.ednsOptions(clientIP))
 
 	var deadline time.Time
 	if d, ok := ctx.Deadline(); ok {
@@ -189,42 +165,44 @@ func (s *QUICNameServer) sendQuery(ctx context.Context, domain string, clientIP
 				return
 			}
 
-			conn, err := s.openStream(dnsCtx)
-			if err != nil {
-				newError("failed to open quic session").Base(err).AtError().WriteToLog()
-				return
-			}
-
-			_, err = conn.Write(b.Bytes())
+			resp, err := s.rawExchange(dnsCtx, b.Bytes())
+			b.Release()
 			if err != nil {
-				newError("failed to send query").Base(err).AtError().WriteToLog()
-				return
-			}
-
-			_ = conn.Close()
-
-			respBuf := buf.New()
-			defer respBuf.Release()
-			n, err := respBuf.ReadFrom(conn)
-			if err != nil && n == 0 {
-				newError("failed to read response").Base(err).AtError().WriteToLog()
+				newError("failed to query DNS over QUIC").Base(err).AtError().WriteToLog()
 				return
 			}
 
-			rec, err := parseResponse(respBuf.Bytes())
+			rec, err := parseResponse(resp)
 			if err != nil {
 				newError("failed to handle response").Base(err).AtError().WriteToLog()
 				return
 			}
+			s.validator.validate(dnsCtx, resp, rec)
 			s.updateIP(r, rec)
 		}(req)
 	}
 }
 
+// rawExchange sends the query on a new stream, and returns the response.
+func (s *QUICNameServer) rawExchange(ctx context.Context, query []byte) ([]byte, error) {
+	stream, err := s.openStream(ctx)
+	if err != nil {
+		return nil, newError("failed to open quic session").Base(err)
+	}
+	if _, err := stream.Write(query); err != nil {
+		return nil, newError("failed to send query").Base(err)
+	}
+	_ = stream.Close()
+
+	resp, err := io.ReadAll(stream)
+	if err != nil && len(resp) == 0 {
+		return nil, newError("failed to read response").Base(err)
+	}
+	return resp, nil
+}
+
 func (s *QUICNameServer) findIPsForDomain(domain string, option dns_feature.IPOption) ([]net.IP, error) {
-	s.RLock()
-	record, found := s.ips[domain]
-	s.RUnlock()
+	record, found := s.cache.get(domain)
 
 	if !found {
 		return nil, errRecordNotFound
@@ -0,0 +1,530 @@
+//go:build !confonly
+// +build !confonly
+
+package dns
+
+import (
+	"bufio"
+	"context"
+	gotls "crypto/tls"
+	"encoding/binary"
+	"io"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	core "github.com/v2fly/v2ray-core/v4"
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/protocol/dns"
+	"github.com/v2fly/v2ray-core/v4/common/session"
+	"github.com/v2fly/v2ray-core/v4/common/signal/done"
+	"github.com/v2fly/v2ray-core/v4/common/signal/pubsub"
+	"github.com/v2fly/v2ray-core/v4/common/task"
+	dns_feature "github.com/v2fly/v2ray-core/v4/features/dns"
+	"github.com/v2fly/v2ray-core/v4/features/routing"
+	"github.com/v2fly/v2ray-core/v4/transport/internet"
+	"github.com/v2fly/v2ray-core/v4/transport/internet/tls"
+)
+
+// NextProtoDoT is the ALPN token of DNS over TLS.
+const NextProtoDoT = "dot"
+
+// TLSNameServer implemented DNS over TLS (RFC7858). Queries are pipelined on a connection that is reused until the
+// server closes it.
+type TLSNameServer struct {
+	sync.RWMutex
+	name        string
+	destination net.Destination
+	cache       *recordCache
+	validator   *validator
+	pub         *pubsub.Service
+	cleanup     *task.Periodic
+	reqID       uint32
+	tlsConfig   *gotls.Config
+	dial        func(context.Context) (net.Conn, error)
+
+	connAccess sync.Mutex
+	conn       *dotConn
+	dialing    *dotDial
+}
+
+// NewTLSNameServer creates DNS over TLS server object for remote resolving.
+func NewTLSNameServer(url *url.URL, config *tls.Config, dispatcher routing.Dispatcher) (*TLSNameServer, error) {
+	s, err := baseTLSNameServer(url, config, "DOT")
+	if err != nil {
+		return nil, err
+	}
+
+	s.dial = func(ctx context.Context) (net.Conn, error) {
+		// The connection outlives the query it is dialed for.
+		link, err := dispatcher.Dispatch(core.ToBackgroundDetachedContext(ctx), s.destination)
+		if err != nil {
+			return nil, err
+		}
+
+		return net.NewConnection(
+			net.ConnectionInputMulti(link.Writer),
+			net.ConnectionOutputMulti(link.Reader),
+		), nil
+	}
+
+	return s, nil
+}
+
+// NewTLSLocalNameServer creates DNS over TLS client object for local resolving
+func NewTLSLocalNameServer(url *url.URL, config *tls.Config) (*TLSNameServer, error) {
+	s, err := baseTLSNameServer(url, config, "DOTL")
+	if err != nil {
+		return nil, err
+	}
+
+	s.dial = func(ctx context.Context) (net.Conn, error) {
+		return internet.DialSystem(ctx, s.destination, nil)
+	}
+
+	return s, nil
+}
+
+func baseTLSNameServer(url *url.URL, config *tls.Config, prefix string) (*TLSNameServer, error) {
+	var err error
+	port := net.Port(853)
+	if url.Port() != "" {
+		port, err = net.PortFromString(url.Port())
+		if err != nil {
+			return nil, err
+		}
+	}
+	dest := net.TCPDestination(net.ParseAddress(url.Hostname()), port)
+
+	if config == nil {
+		config = &tls.Config{}
+	}
+	tlsConfig := config.GetTLSConfig(tls.WithDestination(dest), tls.WithNextProto(NextProtoDoT))
+	if tlsConfig.ServerName == "" {
+		// Verify the certificate against the IP of the server.
+		tlsConfig.ServerName = dest.Address.String()
+	}
+
+	s := &TLSNameServer{
+		destination: dest,
+		cache:       newRecordCache(),
+		pub:         pubsub.NewService(),
+		name:        prefix + "//" + dest.NetAddr(),
+		tlsConfig:   tlsConfig,
+	}
+	s.cleanup = &task.Periodic{
+		Interval: time.Minute,
+		Execute:  s.Cleanup,
+	}
+
+	return s, nil
+}
+
+// Name implements Server.
+func (s *TLSNameServer) Name() string {
+	return s.name
+}
+
+func (s *TLSNameServer) getCache() *recordCache {
+	return s.cache
+}
+
+func (s *TLSNameServer) enableDNSSEC(anchors trustAnchors) {
+	s.validator = newValidator(anchors, s.rawExchange)
+}
+
+// Cleanup clears expired items from cache
+func (s *TLSNameServer) Cleanup() error {
+	if s.cache.empty() {
+		return newError("nothing to do. stopping...")
+	}
+	s.cache.cleanup()
+	return nil
+}
+
+func (s *TLSNameServer) updateIP(req *dnsRequest, ipRec *IPRecord) {
+	elapsed := time.Since(req.start)
+
+	s.Lock()
+	var rec record
+
+	switch req.reqType {
+	case dnsmessage.TypeA:
+		rec.A = ipRec
+	case dnsmessage.TypeAAAA:
+		addr := make([]net.Address, 0)
+		for _, ip := range ipRec.IP {
+			if len(ip.IP()) == net.IPv6len {
+				addr = append(addr, ip)
+			}
+		}
+		ipRec.IP = addr
+		rec.AAAA = ipRec
+	}
+	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", ipRec.IP, " ", elapsed).AtInfo().WriteToLog()
+
+	s.cache.update(req.domain, rec)
+	switch req.reqType {
+	case dnsmessage.TypeA:
+		s.pub.Publish(req.domain+"4", nil)
+	case dnsmessage.TypeAAAA:
+		s.pub.Publish(req.domain+"6", nil)
+	}
+	s.Unlock()
+	common.Must(s.cleanup.Start())
+}
+
+func (s *TLSNameServer) newReqID() uint16 {
+	return uint16(atomic.AddUint32(&s.reqID, 1))
+}
+
+// dotDial is a connection being established to the server.
+type dotDial struct {
+	done chan struct{}
+	conn *dotConn
+	err  error
+}
+
+// getConn returns the connection to the server. Only one connection is established at a time, and queries
+// arriving meanwhile wait for it as long as their contexts allow.
+func (s *TLSNameServer) getConn(ctx context.Context) (*dotConn, error) {
+	for {
+		s.connAccess.Lock()
+		if s.conn != nil && !s.conn.done.Done() {
+			conn := s.conn
+			s.connAccess.Unlock()
+			return conn, nil
+		}
+		d := s.dialing
+		if d == nil {
+			d = &dotDial{done: make(chan struct{})}
+			s.dialing = d
+			s.connAccess.Unlock()
+
+			d.conn, d.err = s.connect(ctx)
+			s.connAccess.Lock()
+			if d.err == nil {
+				s.conn = d.conn
+			}
+			s.dialing = nil
+			s.connAccess.Unlock()
+			close(d.done)
+			return d.conn, d.err
+		}
+		s.connAccess.Unlock()
+
+		select {
+		case <-d.done:
+			if d.err == nil {
+				return d.conn, nil
+			}
+			// The connection may have failed for the context of another query, so try again with this one.
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// connect establishes a new connection to the server.
+func (s *TLSNameServer) connect(ctx context.Context) (*dotConn, error) {
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tlsConn := gotls.Client(conn, s.tlsConfig)
+	// Handshake here, bounded by ctx, rather than in the first write, which holds up the queries waiting for it.
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, newError("failed to handshake").Base(err)
+	}
+	return newDoTConn(&tls.Conn{Conn: tlsConn}), nil
+}
+
+func (s *TLSNameServer) exchange(ctx context.Context, id uint16, b []byte) ([]byte, error) {
+	conn, err := s.getConn(ctx)
+	if err != nil {
+		return nil, newError("failed to dial namesever").Base(err)
+	}
+	resp, err := conn.exchange(ctx, id, b)
+	if err != nil && conn.done.Done() && ctx.Err() == nil {
+		// The server may close idle connections at any time, and a stalled query closes the connection shared with
+		// others. Retry on a new one.
+		conn, err = s.getConn(ctx)
+		if err != nil {
+			return nil, newError("failed to dial namesever").Base(err)
+		}
+		resp, err = conn.exchange(ctx, id, b)
+	}
+	return resp, err
+}
+
+// rawExchange sends the query with a new ID, and returns the response.
+func (s *TLSNameServer) rawExchange(ctx context.Context, query []byte) ([]byte, error) {
+	id := s.newReqID()
+	b := make([]byte, 2+len(query))
+	binary.BigEndian.PutUint16(b, uint16(len(query)))
+	copy(b[2:], query)
+	binary.BigEndian.PutUint16(b[2:], id)
+	return s.exchange(ctx, id, b)
+}
+
+func (s *TLSNameServer) sendQuery(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption) {
+	newError(s.name, " querying DNS for: ", domain).AtDebug().WriteToLog(session.ExportIDToError(ctx))
+
+	reqs := buildReqMsgs(domain, option, s.newReqID, s.validator.ednsOptions(clientIP))
+
+	var deadline time.Time
+	if d, ok := ctx.Deadline(); ok {
+		deadline = d
+	} else {
+		deadline = time.Now().Add(time.Second * 5)
+	}
+
+	for _, req := range reqs {
+		go func(r *dnsRequest) {
+			dnsCtx := ctx
+
+			if inbound := session.InboundFromContext(ctx); inbound != nil {
+				dnsCtx = session.ContextWithInbound(dnsCtx, inbound)
+			}
+
+			dnsCtx = session.ContextWithContent(dnsCtx, &session.Content{
+				Protocol:       "dns",
+				SkipDNSResolve: true,
+			})
+
+			var cancel context.CancelFunc
+			dnsCtx, cancel = context.WithDeadline(dnsCtx, deadline)
+			defer cancel()
+
+			b, err := dns.PackMessage(r.msg)
+			if err != nil {
+				newError("failed to pack dns query").Base(err).AtError().WriteToLog()
+				return
+			}
+			query := make([]byte, 2+b.Len())
+			binary.BigEndian.PutUint16(query, uint16(b.Len()))
+			copy(query[2:], b.Bytes())
+			b.Release()
+
+			resp, err := s.exchange(dnsCtx, r.msg.ID, query)
+			if err != nil {
+				newError("failed to query DNS over TLS").Base(err).AtError().WriteToLog()
+				return
+			}
+
+			rec, err := parseResponse(resp)
+			if err != nil {
+				newError("failed to parse DNS over TLS response").Base(err).AtError().WriteToLog()
+				return
+			}
+			s.validator.validate(dnsCtx, resp, rec)
+
+			s.updateIP(r, rec)
+		}(req)
+	}
+}
+
+func (s *TLSNameServer) findIPsForDomain(domain string, option dns_feature.IPOption) ([]net.IP, error) {
+	record, found := s.cache.get(domain)
+
+	if !found {
+		return nil, errRecordNotFound
+	}
+
+	var ips []net.Address
+	var lastErr error
+	if option.IPv4Enable {
+		a, err := record.A.getIPs()
+		if err != nil {
+			lastErr = err
+		}
+		ips = append(ips, a...)
+	}
+
+	if option.IPv6Enable {
+		aaaa, err := record.AAAA.getIPs()
+		if err != nil {
+			lastErr = err
+		}
+		ips = append(ips, aaaa...)
+	}
+
+	if len(ips) > 0 {
+		return toNetIP(ips)
+	}
+
+	if lastErr != nil {
+		return nil, lastErr
+	}
+
+	return nil, dns_feature.ErrEmptyResponse
+}
+
+// QueryIP implements Server.
+func (s *TLSNameServer) QueryIP(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption, disableCache bool) ([]net.IP, error) {
+	fqdn := Fqdn(domain)
+
+	if disableCache {
+		newError("DNS cache is disabled. Querying IP for ", domain, " at ", s.name).AtDebug().WriteToLog()
+	} else {
+		ips, err := s.findIPsForDomain(fqdn, option)
+		if err != errRecordNotFound {
+			newError(s.name, " cache HIT ", domain, " -> ", ips).Base(err).AtDebug().WriteToLog()
+			return ips, err
+		}
+	}
+
+	// ipv4 and ipv6 belong to different subscription groups
+	var sub4, sub6 *pubsub.Subscriber
+	if option.IPv4Enable {
+		sub4 = s.pub.Subscribe(fqdn + "4")
+		defer sub4.Close()
+	}
+	if option.IPv6Enable {
+		sub6 = s.pub.Subscribe(fqdn + "6")
+		defer sub6.Close()
+	}
+	done := make(chan interface{})
+	go func() {
+		if sub4 != nil {
+			select {
+			case <-sub4.Wait():
+			case <-ctx.Done():
+			}
+		}
+		if sub6 != nil {
+			select {
+			case <-sub6.Wait():
+			case <-ctx.Done():
+			}
+		}
+		close(done)
+	}()
+	s.sendQuery(ctx, fqdn, clientIP, option)
+
+	for {
+		ips, err := s.findIPsForDomain(fqdn, option)
+		if err != errRecordNotFound {
+			return ips, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-done:
+		}
+	}
+}
+
+// dotConn is a connection to a DNS over TLS server. Queries are pipelined on it, and responses are matched to their
+// queries by message IDs, as they may arrive out of order (RFC7766 section 6.2.1.1).
+type dotConn struct {
+	net.Conn
+	access      sync.Mutex
+	writeAccess sync.Mutex
+	pending     map[uint16]chan []byte
+	done        *done.Instance
+}
+
+func newDoTConn(conn net.Conn) *dotConn {
+	c := &dotConn{
+		Conn:    conn,
+		pending: make(map[uint16]chan []byte),
+		done:    done.New(),
+	}
+	go c.readResponses()
+	return c
+}
+
+func (c *dotConn) readResponses() {
+	defer c.Close()
+
+	reader := bufio.NewReader(c.Conn)
+	for {
+		var length uint16
+		if err := binary.Read(reader, binary.BigEndian, &length); err != nil {
+			if err != io.EOF {
+				newError("failed to read response length").Base(err).AtDebug().WriteToLog()
+			}
+			return
+		}
+		resp := make([]byte, length)
+		if _, err := io.ReadFull(reader, resp); err != nil {
+			newError("failed to read response").Base(err).AtDebug().WriteToLog()
+			return
+		}
+		if len(resp) < 2 {
+			continue
+		}
+
+		id := binary.BigEndian.Uint16(resp)
+		c.access.Lock()
+		ch, found := c.pending[id]
+		delete(c.pending, id)
+		c.access.Unlock()
+		if found {
+			ch <- resp
+		}
+	}
+}
+
+// exchange sends the length prefixed query with the ID, and waits for its response.
+func (c *dotConn) exchange(ctx context.Context, id uint16, query []byte) ([]byte, error) {
+	ch := make(chan []byte, 1)
+	c.access.Lock()
+	if c.done.Done() {
+		c.access.Unlock()
+		return nil, newError("connection closed")
+	}
+	c.pending[id] = ch
+	c.access.Unlock()
+	defer func() {
+		c.access.Lock()
+		delete(c.pending, id)
+		c.access.Unlock()
+	}()
+
+	c.writeAccess.Lock()
+	if err := ctx.Err(); err != nil {
+		// A write past the deadline would close the connection shared with other queries.
+		c.writeAccess.Unlock()
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		c.Conn.SetWriteDeadline(deadline)
+	}
+	_, err := c.Conn.Write(query)
+	c.Conn.SetWriteDeadline(time.Time{})
+	c.writeAccess.Unlock()
+	if err != nil {
+		c.Close()
+		return nil, newError("failed to send query").Base(err)
+	}
+
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-c.done.Wait():
+		select {
+		case resp := <-ch:
+			return resp, nil
+		default:
+			return nil, newError("connection closed")
+		}
+	}
+}
+
+// Close implements net.Conn.
+func (c *dotConn) Close() error {
+	c.access.Lock()
+	common.Must(c.done.Close())
+	c.access.Unlock()
+	return c.Conn.Close()
+}
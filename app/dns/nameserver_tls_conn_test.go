@@ -0,0 +1,88 @@
+package dns
+
+import (
+	"context"
+	gotls "crypto/tls"
+	gonet "net"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/protocol/tls/cert"
+	"github.com/v2fly/v2ray-core/v4/transport/internet/tls"
+)
+
+func TestTLSNameServerConnectOnce(t *testing.T) {
+	serverConfig := &tls.Config{
+		Certificate:  []*tls.Certificate{tls.ParseCertificate(cert.MustGenerate(nil, cert.DNSNames("dns.v2fly.org")))},
+		NextProtocol: []string{NextProtoDoT},
+	}
+	url, err := url.Parse("tls+local://127.0.0.1:853")
+	common.Must(err)
+	s, err := baseTLSNameServer(url, &tls.Config{
+		ServerName:    "dns.v2fly.org",
+		AllowInsecure: true,
+	}, "DOTL")
+	common.Must(err)
+
+	// The server does not answer the handshake until released.
+	release := make(chan struct{})
+	var dials int32
+	s.dial = func(ctx context.Context) (net.Conn, error) {
+		atomic.AddInt32(&dials, 1)
+		client, server := gonet.Pipe()
+		go func() {
+			<-release
+			gotls.Server(server, serverConfig.GetTLSConfig()).Handshake()
+		}()
+		return client, nil
+	}
+
+	type result struct {
+		conn *dotConn
+		err  error
+	}
+	results := make(chan result, 3)
+	getConn := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		defer cancel()
+		conn, err := s.getConn(ctx)
+		results <- result{conn, err}
+	}
+	go getConn()
+	for atomic.LoadInt32(&dials) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// A query waiting for the handshake of another gives up at its own deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	start := time.Now()
+	if _, err := s.getConn(ctx); err == nil {
+		t.Error("expected waiting query to time out")
+	}
+	cancel()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Error("waiting query is held up by the handshake for ", elapsed)
+	}
+
+	go getConn()
+	go getConn()
+	close(release)
+	var conn *dotConn
+	for i := 0; i < 3; i++ {
+		r := <-results
+		common.Must(r.err)
+		if conn == nil {
+			conn = r.conn
+		} else if r.conn != conn {
+			t.Error("expected queries to share the connection")
+		}
+	}
+	if n := atomic.LoadInt32(&dials); n != 1 {
+		t.Error("expected one connection, but got ", n)
+	}
+	conn.Close()
+}
@@ -0,0 +1,170 @@
+package dns_test
+
+import (
+	"context"
+	gotls "crypto/tls"
+	"encoding/binary"
+	"io"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	. "github.com/v2fly/v2ray-core/v4/app/dns"
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/protocol/tls/cert"
+	dns_feature "github.com/v2fly/v2ray-core/v4/features/dns"
+	"github.com/v2fly/v2ray-core/v4/transport/internet/tls"
+)
+
+func readDoTMessage(reader io.Reader) (*dnsmessage.Message, error) {
+	var length uint16
+	if err := binary.Read(reader, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+	b := make([]byte, length)
+	if _, err := io.ReadFull(reader, b); err != nil {
+		return nil, err
+	}
+	msg := new(dnsmessage.Message)
+	return msg, msg.Unpack(b)
+}
+
+func writeDoTResponse(writer io.Writer, query *dnsmessage.Message) error {
+	resp := &dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: query.ID, Response: true},
+		Questions: query.Questions,
+	}
+	if q := query.Questions[0]; q.Type == dnsmessage.TypeA {
+		resp.Answers = append(resp.Answers, dnsmessage.Resource{
+			Header: dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: q.Class, TTL: 600},
+			Body:   &dnsmessage.AResource{A: [4]byte{1, 2, 3, 4}},
+		})
+	}
+	b, err := resp.Pack()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(append([]byte{byte(len(b) >> 8), byte(len(b))}, b...))
+	return err
+}
+
+// serveDoT answers each pair of queries in reverse order.
+func serveDoT(conn net.Conn) {
+	defer conn.Close()
+	for {
+		q1, err := readDoTMessage(conn)
+		if err != nil {
+			return
+		}
+		q2, err := readDoTMessage(conn)
+		if err != nil {
+			return
+		}
+		if writeDoTResponse(conn, q2) != nil || writeDoTResponse(conn, q1) != nil {
+			return
+		}
+	}
+}
+
+func TestTLSLocalNameServer(t *testing.T) {
+	serverConfig := &tls.Config{
+		Certificate:  []*tls.Certificate{tls.ParseCertificate(cert.MustGenerate(nil, cert.DNSNames("dns.v2fly.org")))},
+		NextProtocol: []string{NextProtoDoT},
+	}
+	listener, err := gotls.Listen("tcp", "127.0.0.1:0", serverConfig.GetTLSConfig())
+	common.Must(err)
+	defer listener.Close()
+
+	var accepted int32
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			atomic.AddInt32(&accepted, 1)
+			go serveDoT(conn)
+		}
+	}()
+
+	url, err := url.Parse("tls+local://" + listener.Addr().String())
+	common.Must(err)
+	s, err := NewTLSLocalNameServer(url, &tls.Config{
+		ServerName:    "dns.v2fly.org",
+		AllowInsecure: true,
+	})
+	common.Must(err)
+
+	for _, domain := range []string{"v2fly.org", "www.v2fly.org"} {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		ips, err := s.QueryIP(ctx, domain, net.IP(nil), dns_feature.IPOption{
+			IPv4Enable: true,
+			IPv6Enable: true,
+		}, false)
+		cancel()
+		common.Must(err)
+		if len(ips) != 1 || !ips[0].Equal(net.IP{1, 2, 3, 4}) {
+			t.Error("unexpected ips of ", domain, ": ", ips)
+		}
+	}
+
+	if n := atomic.LoadInt32(&accepted); n != 1 {
+		t.Error("expected connection to be reused, but got ", n, " connections")
+	}
+}
+
+func TestTLSLocalNameServerStalledHandshake(t *testing.T) {
+	serverConfig := &tls.Config{
+		Certificate:  []*tls.Certificate{tls.ParseCertificate(cert.MustGenerate(nil, cert.DNSNames("dns.v2fly.org")))},
+		NextProtocol: []string{NextProtoDoT},
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	go func() {
+		// The first connection never completes its handshake.
+		stalled, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer stalled.Close()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveDoT(gotls.Server(conn, serverConfig.GetTLSConfig()))
+		}
+	}()
+
+	url, err := url.Parse("tls+local://" + listener.Addr().String())
+	common.Must(err)
+	s, err := NewTLSLocalNameServer(url, &tls.Config{
+		ServerName:    "dns.v2fly.org",
+		AllowInsecure: true,
+	})
+	common.Must(err)
+
+	option := dns_feature.IPOption{
+		IPv4Enable: true,
+		IPv6Enable: true,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	if _, err := s.QueryIP(ctx, "v2fly.org", net.IP(nil), option, false); err == nil {
+		t.Error("expected query to fail on a stalled handshake")
+	}
+	cancel()
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	ips, err := s.QueryIP(ctx, "v2fly.org", net.IP(nil), option, false)
+	common.Must(err)
+	if len(ips) != 1 || !ips[0].Equal(net.IP{1, 2, 3, 4}) {
+		t.Error("unexpected ips: ", ips)
+	}
+}
@@ -5,6 +5,7 @@ package dns
 
 import (
 	"context"
+	"encoding/binary"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -14,6 +15,7 @@ import (
 
 	core "github.com/v2fly/v2ray-core/v4"
 	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/buf"
 	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/common/protocol/dns"
 	udp_proto "github.com/v2fly/v2ray-core/v4/common/protocol/udp"
@@ -30,8 +32,10 @@ type ClassicNameServer struct {
 	sync.RWMutex
 	name      string
 	address   net.Destination
-	ips       map[string]record
+	cache     *recordCache
+	validator *validator
 	requests  map[uint16]dnsRequest
+	exchanges map[uint16]chan []byte
 	pub       *pubsub.Service
 	udpServer *udp.Dispatcher
 	cleanup   *task.Periodic
@@ -46,11 +50,12 @@ func NewClassicNameServer(address net.Destination, dispatcher routing.Dispatcher
 	}
 
 	s := &ClassicNameServer{
-		address:  address,
-		ips:      make(map[string]record),
-		requests: make(map[uint16]dnsRequest),
-		pub:      pubsub.NewService(),
-		name:     strings.ToUpper(address.String()),
+		address:   address,
+		cache:     newRecordCache(),
+		requests:  make(map[uint16]dnsRequest),
+		exchanges: make(map[uint16]chan []byte),
+		pub:       pubsub.NewService(),
+		name:      strings.ToUpper(address.String()),
 	}
 	s.cleanup = &task.Periodic{
 		Interval: time.Minute,
@@ -66,34 +71,25 @@ func (s *ClassicNameServer) Name() string {
 	return s.name
 }
 
+func (s *ClassicNameServer) getCache() *recordCache {
+	return s.cache
+}
+
+func (s *ClassicNameServer) enableDNSSEC(anchors trustAnchors) {
+	s.validator = newValidator(anchors, s.rawExchange)
+}
+
 // Cleanup clears expired items from cache
 func (s *ClassicNameServer) Cleanup() error {
 	now := time.Now()
 	s.Lock()
 	defer s.Unlock()
 
-	if len(s.ips) == 0 && len(s.requests) == 0 {
+	if s.cache.empty() && len(s.requests) == 0 {
 		return newError(s.name, " nothing to do. stopping...")
 	}
 
-	for domain, record := range s.ips {
-		if record.A != nil && record.A.Expire.Before(now) {
-			record.A = nil
-		}
-		if record.AAAA != nil && record.AAAA.Expire.Before(now) {
-			record.AAAA = nil
-		}
-
-		if record.A == nil && record.AAAA == nil {
-			delete(s.ips, domain)
-		} else {
-			s.ips[domain] = record
-		}
-	}
-
-	if len(s.ips) == 0 {
-		s.ips = make(map[string]record)
-	}
+	s.cache.cleanup()
 
 	for id, req := range s.requests {
 		if req.expire.Before(now) {
@@ -110,6 +106,10 @@ func (s *ClassicNameServer) Cleanup() error {
 
 // HandleResponse handles udp response packet from remote DNS server.
 func (s *ClassicNameServer) HandleResponse(ctx context.Context, packet *udp_proto.Packet) {
+	if s.handleExchange(packet.Payload.Bytes()) {
+		return
+	}
+
 	ipRec, err := parseResponse(packet.Payload.Bytes())
 	if err != nil {
 		newError(s.name, " fail to parse responded DNS udp").AtError().WriteToLog()
@@ -139,30 +139,78 @@ func (s *ClassicNameServer) HandleResponse(ctx context.Context, packet *udp_prot
 
 	elapsed := time.Since(req.start)
 	newError(s.name, " got answer: ", req.domain, " ", req.reqType, " -> ", ipRec.IP, " ", elapsed).AtInfo().WriteToLog()
-	if len(req.domain) > 0 && (rec.A != nil || rec.AAAA != nil) {
+	if len(req.domain) == 0 || (rec.A == nil && rec.AAAA == nil) {
+		return
+	}
+	if s.validator == nil {
 		s.updateIP(req.domain, rec)
+		return
 	}
+	// Validation sends queries, whose responses are handled by the caller, so it must not block.
+	resp := append([]byte(nil), packet.Payload.Bytes()...)
+	go func() {
+		ctx, cancel := context.WithTimeout(core.ToBackgroundDetachedContext(ctx), 5*time.Second)
+		defer cancel()
+		s.validator.validate(ctx, resp, ipRec)
+		s.updateIP(req.domain, rec)
+	}()
 }
 
-func (s *ClassicNameServer) updateIP(domain string, newRec record) {
+// handleExchange passes the response to rawExchange waiting for it. It returns whether there is one.
+func (s *ClassicNameServer) handleExchange(resp []byte) bool {
+	if len(resp) < 2 {
+		return false
+	}
 	s.Lock()
+	ch, found := s.exchanges[binary.BigEndian.Uint16(resp)]
+	s.Unlock()
+	if !found {
+		return false
+	}
+	select {
+	case ch <- append([]byte(nil), resp...):
+	default:
+	}
+	return true
+}
 
-	newError(s.name, " updating IP records for domain:", domain).AtDebug().WriteToLog()
-	rec := s.ips[domain]
+// rawExchange sends the query with a new ID, and waits for the response.
+func (s *ClassicNameServer) rawExchange(ctx context.Context, query []byte) ([]byte, error) {
+	id := s.newReqID()
+	ch := make(chan []byte, 1)
+	s.Lock()
+	s.exchanges[id] = ch
+	s.Unlock()
+	defer func() {
+		s.Lock()
+		delete(s.exchanges, id)
+		s.Unlock()
+	}()
 
-	updated := false
-	if isNewer(rec.A, newRec.A) {
-		rec.A = newRec.A
-		updated = true
+	b := buf.New()
+	if _, err := b.Write(query); err != nil {
+		b.Release()
+		return nil, newError("failed to write query").Base(err)
 	}
-	if isNewer(rec.AAAA, newRec.AAAA) {
-		rec.AAAA = newRec.AAAA
-		updated = true
+	binary.BigEndian.PutUint16(b.BytesTo(2), id)
+	udpCtx := session.ContextWithContent(core.ToBackgroundDetachedContext(ctx), &session.Content{
+		Protocol: "dns",
+	})
+	s.udpServer.Dispatch(udpCtx, s.address, b)
+
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
+}
 
-	if updated {
-		s.ips[domain] = rec
-	}
+func (s *ClassicNameServer) updateIP(domain string, newRec record) {
+	s.Lock()
+
+	newError(s.name, " updating IP records for domain:", domain).AtDebug().WriteToLog()
+	s.cache.update(domain, newRec)
 	if newRec.A != nil {
 		s.pub.Publish(domain+"4", nil)
 	}
@@ -189,7 +237,7 @@ func (s *ClassicNameServer) addPendingRequest(req *dnsRequest) {
 func (s *ClassicNameServer) sendQuery(ctx context.Context, domain string, clientIP net.IP, option dns_feature.IPOption) {
 	newError(s.name, " querying DNS for: ", domain).AtDebug().WriteToLog(session.ExportIDToError(ctx))
 
-	reqs := buildReqMsgs(domain, option, s.newReqID, genEDNS0Options(clientIP))
+	reqs := buildReqMsgs(domain, option, s.newReqID, s.validator.ednsOptions(clientIP))
 
 	for _, req := range reqs {
 		s.addPendingRequest(req)
@@ -206,9 +254,7 @@ func (s *ClassicNameServer) sendQuery(ctx context.Context, domain string, client
 }
 
 func (s *ClassicNameServer) findIPsForDomain(domain string, option dns_feature.IPOption) ([]net.IP, error) {
-	s.RLock()
-	record, found := s.ips[domain]
-	s.RUnlock()
+	record, found := s.cache.get(domain)
 
 	if !found {
 		return nil, errRecordNotFound
@@ -16,11 +16,25 @@ type service struct {
 }
 
 func (s service) ListInstance(ctx context.Context, req *ListInstanceReq) (*ListInstanceResp, error) {
-	instanceNames, err := s.instman.ListInstance(ctx)
+	instances, err := s.instman.ListInstanceStatus(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &ListInstanceResp{Name: instanceNames}, nil
+	resp := &ListInstanceResp{}
+	for _, instance := range instances {
+		// The values of InstanceState match the ones of extension.InstanceState.
+		status := &InstanceStatus{
+			Name:         instance.Name,
+			State:        InstanceState(instance.State),
+			RestartCount: uint32(instance.RestartCount),
+		}
+		if instance.LastError != nil {
+			status.LastError = instance.LastError.Error()
+		}
+		resp.Name = append(resp.Name, instance.Name)
+		resp.Status = append(resp.Status, status)
+	}
+	return resp, nil
 }
 
 func (s service) AddInstance(ctx context.Context, req *AddInstanceReq) (*AddInstanceResp, error) {
@@ -43,6 +57,22 @@ func (s service) StartInstance(ctx context.Context, req *StartInstanceReq) (*Sta
 	return &StartInstanceResp{}, nil
 }
 
+func (s service) StopInstance(ctx context.Context, req *StopInstanceReq) (*StopInstanceResp, error) {
+	err := s.instman.StopInstance(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &StopInstanceResp{}, nil
+}
+
+func (s service) UntrackInstance(ctx context.Context, req *UntrackInstanceReq) (*UntrackInstanceResp, error) {
+	err := s.instman.UntrackInstance(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &UntrackInstanceResp{}, nil
+}
+
 func (s service) Register(server *grpc.Server) {
 	RegisterInstanceManagementServiceServer(server, s)
 }
@@ -21,6 +21,8 @@ type InstanceManagementServiceClient interface {
 	ListInstance(ctx context.Context, in *ListInstanceReq, opts ...grpc.CallOption) (*ListInstanceResp, error)
 	AddInstance(ctx context.Context, in *AddInstanceReq, opts ...grpc.CallOption) (*AddInstanceResp, error)
 	StartInstance(ctx context.Context, in *StartInstanceReq, opts ...grpc.CallOption) (*StartInstanceResp, error)
+	StopInstance(ctx context.Context, in *StopInstanceReq, opts ...grpc.CallOption) (*StopInstanceResp, error)
+	UntrackInstance(ctx context.Context, in *UntrackInstanceReq, opts ...grpc.CallOption) (*UntrackInstanceResp, error)
 }
 
 type instanceManagementServiceClient struct {
@@ -58,6 +60,24 @@ func (c *instanceManagementServiceClient) StartInstance(ctx context.Context, in
 	return out, nil
 }
 
+func (c *instanceManagementServiceClient) StopInstance(ctx context.Context, in *StopInstanceReq, opts ...grpc.CallOption) (*StopInstanceResp, error) {
+	out := new(StopInstanceResp)
+	err := c.cc.Invoke(ctx, "/v2ray.core.app.instman.command.InstanceManagementService/StopInstance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instanceManagementServiceClient) UntrackInstance(ctx context.Context, in *UntrackInstanceReq, opts ...grpc.CallOption) (*UntrackInstanceResp, error) {
+	out := new(UntrackInstanceResp)
+	err := c.cc.Invoke(ctx, "/v2ray.core.app.instman.command.InstanceManagementService/UntrackInstance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InstanceManagementServiceServer is the server API for InstanceManagementService service.
 // All implementations must embed UnimplementedInstanceManagementServiceServer
 // for forward compatibility
@@ -65,6 +85,8 @@ type InstanceManagementServiceServer interface {
 	ListInstance(context.Context, *ListInstanceReq) (*ListInstanceResp, error)
 	AddInstance(context.Context, *AddInstanceReq) (*AddInstanceResp, error)
 	StartInstance(context.Context, *StartInstanceReq) (*StartInstanceResp, error)
+	StopInstance(context.Context, *StopInstanceReq) (*StopInstanceResp, error)
+	UntrackInstance(context.Context, *UntrackInstanceReq) (*UntrackInstanceResp, error)
 	mustEmbedUnimplementedInstanceManagementServiceServer()
 }
 
@@ -81,6 +103,12 @@ func (UnimplementedInstanceManagementServiceServer) AddInstance(context.Context,
 func (UnimplementedInstanceManagementServiceServer) StartInstance(context.Context, *StartInstanceReq) (*StartInstanceResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartInstance not implemented")
 }
+func (UnimplementedInstanceManagementServiceServer) StopInstance(context.Context, *StopInstanceReq) (*StopInstanceResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopInstance not implemented")
+}
+func (UnimplementedInstanceManagementServiceServer) UntrackInstance(context.Context, *UntrackInstanceReq) (*UntrackInstanceResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UntrackInstance not implemented")
+}
 func (UnimplementedInstanceManagementServiceServer) mustEmbedUnimplementedInstanceManagementServiceServer() {
 }
 
@@ -149,6 +177,42 @@ func _InstanceManagementService_StartInstance_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InstanceManagementService_StopInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopInstanceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstanceManagementServiceServer).StopInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v2ray.core.app.instman.command.InstanceManagementService/StopInstance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstanceManagementServiceServer).StopInstance(ctx, req.(*StopInstanceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstanceManagementService_UntrackInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UntrackInstanceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstanceManagementServiceServer).UntrackInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v2ray.core.app.instman.command.InstanceManagementService/UntrackInstance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstanceManagementServiceServer).UntrackInstance(ctx, req.(*UntrackInstanceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InstanceManagementService_ServiceDesc is the grpc.ServiceDesc for InstanceManagementService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -168,6 +232,14 @@ var InstanceManagementService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StartInstance",
 			Handler:    _InstanceManagementService_StartInstance_Handler,
 		},
+		{
+			MethodName: "StopInstance",
+			Handler:    _InstanceManagementService_StopInstance_Handler,
+		},
+		{
+			MethodName: "UntrackInstance",
+			Handler:    _InstanceManagementService_UntrackInstance_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "app/instman/command/command.proto",
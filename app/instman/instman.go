@@ -1,75 +1,346 @@
 package instman
 
+//go:generate go run github.com/v2fly/v2ray-core/v4/common/errors/errorgen
+
 import (
+	"bytes"
 	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
 	core "github.com/v2fly/v2ray-core/v4"
 	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/environment"
+	"github.com/v2fly/v2ray-core/v4/common/environment/envctx"
 	"github.com/v2fly/v2ray-core/v4/features/extension"
+	"github.com/v2fly/v2ray-core/v4/features/extension/storage"
 )
 
-//go:generate go run github.com/v2fly/v2ray-core/v4/common/errors/errorgen
+const defaultRestartInterval = 10 * time.Second
 
+type managedInstance struct {
+	name       string
+	configType string
+	config     []byte
+
+	// instance is nil unless the instance is created and not closed.
+	instance     *core.Instance
+	state        extension.InstanceState
+	lastErr      error
+	restartCount int
+	restartTimer *time.Timer
+}
+
+// InstanceMgr supervises V2Ray instances running inside this one.
 type InstanceMgr struct {
+	access    sync.Mutex
+	ctx       context.Context
 	config    *Config
-	instances map[string]*core.Instance
+	instances map[string]*managedInstance
+	storage   storage.ScopedPersistentStorage
+	closed    bool
 }
 
-func (i InstanceMgr) Type() interface{} {
+func (m *InstanceMgr) Type() interface{} {
 	return extension.InstanceManagementType()
 }
 
-func (i InstanceMgr) Start() error {
-	return nil
+// Start restores the persisted instances, if persistence is enabled.
+func (m *InstanceMgr) Start() error {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	m.closed = false
+	if !m.config.Persist {
+		return nil
+	}
+	env, ok := envctx.EnvironmentFromContext(m.ctx).(environment.AppEnvironment)
+	if !ok {
+		return newError("persistence requires an app environment")
+	}
+	scope := env.PersistentStorage()
+	if scope == nil {
+		return newError("persistence is enabled, but there is no persistent storage")
+	}
+	m.storage = scope
+	return m.restore()
 }
 
-func (i InstanceMgr) Close() error {
+// Close stops all instances. Their persisted states are kept, so that they are started again on next Start.
+func (m *InstanceMgr) Close() error {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	m.closed = true
+	var errs []error
+	for _, entry := range m.instances {
+		if err := m.stop(entry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return newError("failed to stop all instances").Base(errs[0])
+	}
 	return nil
 }
 
-func (i InstanceMgr) ListInstance(ctx context.Context) ([]string, error) {
-	var instanceNames []string
-	for k, _ := range i.instances {
-		instanceNames = append(instanceNames, k)
+func (m *InstanceMgr) ListInstance(ctx context.Context) ([]string, error) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	instanceNames := make([]string, 0, len(m.instances))
+	for name := range m.instances {
+		instanceNames = append(instanceNames, name)
 	}
+	sort.Strings(instanceNames)
 	return instanceNames, nil
 }
 
-func (i InstanceMgr) AddInstance(ctx context.Context, name string, config []byte, configType string) error {
-	coreConfig, err := core.LoadConfig(configType, config)
+func (m *InstanceMgr) ListInstanceStatus(ctx context.Context) ([]extension.InstanceStatus, error) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	status := make([]extension.InstanceStatus, 0, len(m.instances))
+	for _, entry := range m.instances {
+		status = append(status, extension.InstanceStatus{
+			Name:         entry.name,
+			State:        entry.state,
+			LastError:    entry.lastErr,
+			RestartCount: entry.restartCount,
+		})
+	}
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Name < status[j].Name
+	})
+	return status, nil
+}
+
+func (m *InstanceMgr) AddInstance(ctx context.Context, name string, config []byte, configType string) error {
+	if name == "" {
+		return newError("instance name is empty")
+	}
+	instance, err := newInstance(config, configType)
 	if err != nil {
-		return newError("unable to load config").Base(err)
+		return err
+	}
+
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	if _, found := m.instances[name]; found {
+		instance.Close()
+		return newError("instance ", name, " already exists")
+	}
+	entry := &managedInstance{
+		name:       name,
+		configType: configType,
+		config:     config,
+		instance:   instance,
+	}
+	m.instances[name] = entry
+	return m.persist(entry)
+}
+
+func (m *InstanceMgr) StartInstance(ctx context.Context, name string) error {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	entry, found := m.instances[name]
+	if !found {
+		return newError("instance ", name, " not found")
+	}
+	entry.restartCount = 0
+	err := m.start(entry)
+	if perr := m.persist(entry); perr != nil {
+		newError("failed to persist instance ", name).Base(perr).AtWarning().WriteToLog()
+	}
+	return err
+}
+
+func (m *InstanceMgr) StopInstance(ctx context.Context, name string) error {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	entry, found := m.instances[name]
+	if !found {
+		return newError("instance ", name, " not found")
+	}
+	err := m.stop(entry)
+	if perr := m.persist(entry); perr != nil {
+		newError("failed to persist instance ", name).Base(perr).AtWarning().WriteToLog()
+	}
+	return err
+}
+
+// UntrackInstance stops the instance and forgets it.
+func (m *InstanceMgr) UntrackInstance(ctx context.Context, name string) error {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	entry, found := m.instances[name]
+	if !found {
+		return newError("instance ", name, " not found")
+	}
+	err := m.stop(entry)
+	delete(m.instances, name)
+	if m.storage != nil {
+		if perr := m.storage.Put(context.Background(), []byte(name), nil); perr != nil {
+			newError("failed to remove persisted instance ", name).Base(perr).AtWarning().WriteToLog()
+		}
+	}
+	return err
+}
+
+func newInstance(config []byte, configType string) (*core.Instance, error) {
+	coreConfig, err := core.LoadConfig(configType, bytes.NewReader(config))
+	if err != nil {
+		return nil, newError("unable to load config").Base(err)
 	}
 	instance, err := core.New(coreConfig)
 	if err != nil {
-		return newError("unable to create instance").Base(err)
+		return nil, newError("unable to create instance").Base(err)
 	}
-	i.instances[name] = instance
+	return instance, nil
+}
+
+// start starts the instance, creating it again if it was stopped. m.access must be held.
+func (m *InstanceMgr) start(entry *managedInstance) error {
+	if entry.state == extension.InstanceRunning {
+		return nil
+	}
+	if entry.restartTimer != nil {
+		entry.restartTimer.Stop()
+		entry.restartTimer = nil
+	}
+	if entry.instance == nil {
+		instance, err := newInstance(entry.config, entry.configType)
+		if err != nil {
+			return m.fail(entry, err)
+		}
+		entry.instance = instance
+	}
+	if err := entry.instance.Start(); err != nil {
+		// A V2Ray instance is not guaranteed to start again once it fails, so a new one is created next time.
+		entry.instance.Close()
+		entry.instance = nil
+		return m.fail(entry, newError("failed to start instance").Base(err))
+	}
+	entry.state = extension.InstanceRunning
+	entry.lastErr = nil
+	newError("instance ", entry.name, " started").AtInfo().WriteToLog()
 	return nil
 }
 
-func (i InstanceMgr) StartInstance(ctx context.Context, name string) error {
-	err := i.instances[name].Start()
+// fail records the failure of the instance, and schedules a restart if enabled. m.access must be held.
+func (m *InstanceMgr) fail(entry *managedInstance, err error) error {
+	entry.state = extension.InstanceFailed
+	entry.lastErr = err
+	newError("instance ", entry.name, " failed").Base(err).AtWarning().WriteToLog()
+
+	maxAttempts := int(m.config.MaxRestartAttempts)
+	if !m.config.AutoRestart || m.closed || (maxAttempts > 0 && entry.restartCount >= maxAttempts) {
+		return err
+	}
+	interval := defaultRestartInterval
+	if m.config.RestartIntervalSec > 0 {
+		interval = time.Duration(m.config.RestartIntervalSec) * time.Second
+	}
+	entry.restartTimer = time.AfterFunc(interval, func() {
+		m.restart(entry)
+	})
+	return err
+}
+
+func (m *InstanceMgr) restart(entry *managedInstance) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	if m.closed || m.instances[entry.name] != entry || entry.state != extension.InstanceFailed {
+		return
+	}
+	entry.restartTimer = nil
+	entry.restartCount++
+	newError("restarting instance ", entry.name, ", attempt ", entry.restartCount).AtInfo().WriteToLog()
+	m.start(entry)
+}
+
+// stop closes the instance, if it is created, and cancels pending restarts. m.access must be held.
+func (m *InstanceMgr) stop(entry *managedInstance) error {
+	if entry.restartTimer != nil {
+		entry.restartTimer.Stop()
+		entry.restartTimer = nil
+	}
+	entry.state = extension.InstanceCreated
+	entry.lastErr = nil
+	if entry.instance == nil {
+		return nil
+	}
+	err := entry.instance.Close()
+	entry.instance = nil
 	if err != nil {
-		return newError("failed to start instance").Base(err)
+		return newError("failed to stop instance ", entry.name).Base(err)
 	}
 	return nil
 }
 
-func (i InstanceMgr) StopInstance(ctx context.Context, name string) error {
-	err := i.instances[name].Close()
+// persist records the instance in persistent storage, if enabled. m.access must be held.
+func (m *InstanceMgr) persist(entry *managedInstance) error {
+	if m.storage == nil {
+		return nil
+	}
+	data, err := proto.Marshal(&PersistedInstance{
+		ConfigType: entry.configType,
+		Config:     entry.config,
+		Running:    entry.state != extension.InstanceCreated,
+	})
 	if err != nil {
-		return newError("failed to stop instance").Base(err)
+		return err
 	}
-	return nil
+	return m.storage.Put(context.Background(), []byte(entry.name), data)
 }
 
-func (i InstanceMgr) UntrackInstance(ctx context.Context, name string) error {
-	delete(i.instances, name)
+// restore adds the persisted instances, and starts the ones that were running. m.access must be held.
+func (m *InstanceMgr) restore() error {
+	names, err := m.storage.List(context.Background(), nil)
+	if err != nil {
+		return newError("failed to list persisted instances").Base(err)
+	}
+	for _, name := range names {
+		if _, found := m.instances[string(name)]; found {
+			continue
+		}
+		data, err := m.storage.Get(context.Background(), name)
+		if err != nil {
+			newError("failed to read persisted instance ", string(name)).Base(err).AtWarning().WriteToLog()
+			continue
+		}
+		persisted := new(PersistedInstance)
+		if err := proto.Unmarshal(data, persisted); err != nil {
+			newError("failed to decode persisted instance ", string(name)).Base(err).AtWarning().WriteToLog()
+			continue
+		}
+		entry := &managedInstance{
+			name:       string(name),
+			configType: persisted.ConfigType,
+			config:     persisted.Config,
+		}
+		m.instances[entry.name] = entry
+		if persisted.Running {
+			m.start(entry)
+		}
+	}
 	return nil
 }
 
 func NewInstanceMgr(ctx context.Context, config *Config) (extension.InstanceManagement, error) {
-	return InstanceMgr{instances: map[string]*core.Instance{}}, nil
+	return &InstanceMgr{
+		ctx:       ctx,
+		config:    config,
+		instances: map[string]*managedInstance{},
+	}, nil
 }
 
 func init() {
@@ -0,0 +1,163 @@
+package instman_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/anypb"
+
+	core "github.com/v2fly/v2ray-core/v4"
+	"github.com/v2fly/v2ray-core/v4/app/dispatcher"
+	"github.com/v2fly/v2ray-core/v4/app/instman"
+	"github.com/v2fly/v2ray-core/v4/app/persistentstorage/filesystemstorage"
+	"github.com/v2fly/v2ray-core/v4/app/proxyman"
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/serial"
+	"github.com/v2fly/v2ray-core/v4/features/extension"
+	_ "github.com/v2fly/v2ray-core/v4/main/distro/all"
+	"github.com/v2fly/v2ray-core/v4/testing/servers/tcp"
+)
+
+const testInstanceConfig = `{"outbounds": [{"protocol": "freedom"}]}`
+
+func newTestInstanceManager(t *testing.T, config *instman.Config, stateRoot string) (*core.Instance, extension.InstanceManagement) {
+	apps := []*anypb.Any{
+		serial.ToTypedMessage(&dispatcher.Config{}),
+		serial.ToTypedMessage(&proxyman.InboundConfig{}),
+		serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+		serial.ToTypedMessage(config),
+	}
+	if stateRoot != "" {
+		apps = append(apps, serial.ToTypedMessage(&filesystemstorage.Config{StateStorageRoot: stateRoot}))
+	}
+	server, err := core.New(&core.Config{App: apps})
+	common.Must(err)
+	common.Must(server.Start())
+	return server, server.GetFeature(extension.InstanceManagementType()).(extension.InstanceManagement)
+}
+
+func checkStatus(t *testing.T, mgr extension.InstanceManagement, name string, state extension.InstanceState) extension.InstanceStatus {
+	t.Helper()
+	status, err := mgr.ListInstanceStatus(context.Background())
+	common.Must(err)
+	for _, s := range status {
+		if s.Name == name {
+			if s.State != state {
+				t.Errorf("instance %s is %s, want %s (last error: %v)", name, s.State, state, s.LastError)
+			}
+			return s
+		}
+	}
+	t.Fatal("instance ", name, " not found")
+	return extension.InstanceStatus{}
+}
+
+func TestInstanceLifecycle(t *testing.T) {
+	server, mgr := newTestInstanceManager(t, &instman.Config{}, "")
+	defer server.Close()
+	ctx := context.Background()
+
+	common.Must(mgr.AddInstance(ctx, "test", []byte(testInstanceConfig), "json"))
+	if err := mgr.AddInstance(ctx, "test", []byte(testInstanceConfig), "json"); err == nil {
+		t.Error("expected error adding an instance twice")
+	}
+	checkStatus(t, mgr, "test", extension.InstanceCreated)
+
+	common.Must(mgr.StartInstance(ctx, "test"))
+	checkStatus(t, mgr, "test", extension.InstanceRunning)
+
+	common.Must(mgr.StopInstance(ctx, "test"))
+	checkStatus(t, mgr, "test", extension.InstanceCreated)
+
+	// A stopped instance can be started again.
+	common.Must(mgr.StartInstance(ctx, "test"))
+	checkStatus(t, mgr, "test", extension.InstanceRunning)
+
+	common.Must(mgr.UntrackInstance(ctx, "test"))
+	names, err := mgr.ListInstance(ctx)
+	common.Must(err)
+	if len(names) != 0 {
+		t.Error("unexpected instances after untracking: ", names)
+	}
+	if err := mgr.StartInstance(ctx, "test"); err == nil {
+		t.Error("expected error starting an untracked instance")
+	}
+}
+
+func TestInstanceFailure(t *testing.T) {
+	server, mgr := newTestInstanceManager(t, &instman.Config{}, "")
+	defer server.Close()
+	ctx := context.Background()
+
+	// Listening on an address not assigned to the host fails.
+	config := fmt.Sprintf(`{
+		"inbounds": [{"listen": "192.0.2.1", "port": %d, "protocol": "dokodemo-door", "settings": {"address": "127.0.0.1", "port": 80}}],
+		"outbounds": [{"protocol": "freedom"}]
+	}`, tcp.PickPort())
+	common.Must(mgr.AddInstance(ctx, "test", []byte(config), "json"))
+
+	if err := mgr.StartInstance(ctx, "test"); err == nil {
+		t.Fatal("expected error starting an instance listening on an unavailable address")
+	}
+	status := checkStatus(t, mgr, "test", extension.InstanceFailed)
+	if status.LastError == nil {
+		t.Error("expected last error of failed instance")
+	}
+
+	common.Must(mgr.StopInstance(ctx, "test"))
+	status = checkStatus(t, mgr, "test", extension.InstanceCreated)
+	if status.LastError != nil {
+		t.Error("unexpected last error of stopped instance: ", status.LastError)
+	}
+}
+
+func TestInstanceAutoRestart(t *testing.T) {
+	server, mgr := newTestInstanceManager(t, &instman.Config{
+		AutoRestart:        true,
+		RestartIntervalSec: 1,
+		MaxRestartAttempts: 1,
+	}, "")
+	defer server.Close()
+	ctx := context.Background()
+
+	config := fmt.Sprintf(`{
+		"inbounds": [{"listen": "192.0.2.1", "port": %d, "protocol": "dokodemo-door", "settings": {"address": "127.0.0.1", "port": 80}}],
+		"outbounds": [{"protocol": "freedom"}]
+	}`, tcp.PickPort())
+	common.Must(mgr.AddInstance(ctx, "test", []byte(config), "json"))
+	if err := mgr.StartInstance(ctx, "test"); err == nil {
+		t.Fatal("expected error starting an instance listening on an unavailable address")
+	}
+
+	time.Sleep(2500 * time.Millisecond)
+	status := checkStatus(t, mgr, "test", extension.InstanceFailed)
+	if status.RestartCount != 1 {
+		t.Error("unexpected restart count: ", status.RestartCount)
+	}
+}
+
+func TestInstancePersistence(t *testing.T) {
+	stateRoot := t.TempDir()
+	ctx := context.Background()
+
+	server, mgr := newTestInstanceManager(t, &instman.Config{Persist: true}, stateRoot)
+	common.Must(mgr.AddInstance(ctx, "running", []byte(testInstanceConfig), "json"))
+	common.Must(mgr.StartInstance(ctx, "running"))
+	common.Must(mgr.AddInstance(ctx, "stopped", []byte(testInstanceConfig), "json"))
+	common.Must(mgr.AddInstance(ctx, "removed", []byte(testInstanceConfig), "json"))
+	common.Must(mgr.UntrackInstance(ctx, "removed"))
+	common.Must(server.Close())
+
+	server, mgr = newTestInstanceManager(t, &instman.Config{Persist: true}, stateRoot)
+	defer server.Close()
+
+	names, err := mgr.ListInstance(ctx)
+	common.Must(err)
+	if fmt.Sprint(names) != "[running stopped]" {
+		t.Error("unexpected restored instances: ", names)
+	}
+	checkStatus(t, mgr, "running", extension.InstanceRunning)
+	checkStatus(t, mgr, "stopped", extension.InstanceCreated)
+}
@@ -50,7 +50,10 @@ func New(ctx context.Context, config *Config) (*Instance, error) {
 
 func (g *Instance) initAccessLogger() error {
 	handler, err := createHandler(g.config.Access.Type, HandlerCreatorOptions{
-		Path: g.config.Access.Path,
+		Path:     g.config.Access.Path,
+		Format:   g.config.Access.Format,
+		Rotation: g.config.Access.Rotation,
+		Tag:      g.config.Access.Tag,
 	})
 	if err != nil {
 		return err
@@ -61,7 +64,10 @@ func (g *Instance) initAccessLogger() error {
 
 func (g *Instance) initErrorLogger() error {
 	handler, err := createHandler(g.config.Error.Type, HandlerCreatorOptions{
-		Path: g.config.Error.Path,
+		Path:     g.config.Error.Path,
+		Format:   g.config.Error.Format,
+		Rotation: g.config.Error.Rotation,
+		Tag:      g.config.Error.Tag,
 	})
 	if err != nil {
 		return err
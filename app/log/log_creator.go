@@ -1,12 +1,27 @@
 package log
 
 import (
+	"time"
+
 	"github.com/v2fly/v2ray-core/v4/common"
 	"github.com/v2fly/v2ray-core/v4/common/log"
 )
 
 type HandlerCreatorOptions struct {
-	Path string
+	Path     string
+	Format   LogFormat
+	Rotation *LogRotation
+	Tag      string
+}
+
+// defaultTag is the identifier of entries in system logs.
+const defaultTag = "v2ray"
+
+func (o HandlerCreatorOptions) tag() string {
+	if o.Tag == "" {
+		return defaultTag
+	}
+	return o.Tag
 }
 
 type HandlerCreator func(LogType, HandlerCreatorOptions) (log.Handler, error)
@@ -30,17 +45,56 @@ func createHandler(logType LogType, options HandlerCreatorOptions) (log.Handler,
 	return creator(logType, options)
 }
 
+func toRotationOptions(r *LogRotation) log.RotationOptions {
+	return log.RotationOptions{
+		MaxSize:    int64(r.MaxSize) * 1024 * 1024,
+		Interval:   time.Duration(r.Interval) * time.Second,
+		MaxBackups: int(r.MaxBackups),
+		MaxAge:     time.Duration(r.MaxAge) * 24 * time.Hour,
+		Compress:   r.Compress,
+	}
+}
+
+func newLogger(format LogFormat, creator log.WriterCreator) log.Handler {
+	if format == LogFormat_JSON {
+		return log.NewJSONLogger(creator)
+	}
+	return log.NewLogger(creator)
+}
+
 func init() {
 	common.Must(RegisterHandlerCreator(LogType_Console, func(lt LogType, options HandlerCreatorOptions) (log.Handler, error) {
-		return log.NewLogger(log.CreateStdoutLogWriter()), nil
+		return newLogger(options.Format, log.CreateStdoutLogWriter()), nil
 	}))
 
 	common.Must(RegisterHandlerCreator(LogType_File, func(lt LogType, options HandlerCreatorOptions) (log.Handler, error) {
-		creator, err := log.CreateFileLogWriter(options.Path)
+		var creator log.WriterCreator
+		var err error
+		if options.Rotation != nil {
+			creator, err = log.CreateRotatingFileLogWriter(options.Path, toRotationOptions(options.Rotation))
+		} else {
+			creator, err = log.CreateFileLogWriter(options.Path)
+		}
 		if err != nil {
 			return nil, err
 		}
-		return log.NewLogger(creator), nil
+		return newLogger(options.Format, creator), nil
+	}))
+
+	common.Must(RegisterHandlerCreator(LogType_Syslog, func(lt LogType, options HandlerCreatorOptions) (log.Handler, error) {
+		creator, err := log.CreateSyslogLogWriter(options.Path, options.tag())
+		if err != nil {
+			return nil, newError("failed to connect to syslog").Base(err)
+		}
+		return newLogger(options.Format, creator), nil
+	}))
+
+	common.Must(RegisterHandlerCreator(LogType_Journald, func(lt LogType, options HandlerCreatorOptions) (log.Handler, error) {
+		creator, err := log.CreateJournaldLogWriter(options.Path, options.tag())
+		if err != nil {
+			return nil, newError("failed to connect to journald").Base(err)
+		}
+		return newLogger(options.Format, creator), nil
 	}))
 
 	common.Must(RegisterHandlerCreator(LogType_None, func(lt LogType, options HandlerCreatorOptions) (log.Handler, error) {
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	typeCounter = "counter"
+	typeGauge   = "gauge"
+)
+
+type sample struct {
+	labels string
+	value  float64
+}
+
+type family struct {
+	name    string
+	help    string
+	typ     string
+	samples []sample
+}
+
+// exposition is a set of metric families, written in the Prometheus text format or OpenMetrics.
+type exposition struct {
+	families []*family
+	index    map[string]*family
+}
+
+func newExposition() *exposition {
+	return &exposition{index: make(map[string]*family)}
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// add adds a sample to the family of the name, with labels given as pairs of names and values.
+func (e *exposition) add(name, typ, help string, value float64, labels ...string) {
+	f := e.index[name]
+	if f == nil {
+		f = &family{name: name, help: help, typ: typ}
+		e.index[name] = f
+		e.families = append(e.families, f)
+	}
+
+	var b strings.Builder
+	for i := 0; i+1 < len(labels); i += 2 {
+		if b.Len() == 0 {
+			b.WriteByte('{')
+		} else {
+			b.WriteByte(',')
+		}
+		b.WriteString(labels[i])
+		b.WriteString(`="`)
+		b.WriteString(labelValueEscaper.Replace(labels[i+1]))
+		b.WriteByte('"')
+	}
+	if b.Len() > 0 {
+		b.WriteByte('}')
+	}
+	f.samples = append(f.samples, sample{labels: b.String(), value: value})
+}
+
+// writeTo writes the families in the Prometheus text format, or OpenMetrics if openMetrics is set.
+func (e *exposition) writeTo(w io.Writer, openMetrics bool) error {
+	var b strings.Builder
+	for _, f := range e.families {
+		name := f.name
+		if openMetrics && f.typ == typeCounter {
+			// Counter families of OpenMetrics are named without the suffix of their samples.
+			name = strings.TrimSuffix(name, "_total")
+		}
+		b.WriteString("# HELP " + name + " " + f.help + "\n")
+		b.WriteString("# TYPE " + name + " " + f.typ + "\n")
+		sort.SliceStable(f.samples, func(i, j int) bool {
+			return f.samples[i].labels < f.samples[j].labels
+		})
+		for _, s := range f.samples {
+			b.WriteString(f.name)
+			b.WriteString(s.labels)
+			b.WriteByte(' ')
+			b.WriteString(strconv.FormatFloat(s.value, 'g', -1, 64))
+			b.WriteByte('\n')
+		}
+	}
+	if openMetrics {
+		b.WriteString("# EOF\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
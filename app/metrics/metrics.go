@@ -0,0 +1,218 @@
+// Package metrics exports the stats counters, runtime figures and observatory results as Prometheus metrics.
+package metrics
+
+//go:generate go run github.com/v2fly/v2ray-core/v4/common/errors/errorgen
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	core "github.com/v2fly/v2ray-core/v4"
+	"github.com/v2fly/v2ray-core/v4/app/observatory"
+	"github.com/v2fly/v2ray-core/v4/app/stats"
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/features/extension"
+	feature_stats "github.com/v2fly/v2ray-core/v4/features/stats"
+	"github.com/v2fly/v2ray-core/v4/transport/internet"
+)
+
+const (
+	defaultPath = "/metrics"
+
+	contentTypeText        = "text/plain; version=0.0.4; charset=utf-8"
+	contentTypeOpenMetrics = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
+
+// Metrics serves the metrics over HTTP.
+type Metrics struct {
+	access   sync.Mutex
+	ctx      context.Context
+	config   *Config
+	stats    feature_stats.Manager
+	listener net.Listener
+	start    time.Time
+}
+
+// New creates a new Metrics with the config.
+func New(ctx context.Context, config *Config) (*Metrics, error) {
+	m := &Metrics{
+		ctx:    ctx,
+		config: config,
+		start:  time.Now(),
+	}
+	if err := core.RequireFeatures(ctx, func(sm feature_stats.Manager) {
+		m.stats = sm
+	}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Type implements common.HasType.
+func (*Metrics) Type() interface{} {
+	return (*Metrics)(nil)
+}
+
+// Start implements common.Runnable.
+func (m *Metrics) Start() error {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	var listener net.Listener
+	var err error
+	address := net.ParseAddress(m.config.ListenAddr)
+	switch {
+	case address.Family().IsIP():
+		listener, err = internet.ListenSystem(m.ctx, &net.TCPAddr{IP: address.IP(), Port: int(m.config.ListenPort)}, nil)
+	case strings.EqualFold(address.Domain(), "localhost"):
+		listener, err = internet.ListenSystem(m.ctx, &net.TCPAddr{IP: net.IP{127, 0, 0, 1}, Port: int(m.config.ListenPort)}, nil)
+	default:
+		return newError("metrics cannot listen on the address: ", address)
+	}
+	if err != nil {
+		return newError("metrics cannot listen on the port ", m.config.ListenPort).Base(err)
+	}
+	m.listener = listener
+
+	path := m.config.Path
+	if path == "" {
+		path = defaultPath
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, m)
+	go func() {
+		if err := http.Serve(listener, mux); err != nil {
+			newError("stopped serving metrics").Base(err).AtInfo().WriteToLog()
+		}
+	}()
+	return nil
+}
+
+// Close implements common.Closable.
+func (m *Metrics) Close() error {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	if m.listener != nil {
+		return m.listener.Close()
+	}
+	return nil
+}
+
+// ServeHTTP implements http.Handler. OpenMetrics is served if the scraper accepts it.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e := newExposition()
+	m.collectCounters(e)
+	m.collectRuntime(e)
+	m.collectObservatory(r.Context(), e)
+
+	openMetrics := strings.Contains(r.Header.Get("Accept"), "application/openmetrics-text")
+	if openMetrics {
+		w.Header().Set("Content-Type", contentTypeOpenMetrics)
+	} else {
+		w.Header().Set("Content-Type", contentTypeText)
+	}
+	if err := e.writeTo(w, openMetrics); err != nil {
+		newError("failed to write metrics").Base(err).AtDebug().WriteToLog()
+	}
+}
+
+// collectCounters adds the stats counters. Traffic counters, named as "inbound>>>tag>>>traffic>>>uplink", are
+// labelled with their dimension and target, and other counters with their names.
+func (m *Metrics) collectCounters(e *exposition) {
+	manager, ok := m.stats.(*stats.Manager)
+	if !ok {
+		return
+	}
+	manager.VisitCounters(func(name string, c feature_stats.Counter) bool {
+		value := float64(c.Value())
+		parts := strings.Split(name, ">>>")
+		if len(parts) == 4 && parts[2] == "traffic" && (parts[3] == "uplink" || parts[3] == "downlink") {
+			e.add("v2ray_traffic_"+parts[3]+"_bytes_total", typeCounter, "Traffic "+parts[3]+" in bytes.", value,
+				"dimension", parts[0], "target", parts[1])
+		} else {
+			e.add("v2ray_counter", typeGauge, "Value of a stats counter.", value, "name", name)
+		}
+		return true
+	})
+}
+
+// collectRuntime adds the figures of the Go runtime, as the StatsService reports them.
+func (m *Metrics) collectRuntime(e *exposition) {
+	var rtm runtime.MemStats
+	runtime.ReadMemStats(&rtm)
+
+	e.add("v2ray_uptime_seconds", typeGauge, "Time since V2Ray is started.", time.Since(m.start).Seconds())
+	e.add("v2ray_goroutines", typeGauge, "Number of goroutines.", float64(runtime.NumGoroutine()))
+	e.add("v2ray_memstats_heap_alloc_bytes", typeGauge, "Bytes of allocated heap objects.", float64(rtm.Alloc))
+	e.add("v2ray_memstats_alloc_bytes_total", typeCounter, "Cumulative bytes allocated for heap objects.", float64(rtm.TotalAlloc))
+	e.add("v2ray_memstats_sys_bytes", typeGauge, "Bytes of memory obtained from the OS.", float64(rtm.Sys))
+	e.add("v2ray_memstats_mallocs_total", typeCounter, "Cumulative count of heap objects allocated.", float64(rtm.Mallocs))
+	e.add("v2ray_memstats_frees_total", typeCounter, "Cumulative count of heap objects freed.", float64(rtm.Frees))
+	e.add("v2ray_memstats_live_objects", typeGauge, "Number of live heap objects.", float64(rtm.Mallocs-rtm.Frees))
+	e.add("v2ray_memstats_gc_cycles_total", typeCounter, "Number of completed GC cycles.", float64(rtm.NumGC))
+	e.add("v2ray_memstats_gc_pause_seconds_total", typeCounter, "Cumulative time GC has stopped the world.", float64(rtm.PauseTotalNs)/1e9)
+}
+
+// collectObservatory adds the health of the outbounds under observation, if there is an observatory.
+func (m *Metrics) collectObservatory(ctx context.Context, e *exposition) {
+	instance := core.FromContext(m.ctx)
+	if instance == nil {
+		return
+	}
+	o, ok := instance.GetFeature(extension.ObservatoryType()).(extension.Observatory)
+	if !ok {
+		return
+	}
+	observation, err := o.GetObservation(ctx)
+	if err != nil {
+		newError("failed to get observation").Base(err).AtDebug().WriteToLog()
+		return
+	}
+	result, ok := observation.(*observatory.ObservationResult)
+	if !ok {
+		return
+	}
+	for _, status := range result.Status {
+		tag := status.OutboundTag
+		alive := 0.0
+		if status.Alive {
+			alive = 1
+		}
+		e.add("v2ray_observatory_alive", typeGauge, "Whether the outbound is alive.", alive, "outbound", tag)
+		e.add("v2ray_observatory_delay_seconds", typeGauge, "Time for the probe request to finish.",
+			float64(status.Delay)/1e3, "outbound", tag)
+		if status.LastSeenTime > 0 {
+			e.add("v2ray_observatory_last_seen_timestamp_seconds", typeGauge, "Time the outbound is last known to be alive.",
+				float64(status.LastSeenTime), "outbound", tag)
+		}
+		if status.LastTryTime > 0 {
+			e.add("v2ray_observatory_last_try_timestamp_seconds", typeGauge, "Time the outbound is last probed.",
+				float64(status.LastTryTime), "outbound", tag)
+		}
+		if hp := status.HealthPing; hp != nil {
+			e.add("v2ray_observatory_health_ping_probes", typeGauge, "Number of health pings in the sampling window.",
+				float64(hp.All), "outbound", tag)
+			e.add("v2ray_observatory_health_ping_failures", typeGauge, "Number of failed health pings in the sampling window.",
+				float64(hp.Fail), "outbound", tag)
+			for _, rtt := range []struct {
+				stat  string
+				value int64
+			}{{"average", hp.Average}, {"deviation", hp.Deviation}, {"max", hp.Max}, {"min", hp.Min}} {
+				e.add("v2ray_observatory_health_ping_rtt_seconds", typeGauge, "Round trip time of health pings.",
+					time.Duration(rtt.value).Seconds(), "outbound", tag, "stat", rtt.stat)
+			}
+		}
+	}
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return New(ctx, config.(*Config))
+	}))
+}
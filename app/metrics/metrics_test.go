@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/v2fly/v2ray-core/v4/app/stats"
+	"github.com/v2fly/v2ray-core/v4/common"
+)
+
+func newTestMetrics() *Metrics {
+	sm, err := stats.NewManager(context.Background(), &stats.Config{})
+	common.Must(err)
+	common.Must2(sm.RegisterCounter("inbound>>>socks>>>traffic>>>uplink")).(*stats.Counter).Set(1024)
+	common.Must2(sm.RegisterCounter("user>>>a\"b>>>traffic>>>downlink")).(*stats.Counter).Set(2048)
+	common.Must2(sm.RegisterCounter("custom")).(*stats.Counter).Set(7)
+	return &Metrics{ctx: context.Background(), config: &Config{}, stats: sm}
+}
+
+func TestTextExposition(t *testing.T) {
+	m := newTestMetrics()
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != contentTypeText {
+		t.Error("unexpected content type ", ct)
+	}
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE v2ray_traffic_uplink_bytes_total counter\n",
+		"v2ray_traffic_uplink_bytes_total{dimension=\"inbound\",target=\"socks\"} 1024\n",
+		"v2ray_traffic_downlink_bytes_total{dimension=\"user\",target=\"a\\\"b\"} 2048\n",
+		"v2ray_counter{name=\"custom\"} 7\n",
+		"# TYPE v2ray_goroutines gauge\n",
+	} {
+		if !strings.Contains(body, line) {
+			t.Error("expected line ", line, " in ", body)
+		}
+	}
+	if strings.Contains(body, "# EOF") {
+		t.Error("unexpected EOF marker in text format")
+	}
+}
+
+func TestOpenMetricsExposition(t *testing.T) {
+	m := newTestMetrics()
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0,text/plain;q=0.5")
+	m.ServeHTTP(rec, req)
+
+	if ct := rec.Header().Get("Content-Type"); ct != contentTypeOpenMetrics {
+		t.Error("unexpected content type ", ct)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "# TYPE v2ray_traffic_uplink_bytes counter\n") {
+		t.Error("expected counter family without suffix in ", body)
+	}
+	if !strings.HasSuffix(body, "# EOF\n") {
+		t.Error("expected EOF marker at the end of ", body)
+	}
+}
+
+func TestExpositionFamilyNames(t *testing.T) {
+	m := newTestMetrics()
+	for _, accept := range []string{"text/plain", "application/openmetrics-text; version=1.0.0"} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.Header.Set("Accept", accept)
+		m.ServeHTTP(rec, req)
+
+		families := make(map[string]bool)
+		for _, line := range strings.Split(rec.Body.String(), "\n") {
+			if !strings.HasPrefix(line, "# TYPE ") {
+				continue
+			}
+			name := strings.Fields(line)[2]
+			if families[name] {
+				t.Error("duplicate family ", name, " in ", accept)
+			}
+			families[name] = true
+		}
+		if !families["v2ray_memstats_heap_alloc_bytes"] {
+			t.Error("expected runtime families in ", accept)
+		}
+	}
+}
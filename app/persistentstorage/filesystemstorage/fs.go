@@ -0,0 +1,188 @@
+package filesystemstorage
+
+//go:generate go run github.com/v2fly/v2ray-core/v4/common/errors/errorgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/platform"
+	"github.com/v2fly/v2ray-core/v4/features/extension/storage"
+)
+
+const (
+	keyFilePrefix      = "k_"
+	scopeDirPrefix     = "s_"
+	characteristicFile = ".characteristic"
+	defaultInstance    = "default"
+)
+
+// scope is a directory holding one file per key and one sub directory per narrowed scope.
+// Keys and scope names are hex encoded so that any byte sequence maps to a valid file name
+// and lexical order of file names matches the byte order of keys.
+type scope struct {
+	access *sync.RWMutex
+	path   string
+}
+
+func (s *scope) ScopedPersistentStorageEngine() {}
+
+func (s *scope) keyPath(key []byte) string {
+	return filepath.Join(s.path, keyFilePrefix+hex.EncodeToString(key))
+}
+
+// Put stores value under key. A nil value removes the key.
+func (s *scope) Put(ctx context.Context, key []byte, value []byte) error {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	target := s.keyPath(key)
+	if value == nil {
+		if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+			return newError("failed to remove key ", hex.EncodeToString(key)).Base(err)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(s.path, 0o700); err != nil {
+		return newError("failed to create storage directory ", s.path).Base(err)
+	}
+	return writeFileAtomic(target, value)
+}
+
+func (s *scope) Get(ctx context.Context, key []byte) ([]byte, error) {
+	s.access.RLock()
+	defer s.access.RUnlock()
+
+	value, err := os.ReadFile(s.keyPath(key))
+	if os.IsNotExist(err) {
+		return nil, storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, newError("failed to read key ", hex.EncodeToString(key)).Base(err)
+	}
+	return value, nil
+}
+
+func (s *scope) List(ctx context.Context, keyPrefix []byte) ([][]byte, error) {
+	s.access.RLock()
+	defer s.access.RUnlock()
+
+	entries, err := os.ReadDir(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, newError("failed to list storage directory ", s.path).Base(err)
+	}
+	var keys [][]byte
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, keyFilePrefix) {
+			continue
+		}
+		key, err := hex.DecodeString(strings.TrimPrefix(name, keyFilePrefix))
+		if err != nil {
+			continue
+		}
+		if bytes.HasPrefix(key, keyPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// ClearIfCharacteristicMismatch wipes the scope, including all narrowed scopes, when the
+// characteristic recorded for it differs from the given one. The new characteristic is
+// recorded afterwards.
+func (s *scope) ClearIfCharacteristicMismatch(ctx context.Context, characteristic []byte) error {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	characteristicPath := filepath.Join(s.path, characteristicFile)
+	if recorded, err := os.ReadFile(characteristicPath); err == nil && bytes.Equal(recorded, characteristic) {
+		return nil
+	}
+	if err := os.RemoveAll(s.path); err != nil {
+		return newError("failed to clear storage directory ", s.path).Base(err)
+	}
+	if err := os.MkdirAll(s.path, 0o700); err != nil {
+		return newError("failed to create storage directory ", s.path).Base(err)
+	}
+	return writeFileAtomic(characteristicPath, characteristic)
+}
+
+func (s *scope) NarrowScope(ctx context.Context, key []byte) (storage.ScopedPersistentStorage, error) {
+	return &scope{
+		access: s.access,
+		path:   filepath.Join(s.path, scopeDirPrefix+hex.EncodeToString(key)),
+	}, nil
+}
+
+func writeFileAtomic(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return newError("failed to create temporary file").Base(err)
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return newError("failed to write ", path).Base(err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return newError("failed to write ", path).Base(err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return newError("failed to replace ", path).Base(err)
+	}
+	return nil
+}
+
+// StorageFS is a ScopedPersistentStorageService that keeps its data in the local file system.
+type StorageFS struct {
+	scope
+}
+
+func (s *StorageFS) Type() interface{} {
+	return storage.ScopedPersistentStorageServiceType()
+}
+
+func (s *StorageFS) Start() error {
+	if err := os.MkdirAll(s.path, 0o700); err != nil {
+		return newError("failed to create storage directory ", s.path).Base(err)
+	}
+	return nil
+}
+
+func (s *StorageFS) Close() error {
+	return nil
+}
+
+// NewFileSystemStorage creates a StorageFS rooted at the directory given in config.
+func NewFileSystemStorage(ctx context.Context, config *Config) *StorageFS {
+	root := config.StateStorageRoot
+	if root == "" {
+		root = platform.GetStateDirectory()
+	}
+	instance := config.InstanceName
+	if instance == "" {
+		instance = defaultInstance
+	}
+	return &StorageFS{scope: scope{
+		access: new(sync.RWMutex),
+		path:   filepath.Join(root, instance),
+	}}
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return NewFileSystemStorage(ctx, config.(*Config)), nil
+	}))
+}
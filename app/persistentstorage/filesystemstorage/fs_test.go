@@ -0,0 +1,70 @@
+package filesystemstorage_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/v2fly/v2ray-core/v4/app/persistentstorage/filesystemstorage"
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/features/extension/storage"
+)
+
+func TestFileSystemStoragePutGetList(t *testing.T) {
+	ctx := context.Background()
+	fs := filesystemstorage.NewFileSystemStorage(ctx, &filesystemstorage.Config{
+		StateStorageRoot: t.TempDir(),
+		InstanceName:     "test",
+	})
+	common.Must(fs.Start())
+
+	common.Must(fs.Put(ctx, []byte("a1"), []byte("v1")))
+	common.Must(fs.Put(ctx, []byte("a2"), []byte("v2")))
+	common.Must(fs.Put(ctx, []byte("b1"), []byte("v3")))
+
+	value, err := fs.Get(ctx, []byte("a2"))
+	common.Must(err)
+	if !bytes.Equal(value, []byte("v2")) {
+		t.Error("unexpected value: ", string(value))
+	}
+
+	keys, err := fs.List(ctx, []byte("a"))
+	common.Must(err)
+	if len(keys) != 2 || string(keys[0]) != "a1" || string(keys[1]) != "a2" {
+		t.Error("unexpected keys: ", keys)
+	}
+
+	common.Must(fs.Put(ctx, []byte("a1"), nil))
+	if _, err := fs.Get(ctx, []byte("a1")); err != storage.ErrNotFound {
+		t.Error("expected key to be removed, got ", err)
+	}
+}
+
+func TestFileSystemStorageScope(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	fs := filesystemstorage.NewFileSystemStorage(ctx, &filesystemstorage.Config{StateStorageRoot: root})
+	common.Must(fs.Start())
+
+	sub, err := fs.NarrowScope(ctx, []byte("app"))
+	common.Must(err)
+	common.Must(sub.ClearIfCharacteristicMismatch(ctx, []byte("c1")))
+	common.Must(sub.Put(ctx, []byte("k"), []byte("v")))
+
+	if _, err := fs.Get(ctx, []byte("k")); err != storage.ErrNotFound {
+		t.Error("key leaked out of its scope")
+	}
+
+	reopened := filesystemstorage.NewFileSystemStorage(ctx, &filesystemstorage.Config{StateStorageRoot: root})
+	sub, err = reopened.NarrowScope(ctx, []byte("app"))
+	common.Must(err)
+	common.Must(sub.ClearIfCharacteristicMismatch(ctx, []byte("c1")))
+	if value, err := sub.Get(ctx, []byte("k")); err != nil || string(value) != "v" {
+		t.Error("value not persisted: ", value, err)
+	}
+
+	common.Must(sub.ClearIfCharacteristicMismatch(ctx, []byte("c2")))
+	if _, err := sub.Get(ctx, []byte("k")); err != storage.ErrNotFound {
+		t.Error("expected scope to be cleared, got ", err)
+	}
+}
@@ -0,0 +1,110 @@
+package command
+
+//go:generate go run github.com/v2fly/v2ray-core/v4/common/errors/errorgen
+
+import (
+	"context"
+
+	grpc "google.golang.org/grpc"
+
+	core "github.com/v2fly/v2ray-core/v4"
+	"github.com/v2fly/v2ray-core/v4/app/policy"
+	"github.com/v2fly/v2ray-core/v4/common"
+	feature_policy "github.com/v2fly/v2ray-core/v4/features/policy"
+)
+
+// policyServer is an implementation of PolicyService.
+type policyServer struct {
+	manager feature_policy.Manager
+}
+
+func NewPolicyServer(manager feature_policy.Manager) PolicyServiceServer {
+	return &policyServer{
+		manager: manager,
+	}
+}
+
+func (s *policyServer) instance() (*policy.Instance, error) {
+	instance, ok := s.manager.(*policy.Instance)
+	if !ok {
+		return nil, newError("policy is not configured")
+	}
+	return instance, nil
+}
+
+func toBandwidth(b feature_policy.Bandwidth) *policy.Policy_Bandwidth {
+	return &policy.Policy_Bandwidth{
+		Uplink:   uint64(b.Uplink),
+		Downlink: uint64(b.Downlink),
+	}
+}
+
+func (s *policyServer) GetBandwidth(ctx context.Context, request *GetBandwidthRequest) (*GetBandwidthResponse, error) {
+	instance, err := s.instance()
+	if err != nil {
+		return nil, err
+	}
+	levels, inbounds, outbounds := instance.Bandwidths()
+	response := &GetBandwidthResponse{
+		Level:    make(map[uint32]*policy.Policy_Bandwidth, len(levels)),
+		Inbound:  make(map[string]*policy.Policy_Bandwidth, len(inbounds)),
+		Outbound: make(map[string]*policy.Policy_Bandwidth, len(outbounds)),
+	}
+	for level, b := range levels {
+		response.Level[level] = toBandwidth(b)
+	}
+	for tag, b := range inbounds {
+		response.Inbound[tag] = toBandwidth(b)
+	}
+	for tag, b := range outbounds {
+		response.Outbound[tag] = toBandwidth(b)
+	}
+	return response, nil
+}
+
+func (s *policyServer) SetBandwidth(ctx context.Context, request *SetBandwidthRequest) (*SetBandwidthResponse, error) {
+	instance, err := s.instance()
+	if err != nil {
+		return nil, err
+	}
+	b := request.Bandwidth.ToCorePolicy()
+	switch target := request.Target.(type) {
+	case *SetBandwidthRequest_Level:
+		instance.SetLevelBandwidth(target.Level, b)
+	case *SetBandwidthRequest_InboundTag:
+		if target.InboundTag == "" {
+			return nil, newError("empty inbound tag")
+		}
+		instance.SetInboundBandwidth(target.InboundTag, b)
+	case *SetBandwidthRequest_OutboundTag:
+		if target.OutboundTag == "" {
+			return nil, newError("empty outbound tag")
+		}
+		instance.SetOutboundBandwidth(target.OutboundTag, b)
+	default:
+		return nil, newError("bandwidth target is not specified")
+	}
+	return &SetBandwidthResponse{}, nil
+}
+
+func (s *policyServer) mustEmbedUnimplementedPolicyServiceServer() {}
+
+type service struct {
+	policyManager feature_policy.Manager
+}
+
+func (s *service) Register(server *grpc.Server) {
+	RegisterPolicyServiceServer(server, NewPolicyServer(s.policyManager))
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, cfg interface{}) (interface{}, error) {
+		s := new(service)
+
+		core.RequireFeatures(ctx, func(pm feature_policy.Manager) {
+			s.policyManager = pm
+		})
+
+		return s, nil
+	}))
+}
@@ -58,6 +58,29 @@ func (p *Policy) overrideWith(another *Policy) {
 			Connection: another.Buffer.Connection,
 		}
 	}
+	if another.Bandwidth != nil {
+		p.Bandwidth = &Policy_Bandwidth{
+			Uplink:   another.Bandwidth.Uplink,
+			Downlink: another.Bandwidth.Downlink,
+		}
+	}
+	if another.Connection != nil {
+		p.Connection = &Policy_Connection{
+			PerUser:    another.Connection.PerUser,
+			IpsPerUser: another.Connection.IpsPerUser,
+		}
+	}
+}
+
+// ToCorePolicy converts this Bandwidth to policy.Bandwidth.
+func (b *Policy_Bandwidth) ToCorePolicy() policy.Bandwidth {
+	if b == nil {
+		return policy.Bandwidth{}
+	}
+	return policy.Bandwidth{
+		Uplink:   int64(b.Uplink),
+		Downlink: int64(b.Downlink),
+	}
 }
 
 // ToCorePolicy converts this Policy to policy.Session.
@@ -77,6 +100,11 @@ func (p *Policy) ToCorePolicy() policy.Session {
 	if p.Buffer != nil {
 		cp.Buffer.PerConnection = p.Buffer.Connection
 	}
+	cp.Bandwidth = p.Bandwidth.ToCorePolicy()
+	if p.Connection != nil {
+		cp.Connection.PerUser = p.Connection.PerUser
+		cp.Connection.IPsPerUser = p.Connection.IpsPerUser
+	}
 	return cp
 }
 
@@ -84,10 +112,11 @@ func (p *Policy) ToCorePolicy() policy.Session {
 func (p *SystemPolicy) ToCorePolicy() policy.System {
 	return policy.System{
 		Stats: policy.SystemStats{
-			InboundUplink:    p.Stats.InboundUplink,
-			InboundDownlink:  p.Stats.InboundDownlink,
-			OutboundUplink:   p.Stats.OutboundUplink,
-			OutboundDownlink: p.Stats.OutboundDownlink,
+			InboundUplink:    p.GetStats().GetInboundUplink(),
+			InboundDownlink:  p.GetStats().GetInboundDownlink(),
+			OutboundUplink:   p.GetStats().GetOutboundUplink(),
+			OutboundDownlink: p.GetStats().GetOutboundDownlink(),
 		},
+		ConnectionsPerIP: p.ConnectionsPerIp,
 	}
 }
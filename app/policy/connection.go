@@ -0,0 +1,120 @@
+package policy
+
+import (
+	"sync"
+
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/protocol"
+	"github.com/v2fly/v2ray-core/v4/features/policy"
+)
+
+// userConnections are the connections of a user, by their source IPs.
+type userConnections struct {
+	count int
+	ips   map[string]int
+}
+
+// connectionCounter counts simultaneous connections of source IPs and users.
+type connectionCounter struct {
+	access sync.Mutex
+	ips    map[string]int
+	users  map[string]*userConnections
+}
+
+type connection struct {
+	manager *Instance
+	// ip is empty if the source is not an IP.
+	ip string
+	// ipCounted is whether the connection is counted for its source IP.
+	ipCounted bool
+	// email is the user the connection is counted for, if any.
+	email  string
+	closed bool
+}
+
+// NewConnection implements policy.ConnectionManager.
+func (m *Instance) NewConnection(source net.Destination) (policy.Connection, error) {
+	c := &connection{
+		manager: m,
+	}
+	if source.Address != nil && source.Address.Family().IsIP() {
+		c.ip = source.Address.IP().String()
+	}
+
+	limit := m.ForSystem().ConnectionsPerIP
+	if limit == 0 || c.ip == "" {
+		return c, nil
+	}
+	counter := &m.connections
+	counter.access.Lock()
+	defer counter.access.Unlock()
+
+	if counter.ips[c.ip] >= int(limit) {
+		return nil, newError("too many connections from ", c.ip)
+	}
+	counter.ips[c.ip]++
+	c.ipCounted = true
+	return c, nil
+}
+
+// SetUser implements policy.Connection.
+func (c *connection) SetUser(user *protocol.MemoryUser) error {
+	if user == nil || user.Email == "" {
+		return nil
+	}
+	limit := c.manager.ForLevel(user.Level).Connection
+	if limit.PerUser == 0 && limit.IPsPerUser == 0 {
+		return nil
+	}
+	counter := &c.manager.connections
+	counter.access.Lock()
+	defer counter.access.Unlock()
+
+	if c.email != "" || c.closed {
+		return nil
+	}
+	u, found := counter.users[user.Email]
+	if !found {
+		u = &userConnections{
+			ips: make(map[string]int),
+		}
+	}
+	if limit.PerUser > 0 && u.count >= int(limit.PerUser) {
+		return newError("too many connections of user ", user.Email)
+	}
+	if limit.IPsPerUser > 0 && u.ips[c.ip] == 0 && len(u.ips) >= int(limit.IPsPerUser) {
+		return newError("too many source IPs of user ", user.Email)
+	}
+	u.count++
+	u.ips[c.ip]++
+	counter.users[user.Email] = u
+	c.email = user.Email
+	return nil
+}
+
+// Close implements policy.Connection.
+func (c *connection) Close() error {
+	counter := &c.manager.connections
+	counter.access.Lock()
+	defer counter.access.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	if c.ipCounted {
+		if counter.ips[c.ip]--; counter.ips[c.ip] <= 0 {
+			delete(counter.ips, c.ip)
+		}
+	}
+	if u, found := counter.users[c.email]; found && c.email != "" {
+		u.count--
+		if u.ips[c.ip]--; u.ips[c.ip] <= 0 {
+			delete(u.ips, c.ip)
+		}
+		if u.count <= 0 {
+			delete(counter.users, c.email)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/protocol"
+)
+
+func TestEvictIdleUsers(t *testing.T) {
+	manager, err := New(context.Background(), &Config{
+		Level: map[uint32]*Policy{
+			0: {
+				Bandwidth: &Policy_Bandwidth{Uplink: 1024},
+				Connection: &Policy_Connection{
+					PerUser: 2,
+				},
+			},
+		},
+	})
+	common.Must(err)
+
+	idle := &protocol.MemoryUser{Email: "idle@v2fly.org"}
+	connected := &protocol.MemoryUser{Email: "connected@v2fly.org"}
+	manager.ForUser(idle)
+	manager.ForUser(connected)
+
+	conn, err := manager.NewConnection(net.TCPDestination(net.LocalHostIP, 1234))
+	common.Must(err)
+	common.Must(conn.SetUser(connected))
+
+	manager.evictIdleUsers(time.Now().Add(time.Minute))
+	if _, found := manager.users[idle.Email]; found {
+		t.Error("expected idle user to be evicted")
+	}
+	if _, found := manager.users[connected.Email]; !found {
+		t.Error("expected user with connections to be kept")
+	}
+
+	common.Must(conn.Close())
+	manager.evictIdleUsers(time.Now().Add(-time.Minute))
+	if _, found := manager.users[connected.Email]; !found {
+		t.Error("expected recently used user to be kept")
+	}
+	manager.evictIdleUsers(time.Now().Add(time.Minute))
+	if len(manager.users) != 0 {
+		t.Error("unexpected users: ", manager.users)
+	}
+}
@@ -2,22 +2,55 @@ package policy
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/protocol"
+	"github.com/v2fly/v2ray-core/v4/common/task"
 	"github.com/v2fly/v2ray-core/v4/features/policy"
 )
 
+// userIdleTimeout is how long the limiters of a user without connections are kept since they are last used.
+const userIdleTimeout = 10 * time.Minute
+
+// userLimiters are the limiters of a user, and the level they are limited by.
+type userLimiters struct {
+	level    uint32
+	limiters policy.RateLimiters
+	// lastUsed is the unix time in seconds the limiters were last returned by ForUser.
+	lastUsed int64
+}
+
 // Instance is an instance of Policy manager.
 type Instance struct {
+	access sync.RWMutex
 	levels map[uint32]*Policy
 	system *SystemPolicy
+
+	users     map[string]*userLimiters
+	inbounds  map[string]policy.RateLimiters
+	outbounds map[string]policy.RateLimiters
+
+	connections connectionCounter
+
+	evictTask *task.Periodic
 }
 
 // New creates new Policy manager instance.
 func New(ctx context.Context, config *Config) (*Instance, error) {
 	m := &Instance{
-		levels: make(map[uint32]*Policy),
-		system: config.System,
+		levels:    make(map[uint32]*Policy),
+		system:    config.System,
+		users:     make(map[string]*userLimiters),
+		inbounds:  make(map[string]policy.RateLimiters),
+		outbounds: make(map[string]policy.RateLimiters),
+		connections: connectionCounter{
+			ips:   make(map[string]int),
+			users: make(map[string]*userConnections),
+		},
 	}
 	if len(config.Level) > 0 {
 		for lv, p := range config.Level {
@@ -26,10 +59,49 @@ func New(ctx context.Context, config *Config) (*Instance, error) {
 			m.levels[lv] = pp
 		}
 	}
+	for tag, b := range config.System.GetInboundBandwidth() {
+		m.inbounds[tag] = newRateLimiters(b.ToCorePolicy())
+	}
+	for tag, b := range config.System.GetOutboundBandwidth() {
+		m.outbounds[tag] = newRateLimiters(b.ToCorePolicy())
+	}
+	m.evictTask = &task.Periodic{
+		Interval: time.Minute,
+		Execute: func() error {
+			m.evictIdleUsers(time.Now().Add(-userIdleTimeout))
+			return nil
+		},
+	}
 
 	return m, nil
 }
 
+func newRateLimiters(b policy.Bandwidth) policy.RateLimiters {
+	var limiters policy.RateLimiters
+	if b.Uplink > 0 {
+		limiters.Uplink = buf.NewRateLimiter(b.Uplink)
+	}
+	if b.Downlink > 0 {
+		limiters.Downlink = buf.NewRateLimiter(b.Downlink)
+	}
+	return limiters
+}
+
+// setRateLimiters adjusts the limiters to the bandwidth. Limiters are created if the traffic was not limited.
+func setRateLimiters(limiters policy.RateLimiters, b policy.Bandwidth) policy.RateLimiters {
+	if limiters.Uplink != nil {
+		limiters.Uplink.SetRate(b.Uplink)
+	} else if b.Uplink > 0 {
+		limiters.Uplink = buf.NewRateLimiter(b.Uplink)
+	}
+	if limiters.Downlink != nil {
+		limiters.Downlink.SetRate(b.Downlink)
+	} else if b.Downlink > 0 {
+		limiters.Downlink = buf.NewRateLimiter(b.Downlink)
+	}
+	return limiters
+}
+
 // Type implements common.HasType.
 func (*Instance) Type() interface{} {
 	return policy.ManagerType()
@@ -37,6 +109,9 @@ func (*Instance) Type() interface{} {
 
 // ForLevel implements policy.Manager.
 func (m *Instance) ForLevel(level uint32) policy.Session {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
 	if p, ok := m.levels[level]; ok {
 		return p.ToCorePolicy()
 	}
@@ -51,14 +126,167 @@ func (m *Instance) ForSystem() policy.System {
 	return m.system.ToCorePolicy()
 }
 
+// ForUser implements policy.BandwidthManager. Users are identified by their emails.
+func (m *Instance) ForUser(user *protocol.MemoryUser) policy.RateLimiters {
+	if user == nil || user.Email == "" {
+		return policy.RateLimiters{}
+	}
+
+	m.access.RLock()
+	u, found := m.users[user.Email]
+	m.access.RUnlock()
+	if found && u.level == user.Level {
+		atomic.StoreInt64(&u.lastUsed, time.Now().Unix())
+		return u.limiters
+	}
+
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	if u, found := m.users[user.Email]; found && u.level == user.Level {
+		atomic.StoreInt64(&u.lastUsed, time.Now().Unix())
+		return u.limiters
+	}
+	var b policy.Bandwidth
+	if p, ok := m.levels[user.Level]; ok {
+		b = p.Bandwidth.ToCorePolicy()
+	}
+	if b.Uplink == 0 && b.Downlink == 0 {
+		delete(m.users, user.Email)
+		return policy.RateLimiters{}
+	}
+	u = &userLimiters{
+		level:    user.Level,
+		limiters: newRateLimiters(b),
+		lastUsed: time.Now().Unix(),
+	}
+	m.users[user.Email] = u
+	return u.limiters
+}
+
+// ReleaseUser implements policy.BandwidthManager.
+func (m *Instance) ReleaseUser(email string) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	delete(m.users, email)
+}
+
+// evictIdleUsers discards the limiters of the users that are last used before the given time, and have no connections
+// counted. Connections in progress keep the limiters they use.
+func (m *Instance) evictIdleUsers(before time.Time) {
+	m.connections.access.Lock()
+	connected := make(map[string]bool, len(m.connections.users))
+	for email := range m.connections.users {
+		connected[email] = true
+	}
+	m.connections.access.Unlock()
+
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	for email, u := range m.users {
+		if atomic.LoadInt64(&u.lastUsed) < before.Unix() && !connected[email] {
+			delete(m.users, email)
+		}
+	}
+}
+
+// ForInbound implements policy.BandwidthManager.
+func (m *Instance) ForInbound(tag string) policy.RateLimiters {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
+	return m.inbounds[tag]
+}
+
+// ForOutbound implements policy.BandwidthManager.
+func (m *Instance) ForOutbound(tag string) policy.RateLimiters {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
+	return m.outbounds[tag]
+}
+
+// SetLevelBandwidth changes the bandwidth of each user of the level. Connections in progress of users that were
+// limited are adjusted at once, and the others are limited from their next connections.
+func (m *Instance) SetLevelBandwidth(level uint32, b policy.Bandwidth) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	p, ok := m.levels[level]
+	if !ok {
+		p = defaultPolicy()
+		m.levels[level] = p
+	}
+	p.Bandwidth = &Policy_Bandwidth{
+		Uplink:   uint64(b.Uplink),
+		Downlink: uint64(b.Downlink),
+	}
+	for _, u := range m.users {
+		if u.level == level {
+			u.limiters = setRateLimiters(u.limiters, b)
+		}
+	}
+}
+
+// SetInboundBandwidth changes the bandwidth of the inbound handler with the given tag.
+func (m *Instance) SetInboundBandwidth(tag string, b policy.Bandwidth) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	m.inbounds[tag] = setRateLimiters(m.inbounds[tag], b)
+}
+
+// SetOutboundBandwidth changes the bandwidth of the outbound handler with the given tag.
+func (m *Instance) SetOutboundBandwidth(tag string, b policy.Bandwidth) {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	m.outbounds[tag] = setRateLimiters(m.outbounds[tag], b)
+}
+
+// Bandwidths returns the bandwidth of each level, inbound and outbound handler that is limited.
+func (m *Instance) Bandwidths() (levels map[uint32]policy.Bandwidth, inbounds map[string]policy.Bandwidth, outbounds map[string]policy.Bandwidth) {
+	m.access.RLock()
+	defer m.access.RUnlock()
+
+	levels = make(map[uint32]policy.Bandwidth)
+	for level, p := range m.levels {
+		if p.Bandwidth != nil {
+			levels[level] = p.Bandwidth.ToCorePolicy()
+		}
+	}
+	inbounds = make(map[string]policy.Bandwidth)
+	for tag, limiters := range m.inbounds {
+		inbounds[tag] = rateOf(limiters)
+	}
+	outbounds = make(map[string]policy.Bandwidth)
+	for tag, limiters := range m.outbounds {
+		outbounds[tag] = rateOf(limiters)
+	}
+	return
+}
+
+func rateOf(limiters policy.RateLimiters) policy.Bandwidth {
+	var b policy.Bandwidth
+	if limiters.Uplink != nil {
+		b.Uplink = limiters.Uplink.Rate()
+	}
+	if limiters.Downlink != nil {
+		b.Downlink = limiters.Downlink.Rate()
+	}
+	return b
+}
+
 // Start implements common.Runnable.Start().
 func (m *Instance) Start() error {
-	return nil
+	return m.evictTask.Start()
 }
 
 // Close implements common.Closable.Close().
 func (m *Instance) Close() error {
-	return nil
+	return m.evictTask.Close()
 }
 
 func init() {
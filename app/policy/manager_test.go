@@ -7,6 +7,8 @@ import (
 
 	. "github.com/v2fly/v2ray-core/v4/app/policy"
 	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/protocol"
 	"github.com/v2fly/v2ray-core/v4/features/policy"
 )
 
@@ -43,3 +45,102 @@ func TestPolicy(t *testing.T) {
 		}
 	}
 }
+
+func TestPolicyBandwidth(t *testing.T) {
+	manager, err := New(context.Background(), &Config{
+		Level: map[uint32]*Policy{
+			1: {
+				Bandwidth: &Policy_Bandwidth{Uplink: 1024},
+			},
+		},
+		System: &SystemPolicy{
+			OutboundBandwidth: map[string]*Policy_Bandwidth{
+				"out": {Downlink: 2048},
+			},
+		},
+	})
+	common.Must(err)
+
+	user := &protocol.MemoryUser{Email: "test@v2fly.org", Level: 1}
+	limiters := manager.ForUser(user)
+	if limiters.Uplink == nil || limiters.Uplink.Rate() != 1024 || limiters.Downlink != nil {
+		t.Fatal("unexpected user limiters: ", limiters)
+	}
+	if manager.ForUser(user).Uplink != limiters.Uplink {
+		t.Error("expected connections of a user to share limiters")
+	}
+	if l := manager.ForUser(&protocol.MemoryUser{Email: "other@v2fly.org"}); l.Uplink != nil || l.Downlink != nil {
+		t.Error("unexpected limiters of unlimited level: ", l)
+	}
+	if l := manager.ForOutbound("out"); l.Downlink == nil || l.Downlink.Rate() != 2048 {
+		t.Error("unexpected outbound limiters: ", l)
+	}
+
+	manager.SetLevelBandwidth(1, policy.Bandwidth{Uplink: 4096, Downlink: 4096})
+	if limiters.Uplink.Rate() != 4096 {
+		t.Error("expected limiter of user in progress to be adjusted, but got ", limiters.Uplink.Rate())
+	}
+	if l := manager.ForUser(user); l.Downlink == nil || l.Downlink.Rate() != 4096 {
+		t.Error("unexpected downlink limiter of user: ", l)
+	}
+	if b := manager.ForLevel(1).Bandwidth; b.Uplink != 4096 || b.Downlink != 4096 {
+		t.Error("unexpected bandwidth of level: ", b)
+	}
+
+	manager.ReleaseUser(user.Email)
+	if manager.ForUser(user).Uplink == limiters.Uplink {
+		t.Error("expected limiters of a released user to be discarded")
+	}
+
+	manager.SetInboundBandwidth("in", policy.Bandwidth{Uplink: 512})
+	_, inbounds, _ := manager.Bandwidths()
+	if inbounds["in"].Uplink != 512 {
+		t.Error("unexpected inbound bandwidth: ", inbounds)
+	}
+}
+
+func TestPolicyConnectionLimit(t *testing.T) {
+	manager, err := New(context.Background(), &Config{
+		Level: map[uint32]*Policy{
+			0: {
+				Connection: &Policy_Connection{PerUser: 2, IpsPerUser: 1},
+			},
+		},
+		System: &SystemPolicy{
+			ConnectionsPerIp: 2,
+		},
+	})
+	common.Must(err)
+
+	source := net.TCPDestination(net.ParseAddress("1.2.3.4"), 1024)
+	c1, err := manager.NewConnection(source)
+	common.Must(err)
+	c2, err := manager.NewConnection(source)
+	common.Must(err)
+	if _, err := manager.NewConnection(source); err == nil {
+		t.Error("expected connection over the limit of source IP to be rejected")
+	}
+
+	user := &protocol.MemoryUser{Email: "test@v2fly.org"}
+	common.Must(c1.SetUser(user))
+	common.Must(c2.SetUser(user))
+
+	c3, err := manager.NewConnection(net.TCPDestination(net.ParseAddress("5.6.7.8"), 1024))
+	common.Must(err)
+	if err := c3.SetUser(user); err == nil {
+		t.Error("expected connection over the limit of user to be rejected")
+	}
+
+	common.Must(c1.Close())
+	common.Must(c1.Close())
+	if err := c3.SetUser(user); err == nil {
+		t.Error("expected connection over the limit of source IPs of user to be rejected")
+	}
+	common.Must(c2.Close())
+	if err := c3.SetUser(user); err != nil {
+		t.Error("expected connection to be accepted, but got ", err)
+	}
+	if _, err := manager.NewConnection(source); err != nil {
+		t.Error("expected connection to be accepted after others close, but got ", err)
+	}
+}
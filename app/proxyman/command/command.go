@@ -10,6 +10,7 @@ import (
 	"github.com/v2fly/v2ray-core/v4/common"
 	"github.com/v2fly/v2ray-core/v4/features/inbound"
 	"github.com/v2fly/v2ray-core/v4/features/outbound"
+	"github.com/v2fly/v2ray-core/v4/features/policy"
 	"github.com/v2fly/v2ray-core/v4/proxy"
 )
 
@@ -69,6 +70,15 @@ type handlerServer struct {
 	ohm outbound.Manager
 }
 
+// NewHandlerServer creates a HandlerService server operating on the handlers of the given instance.
+func NewHandlerServer(s *core.Instance, ihm inbound.Manager, ohm outbound.Manager) HandlerServiceServer {
+	return &handlerServer{
+		s:   s,
+		ihm: ihm,
+		ohm: ohm,
+	}
+}
+
 func (s *handlerServer) AddInbound(ctx context.Context, request *AddInboundRequest) (*AddInboundResponse, error) {
 	if err := core.AddInboundHandler(s.s, request.Inbound); err != nil {
 		return nil, err
@@ -96,7 +106,15 @@ func (s *handlerServer) AlterInbound(ctx context.Context, request *AlterInboundR
 		return nil, newError("failed to get handler: ", request.Tag).Base(err)
 	}
 
-	return &AlterInboundResponse{}, operation.ApplyInbound(ctx, handler)
+	if err := operation.ApplyInbound(ctx, handler); err != nil {
+		return nil, err
+	}
+	if op, ok := operation.(*RemoveUserOperation); ok {
+		if bm, ok := s.s.GetFeature(policy.ManagerType()).(policy.BandwidthManager); ok {
+			bm.ReleaseUser(op.Email)
+		}
+	}
+	return &AlterInboundResponse{}, nil
 }
 
 func (s *handlerServer) AddOutbound(ctx context.Context, request *AddOutboundRequest) (*AddOutboundResponse, error) {
@@ -0,0 +1,66 @@
+package inbound
+
+import (
+	"context"
+
+	core "github.com/v2fly/v2ray-core/v4"
+	"github.com/v2fly/v2ray-core/v4/common/log"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/session"
+	"github.com/v2fly/v2ray-core/v4/features/policy"
+	"github.com/v2fly/v2ray-core/v4/features/routing"
+	"github.com/v2fly/v2ray-core/v4/transport"
+)
+
+// getConnectionManager returns the policy manager of the instance if it limits connections, or nil.
+func getConnectionManager(v *core.Instance) policy.ConnectionManager {
+	if m, ok := v.GetFeature(policy.ManagerType()).(policy.ConnectionManager); ok {
+		return m
+	}
+	return nil
+}
+
+// limitedDispatcher counts the connection for the user of each request before dispatching it.
+type limitedDispatcher struct {
+	routing.Dispatcher
+	conn policy.Connection
+}
+
+// Dispatch implements routing.Dispatcher.
+func (d *limitedDispatcher) Dispatch(ctx context.Context, dest net.Destination) (*transport.Link, error) {
+	if inbound := session.InboundFromContext(ctx); inbound != nil && inbound.User != nil {
+		if err := d.conn.SetUser(inbound.User); err != nil {
+			log.Record(&log.AccessMessage{
+				From:   inbound.Source,
+				To:     dest,
+				Status: log.AccessRejected,
+				Reason: err,
+				Email:  inbound.User.Email,
+			})
+			return nil, newError("connection rejected").Base(err)
+		}
+	}
+	return d.Dispatcher.Dispatch(ctx, dest)
+}
+
+// acceptConnection applies the connection limits to a new connection from the source. It returns the connection to
+// close when it ends, and the dispatcher for its requests.
+func acceptConnection(m policy.ConnectionManager, source net.Destination, dispatcher routing.Dispatcher) (policy.Connection, routing.Dispatcher, error) {
+	if m == nil {
+		return nil, dispatcher, nil
+	}
+	conn, err := m.NewConnection(source)
+	if err != nil {
+		log.Record(&log.AccessMessage{
+			From:   source,
+			To:     "",
+			Status: log.AccessRejected,
+			Reason: err,
+		})
+		return nil, nil, err
+	}
+	return conn, &limitedDispatcher{
+		Dispatcher: dispatcher,
+		conn:       conn,
+	}, nil
+}
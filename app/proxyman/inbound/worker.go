@@ -14,6 +14,7 @@ import (
 	"github.com/v2fly/v2ray-core/v4/common/session"
 	"github.com/v2fly/v2ray-core/v4/common/signal/done"
 	"github.com/v2fly/v2ray-core/v4/common/task"
+	"github.com/v2fly/v2ray-core/v4/features/policy"
 	"github.com/v2fly/v2ray-core/v4/features/routing"
 	"github.com/v2fly/v2ray-core/v4/features/stats"
 	"github.com/v2fly/v2ray-core/v4/proxy"
@@ -38,6 +39,7 @@ type tcpWorker struct {
 	recvOrigDest    bool
 	tag             string
 	dispatcher      routing.Dispatcher
+	connections     policy.ConnectionManager
 	sniffingConfig  *proxyman.SniffingConfig
 	uplinkCounter   stats.Counter
 	downlinkCounter stats.Counter
@@ -78,8 +80,19 @@ func (w *tcpWorker) callback(conn internet.Connection) {
 			})
 		}
 	}
+	source := net.DestinationFromAddr(conn.RemoteAddr())
+	limited, dispatcher, err := acceptConnection(w.connections, source, w.dispatcher)
+	if err != nil {
+		newError("connection rejected").Base(err).WriteToLog(session.ExportIDToError(ctx))
+		cancel()
+		conn.Close()
+		return
+	}
+	if limited != nil {
+		defer limited.Close()
+	}
 	ctx = session.ContextWithInbound(ctx, &session.Inbound{
-		Source:  net.DestinationFromAddr(conn.RemoteAddr()),
+		Source:  source,
 		Gateway: net.TCPDestination(w.address, w.port),
 		Tag:     w.tag,
 	})
@@ -97,7 +110,7 @@ func (w *tcpWorker) callback(conn internet.Connection) {
 			WriteCounter: w.downlinkCounter,
 		}
 	}
-	if err := w.proxy.Process(ctx, net.Network_TCP, conn, w.dispatcher); err != nil {
+	if err := w.proxy.Process(ctx, net.Network_TCP, conn, dispatcher); err != nil {
 		newError("connection ends").Base(err).WriteToLog(session.ExportIDToError(ctx))
 	}
 	cancel()
@@ -236,6 +249,7 @@ type udpWorker struct {
 	tag             string
 	stream          *internet.MemoryStreamConfig
 	dispatcher      routing.Dispatcher
+	connections     policy.ConnectionManager
 	sniffingConfig  *proxyman.SniffingConfig
 	uplinkCounter   stats.Counter
 	downlinkCounter stats.Counter
@@ -304,6 +318,19 @@ func (w *udpWorker) callback(b *buf.Buffer, source net.Destination, originalDest
 					Target: originalDest,
 				})
 			}
+			limited, dispatcher, err := acceptConnection(w.connections, source, w.dispatcher)
+			if err != nil {
+				newError("connection rejected").Base(err).WriteToLog(session.ExportIDToError(ctx))
+				conn.Close()
+				if !conn.inactive {
+					conn.setInactive()
+					w.removeConn(id)
+				}
+				return
+			}
+			if limited != nil {
+				defer limited.Close()
+			}
 			ctx = session.ContextWithInbound(ctx, &session.Inbound{
 				Source:  source,
 				Gateway: net.UDPDestination(w.address, w.port),
@@ -316,7 +343,7 @@ func (w *udpWorker) callback(b *buf.Buffer, source net.Destination, originalDest
 				content.SniffingRequest.MetadataOnly = w.sniffingConfig.MetadataOnly
 			}
 			ctx = session.ContextWithContent(ctx, content)
-			if err := w.proxy.Process(ctx, net.Network_UDP, conn, w.dispatcher); err != nil {
+			if err := w.proxy.Process(ctx, net.Network_UDP, conn, dispatcher); err != nil {
 				newError("connection ends").Base(err).WriteToLog(session.ExportIDToError(ctx))
 			}
 			conn.Close()
@@ -428,6 +455,7 @@ type dsWorker struct {
 	stream          *internet.MemoryStreamConfig
 	tag             string
 	dispatcher      routing.Dispatcher
+	connections     policy.ConnectionManager
 	sniffingConfig  *proxyman.SniffingConfig
 	uplinkCounter   stats.Counter
 	downlinkCounter stats.Counter
@@ -442,8 +470,19 @@ func (w *dsWorker) callback(conn internet.Connection) {
 	sid := session.NewID()
 	ctx = session.ContextWithID(ctx, sid)
 
+	source := net.DestinationFromAddr(conn.RemoteAddr())
+	limited, dispatcher, err := acceptConnection(w.connections, source, w.dispatcher)
+	if err != nil {
+		newError("connection rejected").Base(err).WriteToLog(session.ExportIDToError(ctx))
+		cancel()
+		conn.Close()
+		return
+	}
+	if limited != nil {
+		defer limited.Close()
+	}
 	ctx = session.ContextWithInbound(ctx, &session.Inbound{
-		Source:  net.DestinationFromAddr(conn.RemoteAddr()),
+		Source:  source,
 		Gateway: net.UnixDestination(w.address),
 		Tag:     w.tag,
 	})
@@ -461,7 +500,7 @@ func (w *dsWorker) callback(conn internet.Connection) {
 			WriteCounter: w.downlinkCounter,
 		}
 	}
-	if err := w.proxy.Process(ctx, net.Network_UNIX, conn, w.dispatcher); err != nil {
+	if err := w.proxy.Process(ctx, net.Network_UNIX, conn, dispatcher); err != nil {
 		newError("connection ends").Base(err).WriteToLog(session.ExportIDToError(ctx))
 	}
 	cancel()
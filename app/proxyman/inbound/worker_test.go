@@ -0,0 +1,157 @@
+package inbound
+
+import (
+	"context"
+	gonet "net"
+	"testing"
+
+	"github.com/v2fly/v2ray-core/v4/app/policy"
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/protocol"
+	"github.com/v2fly/v2ray-core/v4/common/session"
+	"github.com/v2fly/v2ray-core/v4/features/routing"
+	"github.com/v2fly/v2ray-core/v4/transport"
+	"github.com/v2fly/v2ray-core/v4/transport/internet"
+)
+
+type testConn struct {
+	gonet.Conn
+	remote gonet.Addr
+}
+
+func (c *testConn) RemoteAddr() gonet.Addr {
+	return c.remote
+}
+
+type testDispatcher struct{}
+
+func (testDispatcher) Type() interface{} {
+	return routing.DispatcherType()
+}
+
+func (testDispatcher) Start() error {
+	return nil
+}
+
+func (testDispatcher) Close() error {
+	return nil
+}
+
+func (testDispatcher) Dispatch(ctx context.Context, dest net.Destination) (*transport.Link, error) {
+	return &transport.Link{}, nil
+}
+
+// testInbound authenticates every connection as the user, and holds it until released.
+type testInbound struct {
+	user    *protocol.MemoryUser
+	results chan error
+	release chan struct{}
+}
+
+func (p *testInbound) Network() []net.Network {
+	return []net.Network{net.Network_TCP}
+}
+
+func (p *testInbound) Process(ctx context.Context, network net.Network, conn internet.Connection, dispatcher routing.Dispatcher) error {
+	session.InboundFromContext(ctx).User = p.user
+	_, err := dispatcher.Dispatch(ctx, net.TCPDestination(net.LocalHostIP, 80))
+	p.results <- err
+	if err != nil {
+		return err
+	}
+	<-p.release
+	return nil
+}
+
+// accept runs a connection from the source IP through the worker, and returns a channel closed when it ends.
+func accept(w *tcpWorker, ip string) <-chan struct{} {
+	client, server := gonet.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer client.Close()
+		w.callback(&testConn{Conn: server, remote: &gonet.TCPAddr{IP: gonet.ParseIP(ip), Port: 1024}})
+	}()
+	return done
+}
+
+func TestTCPWorkerConnectionLimit(t *testing.T) {
+	manager, err := policy.New(context.Background(), &policy.Config{
+		Level: map[uint32]*policy.Policy{
+			0: {
+				Connection: &policy.Policy_Connection{PerUser: 1},
+			},
+		},
+	})
+	common.Must(err)
+	p := &testInbound{
+		user:    &protocol.MemoryUser{Email: "test@v2fly.org"},
+		results: make(chan error, 1),
+		release: make(chan struct{}),
+	}
+	w := &tcpWorker{
+		address:     net.LocalHostIP,
+		port:        1080,
+		proxy:       p,
+		tag:         "in",
+		dispatcher:  testDispatcher{},
+		connections: manager,
+		ctx:         context.Background(),
+	}
+
+	first := accept(w, "1.2.3.4")
+	if err := <-p.results; err != nil {
+		t.Fatal("expected first connection to be accepted, but got ", err)
+	}
+
+	second := accept(w, "5.6.7.8")
+	if err := <-p.results; err == nil {
+		t.Error("expected connection over the limit of user to be rejected")
+	}
+	<-second
+
+	close(p.release)
+	<-first
+	third := accept(w, "5.6.7.8")
+	if err := <-p.results; err != nil {
+		t.Error("expected connection to be accepted after the first one ends, but got ", err)
+	}
+	<-third
+}
+
+func TestTCPWorkerConnectionLimitPerIP(t *testing.T) {
+	manager, err := policy.New(context.Background(), &policy.Config{
+		System: &policy.SystemPolicy{
+			ConnectionsPerIp: 1,
+		},
+	})
+	common.Must(err)
+	p := &testInbound{
+		results: make(chan error, 2),
+		release: make(chan struct{}),
+	}
+	w := &tcpWorker{
+		address:     net.LocalHostIP,
+		port:        1080,
+		proxy:       p,
+		tag:         "in",
+		dispatcher:  testDispatcher{},
+		connections: manager,
+		ctx:         context.Background(),
+	}
+
+	first := accept(w, "1.2.3.4")
+	common.Must(<-p.results)
+
+	// The connection is closed before it reaches the proxy.
+	<-accept(w, "1.2.3.4")
+	select {
+	case err := <-p.results:
+		t.Error("expected connection over the limit of source IP to be rejected, but it is processed: ", err)
+	default:
+	}
+
+	close(p.release)
+	<-first
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/v2fly/v2ray-core/v4/features/outbound"
 	"github.com/v2fly/v2ray-core/v4/features/policy"
 	"github.com/v2fly/v2ray-core/v4/features/stats"
+	"github.com/v2fly/v2ray-core/v4/features/tracing"
 	"github.com/v2fly/v2ray-core/v4/proxy"
 	"github.com/v2fly/v2ray-core/v4/transport"
 	"github.com/v2fly/v2ray-core/v4/transport/internet"
@@ -133,9 +134,14 @@ func (h *Handler) Tag() string {
 
 // Dispatch implements proxy.Outbound.Dispatch.
 func (h *Handler) Dispatch(ctx context.Context, link *transport.Link) {
+	ctx, span := tracing.Start(ctx, "outbound", tracing.String("outbound.tag", h.tag))
+	defer span.End()
+
 	if h.mux != nil && (h.mux.Enabled || session.MuxPreferedFromContext(ctx)) {
+		span.SetAttributes(tracing.Bool("mux", true))
 		if err := h.mux.Dispatch(ctx, link); err != nil {
 			err := newError("failed to process mux outbound traffic").Base(err)
+			span.RecordError(err)
 			session.SubmitOutboundErrorToOriginator(ctx, err)
 			err.WriteToLog(session.ExportIDToError(ctx))
 			common.Interrupt(link.Writer)
@@ -144,6 +150,7 @@ func (h *Handler) Dispatch(ctx context.Context, link *transport.Link) {
 		if err := h.proxy.Process(ctx, link, h); err != nil {
 			// Ensure outbound ray is properly closed.
 			err := newError("failed to process outbound traffic").Base(err)
+			span.RecordError(err)
 			session.SubmitOutboundErrorToOriginator(ctx, err)
 			err.WriteToLog(session.ExportIDToError(ctx))
 			common.Interrupt(link.Writer)
@@ -208,7 +215,10 @@ func (h *Handler) Dial(ctx context.Context, dest net.Destination) (internet.Conn
 		ctx = session.SetTransportLayerProxyTagToContext(ctx, tag)
 	}
 
+	ctx, span := tracing.Start(ctx, "dial", tracing.String("destination", dest.NetAddr()))
 	conn, err := internet.Dial(ctx, dest, h.streamSettings)
+	span.RecordError(err)
+	span.End()
 	return h.getStatCouterConnection(conn), err
 }
 
@@ -0,0 +1,361 @@
+package quota
+
+//go:generate go run github.com/v2fly/v2ray-core/v4/common/errors/errorgen
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/environment"
+	"github.com/v2fly/v2ray-core/v4/common/environment/envctx"
+	"github.com/v2fly/v2ray-core/v4/common/session"
+	"github.com/v2fly/v2ray-core/v4/common/task"
+	"github.com/v2fly/v2ray-core/v4/features"
+	"github.com/v2fly/v2ray-core/v4/features/extension/storage"
+	"github.com/v2fly/v2ray-core/v4/features/quota"
+)
+
+const updateInterval = time.Minute
+
+// usage is the traffic counted against the quota of a user or an inbound in the current period.
+type usage struct {
+	// bytes is accessed atomically.
+	bytes int64
+
+	// Fields below are guarded by Manager.access.
+	key         string
+	limit       *Limit
+	periodStart int64
+	savedBytes  int64
+}
+
+// exceeded returns whether the usage has reached its limit. A limit of 0 bytes is unlimited, the usage is only counted.
+func (u *usage) exceeded() bool {
+	return u.limit.Bytes > 0 && atomic.LoadInt64(&u.bytes) >= int64(u.limit.Bytes)
+}
+
+// Manager enforces traffic quotas of users and inbounds.
+type Manager struct {
+	access   sync.Mutex
+	ctx      context.Context
+	config   *Config
+	location *time.Location
+	users    map[string]*Limit
+	inbounds map[string]*Limit
+	usages   map[string]*usage
+	storage  storage.ScopedPersistentStorage
+	task     *task.Periodic
+	now      func() time.Time
+}
+
+// New creates a new quota Manager from the config.
+func New(ctx context.Context, config *Config) (*Manager, error) {
+	m := &Manager{
+		ctx:      ctx,
+		config:   config,
+		location: time.Local,
+		users:    make(map[string]*Limit),
+		inbounds: make(map[string]*Limit),
+		usages:   make(map[string]*usage),
+		now:      time.Now,
+	}
+	if config.TimeZone != "" {
+		location, err := time.LoadLocation(config.TimeZone)
+		if err != nil {
+			return nil, newError("invalid time zone ", config.TimeZone).Base(err)
+		}
+		m.location = location
+	}
+	for _, q := range config.User {
+		if err := validateLimit(q.Limit); err != nil {
+			return nil, err
+		}
+		for _, email := range q.Email {
+			m.users[email] = q.Limit
+		}
+	}
+	for _, q := range config.Inbound {
+		if err := validateLimit(q.Limit); err != nil {
+			return nil, err
+		}
+		for _, tag := range q.Tag {
+			m.inbounds[tag] = q.Limit
+		}
+	}
+	for _, limit := range config.Level {
+		if err := validateLimit(limit); err != nil {
+			return nil, err
+		}
+	}
+	m.task = &task.Periodic{
+		Interval: updateInterval,
+		Execute:  m.update,
+	}
+	return m, nil
+}
+
+func validateLimit(limit *Limit) error {
+	if limit == nil {
+		return newError("quota without limit")
+	}
+	if limit.ResetDay > 28 {
+		return newError("invalid reset day ", limit.ResetDay)
+	}
+	return nil
+}
+
+// Type implements common.HasType.
+func (*Manager) Type() interface{} {
+	return quota.ManagerType()
+}
+
+// Start implements common.Runnable. It restores the persisted usages, if persistence is enabled.
+func (m *Manager) Start() error {
+	m.access.Lock()
+	if m.config.Persist {
+		if err := m.openStorage(); err != nil {
+			m.access.Unlock()
+			return err
+		}
+	}
+	m.access.Unlock()
+	return m.task.Start()
+}
+
+// Close implements common.Closable. Usages are saved, if persistence is enabled.
+func (m *Manager) Close() error {
+	if err := m.task.Close(); err != nil {
+		return err
+	}
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	m.save()
+	return nil
+}
+
+// ReplaceWith implements features.Reloadable. Limits of the new manager take effect at once, and usages counted
+// so far are kept.
+func (m *Manager) ReplaceWith(feature features.Feature) error {
+	next, ok := feature.(*Manager)
+	if !ok {
+		return newError("cannot replace quota manager with ", feature.Type())
+	}
+
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	// The storage is opened first, so that the manager keeps its settings if it fails.
+	if next.config.Persist && m.storage == nil {
+		if err := m.openStorage(); err != nil {
+			return err
+		}
+	}
+	if !next.config.Persist {
+		m.storage = nil
+	}
+	m.config = next.config
+	m.location = next.location
+	m.users = next.users
+	m.inbounds = next.inbounds
+	return nil
+}
+
+// Track implements quota.Manager.
+func (m *Manager) Track(ctx context.Context) (quota.Usage, error) {
+	inbound := session.InboundFromContext(ctx)
+	if inbound == nil {
+		return nil, nil
+	}
+
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	var usages []*usage
+	if user := inbound.User; user != nil && user.Email != "" {
+		limit, found := m.users[user.Email]
+		if !found {
+			limit = m.config.Level[user.Level]
+		}
+		if limit != nil {
+			u := m.getUsage("user>>>"+user.Email, limit)
+			if u.exceeded() {
+				return nil, newError("user ", user.Email, " is over quota")
+			}
+			usages = append(usages, u)
+		}
+	}
+	if limit, found := m.inbounds[inbound.Tag]; found && inbound.Tag != "" {
+		u := m.getUsage("inbound>>>"+inbound.Tag, limit)
+		if u.exceeded() {
+			return nil, newError("inbound ", inbound.Tag, " is over quota")
+		}
+		usages = append(usages, u)
+	}
+	if len(usages) == 0 {
+		return nil, nil
+	}
+	s := &sessionUsage{
+		usages:      usages,
+		limits:      make([]int64, len(usages)),
+		cutExisting: m.config.CutExisting,
+	}
+	for i, u := range usages {
+		s.limits[i] = int64(u.limit.Bytes)
+	}
+	return s, nil
+}
+
+// getUsage returns the usage of the key in the current period. m.access must be held.
+func (m *Manager) getUsage(key string, limit *Limit) *usage {
+	u, found := m.usages[key]
+	if !found {
+		u = &usage{key: key}
+		m.usages[key] = u
+	}
+	u.limit = limit
+	m.resetIfExpired(u, m.now())
+	return u
+}
+
+// resetIfExpired starts counting the usage again if a new period has started. m.access must be held.
+func (m *Manager) resetIfExpired(u *usage, now time.Time) {
+	start := periodStart(u.limit, now.In(m.location))
+	if start == u.periodStart {
+		return
+	}
+	atomic.StoreInt64(&u.bytes, 0)
+	u.savedBytes = -1
+	u.periodStart = start
+}
+
+// periodStart returns the Unix time of the start of the period containing the time, or 0 if the usage is never reset.
+func periodStart(limit *Limit, t time.Time) int64 {
+	year, month, day := t.Date()
+	switch limit.Period {
+	case Period_Daily:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location()).Unix()
+	case Period_Monthly:
+		resetDay := int(limit.ResetDay)
+		if resetDay == 0 {
+			resetDay = 1
+		}
+		if day < resetDay {
+			month--
+		}
+		return time.Date(year, month, resetDay, 0, 0, 0, 0, t.Location()).Unix()
+	default:
+		return 0
+	}
+}
+
+// update resets usages of expired periods, and saves the usages if persistence is enabled.
+func (m *Manager) update() error {
+	m.access.Lock()
+	defer m.access.Unlock()
+
+	now := m.now()
+	for _, u := range m.usages {
+		if u.limit != nil {
+			m.resetIfExpired(u, now)
+		}
+	}
+	m.save()
+	return nil
+}
+
+// openStorage opens the persistent storage, and restores the usages in it. m.access must be held.
+func (m *Manager) openStorage() error {
+	env, ok := envctx.EnvironmentFromContext(m.ctx).(environment.AppEnvironment)
+	if !ok {
+		return newError("persistence requires an app environment")
+	}
+	scope := env.PersistentStorage()
+	if scope == nil {
+		return newError("persistence is enabled, but there is no persistent storage")
+	}
+	keys, err := scope.List(context.Background(), nil)
+	if err != nil {
+		return newError("failed to list persisted usages").Base(err)
+	}
+	m.storage = scope
+	for _, key := range keys {
+		if _, found := m.usages[string(key)]; found {
+			continue
+		}
+		data, err := scope.Get(context.Background(), key)
+		if err != nil {
+			newError("failed to read persisted usage ", string(key)).Base(err).AtWarning().WriteToLog()
+			continue
+		}
+		state := new(UsageState)
+		if err := proto.Unmarshal(data, state); err != nil {
+			newError("failed to decode persisted usage ", string(key)).Base(err).AtWarning().WriteToLog()
+			continue
+		}
+		m.usages[string(key)] = &usage{
+			bytes:       state.Bytes,
+			key:         string(key),
+			periodStart: state.PeriodStart,
+			savedBytes:  state.Bytes,
+		}
+	}
+	return nil
+}
+
+// save writes the usages changed since last time to persistent storage, if enabled. m.access must be held.
+func (m *Manager) save() {
+	if m.storage == nil {
+		return
+	}
+	for _, u := range m.usages {
+		bytes := atomic.LoadInt64(&u.bytes)
+		if bytes == u.savedBytes {
+			continue
+		}
+		data, err := proto.Marshal(&UsageState{
+			Bytes:       bytes,
+			PeriodStart: u.periodStart,
+		})
+		if err != nil {
+			continue
+		}
+		if err := m.storage.Put(context.Background(), []byte(u.key), data); err != nil {
+			newError("failed to persist usage ", u.key).Base(err).AtWarning().WriteToLog()
+			continue
+		}
+		u.savedBytes = bytes
+	}
+}
+
+type sessionUsage struct {
+	usages []*usage
+	// limits are the limits of the usages when the session started. 0 is unlimited.
+	limits      []int64
+	cutExisting bool
+}
+
+// Add implements quota.Usage.
+func (s *sessionUsage) Add(n int64) error {
+	var exceeded *usage
+	for i, u := range s.usages {
+		if atomic.AddInt64(&u.bytes, n) >= s.limits[i] && s.limits[i] > 0 && exceeded == nil {
+			exceeded = u
+		}
+	}
+	if exceeded != nil && s.cutExisting {
+		return newError(exceeded.key, " is over quota")
+	}
+	return nil
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return New(ctx, config.(*Config))
+	}))
+}
@@ -0,0 +1,187 @@
+package quota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/anypb"
+
+	core "github.com/v2fly/v2ray-core/v4"
+	"github.com/v2fly/v2ray-core/v4/app/persistentstorage/filesystemstorage"
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/protocol"
+	"github.com/v2fly/v2ray-core/v4/common/serial"
+	"github.com/v2fly/v2ray-core/v4/common/session"
+	"github.com/v2fly/v2ray-core/v4/features/quota"
+)
+
+func userContext(email string, level uint32, tag string) context.Context {
+	return session.ContextWithInbound(context.Background(), &session.Inbound{
+		Tag:  tag,
+		User: &protocol.MemoryUser{Email: email, Level: level},
+	})
+}
+
+func TestPeriodStart(t *testing.T) {
+	location := time.FixedZone("test", 8*3600)
+	cases := []struct {
+		limit *Limit
+		time  time.Time
+		start time.Time
+	}{
+		{
+			limit: &Limit{Period: Period_Daily},
+			time:  time.Date(2021, 3, 15, 23, 59, 0, 0, location),
+			start: time.Date(2021, 3, 15, 0, 0, 0, 0, location),
+		},
+		{
+			limit: &Limit{Period: Period_Monthly},
+			time:  time.Date(2021, 3, 15, 12, 0, 0, 0, location),
+			start: time.Date(2021, 3, 1, 0, 0, 0, 0, location),
+		},
+		{
+			limit: &Limit{Period: Period_Monthly, ResetDay: 20},
+			time:  time.Date(2021, 1, 15, 12, 0, 0, 0, location),
+			start: time.Date(2020, 12, 20, 0, 0, 0, 0, location),
+		},
+		{
+			limit: &Limit{Period: Period_Monthly, ResetDay: 20},
+			time:  time.Date(2021, 1, 20, 0, 0, 0, 0, location),
+			start: time.Date(2021, 1, 20, 0, 0, 0, 0, location),
+		},
+	}
+	for _, c := range cases {
+		if start := periodStart(c.limit, c.time); start != c.start.Unix() {
+			t.Error("period start of ", c.time, ": want ", c.start, ", got ", time.Unix(start, 0).In(location))
+		}
+	}
+	if start := periodStart(&Limit{}, time.Now()); start != 0 {
+		t.Error("unexpected period start of usage never reset: ", start)
+	}
+}
+
+func TestQuotaReject(t *testing.T) {
+	m, err := New(context.Background(), &Config{
+		User:    []*UserQuota{{Email: []string{"a@v2fly.org"}, Limit: &Limit{Bytes: 100}}},
+		Inbound: []*InboundQuota{{Tag: []string{"in"}, Limit: &Limit{Bytes: 300}}},
+		Level:   map[uint32]*Limit{1: {Bytes: 50}},
+	})
+	common.Must(err)
+
+	usage, err := m.Track(userContext("a@v2fly.org", 0, "in"))
+	common.Must(err)
+	// Connections in progress are not cut by default.
+	common.Must(usage.Add(150))
+	common.Must(usage.Add(10))
+	if _, err := m.Track(userContext("a@v2fly.org", 0, "in")); err == nil {
+		t.Error("expected user over quota to be rejected")
+	}
+
+	// Users without user quota are limited by their levels.
+	usage, err = m.Track(userContext("b@v2fly.org", 1, "in"))
+	common.Must(err)
+	common.Must(usage.Add(60))
+	if _, err := m.Track(userContext("b@v2fly.org", 1, "other")); err == nil {
+		t.Error("expected user over level quota to be rejected")
+	}
+
+	// The inbound has used 220 bytes.
+	usage, err = m.Track(userContext("c@v2fly.org", 0, "in"))
+	common.Must(err)
+	common.Must(usage.Add(100))
+	if _, err := m.Track(userContext("d@v2fly.org", 0, "in")); err == nil {
+		t.Error("expected inbound over quota to be rejected")
+	}
+
+	if usage, err := m.Track(userContext("d@v2fly.org", 0, "other")); err != nil || usage != nil {
+		t.Error("expected no quota: ", usage, err)
+	}
+}
+
+func TestQuotaUnlimited(t *testing.T) {
+	m, err := New(context.Background(), &Config{
+		User:        []*UserQuota{{Email: []string{"a@v2fly.org"}, Limit: &Limit{Period: Period_Daily}}},
+		CutExisting: true,
+	})
+	common.Must(err)
+
+	usage, err := m.Track(userContext("a@v2fly.org", 0, "in"))
+	common.Must(err)
+	if err := usage.Add(1 << 30); err != nil {
+		t.Error("unexpected cut of a user without byte limit: ", err)
+	}
+	if _, err := m.Track(userContext("a@v2fly.org", 0, "in")); err != nil {
+		t.Error("unexpected rejection of a user without byte limit: ", err)
+	}
+}
+
+func TestQuotaCutExisting(t *testing.T) {
+	m, err := New(context.Background(), &Config{
+		User:        []*UserQuota{{Email: []string{"a@v2fly.org"}, Limit: &Limit{Bytes: 100}}},
+		CutExisting: true,
+	})
+	common.Must(err)
+
+	first, err := m.Track(userContext("a@v2fly.org", 0, ""))
+	common.Must(err)
+	second, err := m.Track(userContext("a@v2fly.org", 0, ""))
+	common.Must(err)
+	common.Must(first.Add(60))
+	if err := second.Add(60); err == nil {
+		t.Error("expected connection over quota to be cut")
+	}
+	if err := first.Add(1); err == nil {
+		t.Error("expected all connections of user over quota to be cut")
+	}
+}
+
+func TestQuotaReset(t *testing.T) {
+	m, err := New(context.Background(), &Config{
+		User:     []*UserQuota{{Email: []string{"a@v2fly.org"}, Limit: &Limit{Bytes: 100, Period: Period_Daily}}},
+		TimeZone: "UTC",
+	})
+	common.Must(err)
+	now := time.Date(2021, 3, 15, 23, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	usage, err := m.Track(userContext("a@v2fly.org", 0, ""))
+	common.Must(err)
+	common.Must(usage.Add(100))
+	if _, err := m.Track(userContext("a@v2fly.org", 0, "")); err == nil {
+		t.Error("expected user over quota to be rejected")
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := m.Track(userContext("a@v2fly.org", 0, "")); err != nil {
+		t.Error("expected usage to be reset on the next day: ", err)
+	}
+}
+
+func TestQuotaPersistence(t *testing.T) {
+	root := t.TempDir()
+	newServer := func() (*core.Instance, quota.Manager) {
+		server, err := core.New(&core.Config{App: []*anypb.Any{
+			serial.ToTypedMessage(&filesystemstorage.Config{StateStorageRoot: root}),
+			serial.ToTypedMessage(&Config{
+				User:    []*UserQuota{{Email: []string{"a@v2fly.org"}, Limit: &Limit{Bytes: 100, Period: Period_Monthly}}},
+				Persist: true,
+			}),
+		}})
+		common.Must(err)
+		common.Must(server.Start())
+		return server, server.GetFeature(quota.ManagerType()).(quota.Manager)
+	}
+
+	server, m := newServer()
+	usage, err := m.Track(userContext("a@v2fly.org", 0, ""))
+	common.Must(err)
+	common.Must(usage.Add(100))
+	common.Must(server.Close())
+
+	server, m = newServer()
+	defer server.Close()
+	if _, err := m.Track(userContext("a@v2fly.org", 0, "")); err == nil {
+		t.Error("expected usage over quota to be restored")
+	}
+}
@@ -0,0 +1,352 @@
+package restful_api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	core "github.com/v2fly/v2ray-core/v4"
+	"github.com/v2fly/v2ray-core/v4/app/log"
+	observatory_command "github.com/v2fly/v2ray-core/v4/app/observatory/command"
+	"github.com/v2fly/v2ray-core/v4/app/policy"
+	policy_command "github.com/v2fly/v2ray-core/v4/app/policy/command"
+	proxyman_command "github.com/v2fly/v2ray-core/v4/app/proxyman/command"
+	reverse_command "github.com/v2fly/v2ray-core/v4/app/reverse/command"
+	router_command "github.com/v2fly/v2ray-core/v4/app/router/command"
+	stats_command "github.com/v2fly/v2ray-core/v4/app/stats/command"
+	cmlog "github.com/v2fly/v2ray-core/v4/common/log"
+	"github.com/v2fly/v2ray-core/v4/features/extension"
+)
+
+// The management endpoints speak the JSON mapping of the messages of the corresponding gRPC services,
+// so that a request body or response is exactly what protojson produces for the gRPC message.
+
+const (
+	maxRequestBodySize = 1 << 20
+	maxConfigSize      = 16 << 20
+)
+
+func renderError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	render.Status(r, status)
+	render.JSON(w, r, render.M{"error": err.Error()})
+}
+
+func renderMessage(w http.ResponseWriter, r *http.Request, message proto.Message) {
+	data, err := protojson.Marshal(message)
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, newError("failed to marshal response").Base(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func decodeMessage(r *http.Request, message proto.Message) error {
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBodySize))
+	if err != nil {
+		return newError("failed to read request body").Base(err)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if err := protojson.Unmarshal(data, message); err != nil {
+		return newError("invalid request body").Base(err)
+	}
+	return nil
+}
+
+// handleMessage decodes the request into req, calls the given service method and renders its reply.
+func handleMessage(w http.ResponseWriter, r *http.Request, req proto.Message, call func(ctx context.Context) (proto.Message, error)) {
+	if err := decodeMessage(r, req); err != nil {
+		renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	resp, err := call(r.Context())
+	if err != nil {
+		renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	renderMessage(w, r, resp)
+}
+
+func (rs *restfulService) addInbound(w http.ResponseWriter, r *http.Request) {
+	req := new(proxyman_command.AddInboundRequest)
+	handleMessage(w, r, req, func(ctx context.Context) (proto.Message, error) {
+		return rs.handlerServer.AddInbound(ctx, req)
+	})
+}
+
+func (rs *restfulService) removeInbound(w http.ResponseWriter, r *http.Request) {
+	req := new(proxyman_command.RemoveInboundRequest)
+	handleMessage(w, r, req, func(ctx context.Context) (proto.Message, error) {
+		req.Tag = chi.URLParam(r, "tag")
+		return rs.handlerServer.RemoveInbound(ctx, req)
+	})
+}
+
+func (rs *restfulService) alterInbound(w http.ResponseWriter, r *http.Request) {
+	req := new(proxyman_command.AlterInboundRequest)
+	handleMessage(w, r, req, func(ctx context.Context) (proto.Message, error) {
+		req.Tag = chi.URLParam(r, "tag")
+		return rs.handlerServer.AlterInbound(ctx, req)
+	})
+}
+
+func (rs *restfulService) addOutbound(w http.ResponseWriter, r *http.Request) {
+	req := new(proxyman_command.AddOutboundRequest)
+	handleMessage(w, r, req, func(ctx context.Context) (proto.Message, error) {
+		return rs.handlerServer.AddOutbound(ctx, req)
+	})
+}
+
+func (rs *restfulService) removeOutbound(w http.ResponseWriter, r *http.Request) {
+	req := new(proxyman_command.RemoveOutboundRequest)
+	handleMessage(w, r, req, func(ctx context.Context) (proto.Message, error) {
+		req.Tag = chi.URLParam(r, "tag")
+		return rs.handlerServer.RemoveOutbound(ctx, req)
+	})
+}
+
+func (rs *restfulService) alterOutbound(w http.ResponseWriter, r *http.Request) {
+	req := new(proxyman_command.AlterOutboundRequest)
+	handleMessage(w, r, req, func(ctx context.Context) (proto.Message, error) {
+		req.Tag = chi.URLParam(r, "tag")
+		return rs.handlerServer.AlterOutbound(ctx, req)
+	})
+}
+
+func (rs *restfulService) testRoute(w http.ResponseWriter, r *http.Request) {
+	req := new(router_command.TestRouteRequest)
+	handleMessage(w, r, req, func(ctx context.Context) (proto.Message, error) {
+		return rs.routingServer.TestRoute(ctx, req)
+	})
+}
+
+func (rs *restfulService) balancerInfo(w http.ResponseWriter, r *http.Request) {
+	req := new(router_command.GetBalancerInfoRequest)
+	handleMessage(w, r, req, func(ctx context.Context) (proto.Message, error) {
+		req.Tag = chi.URLParam(r, "tag")
+		return rs.routingServer.GetBalancerInfo(ctx, req)
+	})
+}
+
+func (rs *restfulService) overrideBalancer(w http.ResponseWriter, r *http.Request) {
+	req := new(router_command.OverrideBalancerTargetRequest)
+	handleMessage(w, r, req, func(ctx context.Context) (proto.Message, error) {
+		req.BalancerTag = chi.URLParam(r, "tag")
+		return rs.routingServer.OverrideBalancerTarget(ctx, req)
+	})
+}
+
+func (rs *restfulService) outboundStatus(w http.ResponseWriter, r *http.Request) {
+	req := new(observatory_command.GetOutboundStatusRequest)
+	handleMessage(w, r, req, func(ctx context.Context) (proto.Message, error) {
+		if tag := r.URL.Query().Get("tag"); tag != "" {
+			req.Tag = tag
+		}
+		if rs.instance == nil {
+			return nil, newError("observatory is not available")
+		}
+		observatory, ok := rs.instance.GetFeature(extension.ObservatoryType()).(extension.Observatory)
+		if !ok {
+			return nil, newError("observatory is not enabled")
+		}
+		return observatory_command.NewObservatoryServer(observatory).GetOutboundStatus(ctx, req)
+	})
+}
+
+func (rs *restfulService) portalStatus(w http.ResponseWriter, r *http.Request) {
+	req := new(reverse_command.GetPortalStatusRequest)
+	handleMessage(w, r, req, func(ctx context.Context) (proto.Message, error) {
+		if tag := r.URL.Query().Get("tag"); tag != "" {
+			req.Tag = tag
+		}
+		return rs.reverseServer.GetPortalStatus(ctx, req)
+	})
+}
+
+// queryStats accepts the fields of QueryStatsRequest either as query parameters or as the request body.
+// Repeated "pattern" parameters are combined.
+func (rs *restfulService) queryStats(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &stats_command.QueryStatsRequest{
+		Patterns: query["pattern"],
+	}
+	for _, field := range []struct {
+		name  string
+		value *bool
+	}{{"reset", &req.Reset_}, {"regexp", &req.Regexp}} {
+		if v := query.Get(field.name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				renderError(w, r, http.StatusBadRequest, newError("invalid value of ", field.name).Base(err))
+				return
+			}
+			*field.value = b
+		}
+	}
+	handleMessage(w, r, req, func(ctx context.Context) (proto.Message, error) {
+		return rs.statsServer.QueryStats(ctx, req)
+	})
+}
+
+func (rs *restfulService) sysStats(w http.ResponseWriter, r *http.Request) {
+	req := new(stats_command.SysStatsRequest)
+	handleMessage(w, r, req, func(ctx context.Context) (proto.Message, error) {
+		return rs.statsServer.GetSysStats(ctx, req)
+	})
+}
+
+func (rs *restfulService) getBandwidth(w http.ResponseWriter, r *http.Request) {
+	req := new(policy_command.GetBandwidthRequest)
+	handleMessage(w, r, req, func(ctx context.Context) (proto.Message, error) {
+		return rs.policyServer.GetBandwidth(ctx, req)
+	})
+}
+
+// setBandwidth takes the bandwidth as the request body, and applies it to the target of req.
+func (rs *restfulService) setBandwidth(w http.ResponseWriter, r *http.Request, req *policy_command.SetBandwidthRequest) {
+	req.Bandwidth = new(policy.Policy_Bandwidth)
+	handleMessage(w, r, req.Bandwidth, func(ctx context.Context) (proto.Message, error) {
+		return rs.policyServer.SetBandwidth(ctx, req)
+	})
+}
+
+func (rs *restfulService) setLevelBandwidth(w http.ResponseWriter, r *http.Request) {
+	level, err := strconv.ParseUint(chi.URLParam(r, "level"), 10, 32)
+	if err != nil {
+		renderError(w, r, http.StatusBadRequest, newError("invalid level").Base(err))
+		return
+	}
+	rs.setBandwidth(w, r, &policy_command.SetBandwidthRequest{
+		Target: &policy_command.SetBandwidthRequest_Level{Level: uint32(level)},
+	})
+}
+
+func (rs *restfulService) setInboundBandwidth(w http.ResponseWriter, r *http.Request) {
+	rs.setBandwidth(w, r, &policy_command.SetBandwidthRequest{
+		Target: &policy_command.SetBandwidthRequest_InboundTag{InboundTag: chi.URLParam(r, "tag")},
+	})
+}
+
+func (rs *restfulService) setOutboundBandwidth(w http.ResponseWriter, r *http.Request) {
+	rs.setBandwidth(w, r, &policy_command.SetBandwidthRequest{
+		Target: &policy_command.SetBandwidthRequest_OutboundTag{OutboundTag: chi.URLParam(r, "tag")},
+	})
+}
+
+// followLog streams log messages as server-sent events until the client goes away.
+// Messages are dropped rather than blocking the logger if the client falls behind.
+func (rs *restfulService) followLog(w http.ResponseWriter, r *http.Request) {
+	var follower cmlog.Follower
+	if rs.instance != nil {
+		follower, _ = rs.instance.GetFeature((*log.Instance)(nil)).(cmlog.Follower)
+	}
+	if follower == nil {
+		renderError(w, r, http.StatusNotImplemented, newError("logger not support following"))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		renderError(w, r, http.StatusInternalServerError, newError("streaming not supported"))
+		return
+	}
+
+	messages := make(chan string, 64)
+	f := func(msg cmlog.Message) {
+		select {
+		case messages <- msg.String():
+		default:
+		}
+	}
+	follower.AddFollower(f)
+	defer follower.RemoveFollower(f)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case msg := <-messages:
+			for _, line := range strings.Split(msg, "\n") {
+				if _, err := io.WriteString(w, "data: "+line+"\n"); err != nil {
+					return
+				}
+			}
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// reloadConfig applies the config in the request body, given in the format named by the "format" query
+// parameter (default "json"), to the running instance. See core.Instance.Reload for what can be reloaded.
+func (rs *restfulService) reloadConfig(w http.ResponseWriter, r *http.Request) {
+	if rs.instance == nil {
+		renderError(w, r, http.StatusNotImplemented, newError("reloading is not available"))
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxConfigSize))
+	if err != nil {
+		renderError(w, r, http.StatusBadRequest, newError("failed to read request body").Base(err))
+		return
+	}
+	config, err := core.LoadConfig(format, bytes.NewReader(data))
+	if err != nil {
+		renderError(w, r, http.StatusBadRequest, newError("invalid config").Base(err))
+		return
+	}
+	if err := rs.instance.Reload(config); err != nil {
+		renderError(w, r, http.StatusConflict, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (rs *restfulService) managementRoutes(r chi.Router) {
+	r.Post("/inbounds", rs.addInbound)
+	r.Delete("/inbounds/{tag}", rs.removeInbound)
+	r.Post("/inbounds/{tag}/operations", rs.alterInbound)
+	r.Post("/outbounds", rs.addOutbound)
+	r.Delete("/outbounds/{tag}", rs.removeOutbound)
+	r.Post("/outbounds/{tag}/operations", rs.alterOutbound)
+
+	r.Post("/routing/test", rs.testRoute)
+	r.Get("/balancers/{tag}", rs.balancerInfo)
+	r.Put("/balancers/{tag}/override", rs.overrideBalancer)
+
+	r.Get("/observatory", rs.outboundStatus)
+
+	r.Get("/reverse/portals", rs.portalStatus)
+
+	r.Get("/stats", rs.queryStats)
+	r.Post("/stats/query", rs.queryStats)
+	r.Get("/sys/stats", rs.sysStats)
+
+	r.Get("/policy/bandwidth", rs.getBandwidth)
+	r.Put("/policy/levels/{level}/bandwidth", rs.setLevelBandwidth)
+	r.Put("/inbounds/{tag}/bandwidth", rs.setInboundBandwidth)
+	r.Put("/outbounds/{tag}/bandwidth", rs.setOutboundBandwidth)
+
+	r.Get("/logs", rs.followLog)
+
+	r.Put("/config", rs.reloadConfig)
+}
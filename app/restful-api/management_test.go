@@ -0,0 +1,116 @@
+package restful_api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/v2fly/v2ray-core/v4/app/policy"
+	policy_command "github.com/v2fly/v2ray-core/v4/app/policy/command"
+	"github.com/v2fly/v2ray-core/v4/app/stats"
+	stats_command "github.com/v2fly/v2ray-core/v4/app/stats/command"
+	"github.com/v2fly/v2ray-core/v4/common"
+)
+
+func newTestService(t *testing.T) *restfulService {
+	manager, err := stats.NewManager(context.Background(), &stats.Config{})
+	common.Must(err)
+	counter, err := manager.RegisterCounter("user>>>test>>>traffic>>>uplink")
+	common.Must(err)
+	counter.Set(42)
+
+	rs := &restfulService{ctx: context.Background()}
+	rs.init(&Config{AuthToken: "token"}, manager)
+	return rs
+}
+
+func TestManagementRequiresToken(t *testing.T) {
+	rs := newTestService(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/stats", nil)
+	rec := httptest.NewRecorder()
+	rs.handler().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestManagementQueryStats(t *testing.T) {
+	rs := newTestService(t)
+	handler := rs.handler()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/stats?pattern=user>>>&reset=true", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	resp := new(stats_command.QueryStatsResponse)
+	common.Must(protojson.Unmarshal(rec.Body.Bytes(), resp))
+	assert.Len(t, resp.Stat, 1)
+	assert.Equal(t, int64(42), resp.Stat[0].Value)
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/stats/query", strings.NewReader(`{"patterns": ["uplink"]}`))
+	req.Header.Set("Authorization", "Bearer token")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	resp = new(stats_command.QueryStatsResponse)
+	common.Must(protojson.Unmarshal(rec.Body.Bytes(), resp))
+	assert.Len(t, resp.Stat, 1)
+	assert.Equal(t, int64(0), resp.Stat[0].Value)
+}
+
+func TestManagementRejectsInvalidBody(t *testing.T) {
+	rs := newTestService(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/stats/query", strings.NewReader(`{"unknown": 1}`))
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	rs.handler().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestManagementBandwidth(t *testing.T) {
+	rs := newTestService(t)
+	manager, err := policy.New(context.Background(), &policy.Config{})
+	common.Must(err)
+	rs.policyServer = policy_command.NewPolicyServer(manager)
+	handler := rs.handler()
+
+	for _, path := range []string{"/v1/policy/levels/1/bandwidth", "/v1/inbounds/in/bandwidth", "/v1/outbounds/out/bandwidth"} {
+		req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(`{"uplink": "1024", "downlink": "2048"}`))
+		req.Header.Set("Authorization", "Bearer token")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/policy/bandwidth", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	resp := new(policy_command.GetBandwidthResponse)
+	common.Must(protojson.Unmarshal(rec.Body.Bytes(), resp))
+	assert.Equal(t, uint64(2048), resp.Level[1].GetDownlink())
+	assert.Equal(t, uint64(1024), resp.Inbound["in"].GetUplink())
+	assert.Equal(t, uint64(2048), resp.Outbound["out"].GetDownlink())
+	assert.Equal(t, int64(1024), manager.ForLevel(1).Bandwidth.Uplink)
+}
+
+func TestManagementPortalStatusWithoutReverse(t *testing.T) {
+	rs := newTestService(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/reverse/portals", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	rs.handler().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "reverse is not configured")
+}
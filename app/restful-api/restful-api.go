@@ -67,16 +67,21 @@ func (rs *restfulService) TokenAuthMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-func (rs *restfulService) start() error {
+func (rs *restfulService) handler() http.Handler {
 	r := chi.NewRouter()
 	r.Use(middleware.Heartbeat("/ping"))
 
 	validate = validator.New()
 	r.Route("/v1", func(r chi.Router) {
+		r.Use(rs.TokenAuthMiddleware)
 		r.Get("/{bound_type}/{tag}/stats", rs.tagStats)
+		rs.managementRoutes(r)
 	})
 	r.Get("/version", rs.version)
+	return r
+}
 
+func (rs *restfulService) start() error {
 	var listener net.Listener
 	var err error
 	address := net.ParseAddress(rs.config.ListenAddr)
@@ -93,8 +98,9 @@ func (rs *restfulService) start() error {
 		return newError("restful api cannot listen on the port ", rs.config.ListenPort).Base(err)
 	}
 
+	rs.listener = listener
 	go func() {
-		err := http.Serve(listener, r)
+		err := http.Serve(listener, rs.handler())
 		if err != nil {
 			newError("unable to serve restful api").WriteToLog()
 		}
@@ -2,11 +2,21 @@ package restful_api
 
 import (
 	"context"
+	"net"
+	"sync"
+
 	core "github.com/v2fly/v2ray-core/v4"
+	policy_command "github.com/v2fly/v2ray-core/v4/app/policy/command"
+	proxyman_command "github.com/v2fly/v2ray-core/v4/app/proxyman/command"
+	reverse_command "github.com/v2fly/v2ray-core/v4/app/reverse/command"
+	router_command "github.com/v2fly/v2ray-core/v4/app/router/command"
+	stats_command "github.com/v2fly/v2ray-core/v4/app/stats/command"
 	"github.com/v2fly/v2ray-core/v4/features"
+	"github.com/v2fly/v2ray-core/v4/features/inbound"
+	"github.com/v2fly/v2ray-core/v4/features/outbound"
+	"github.com/v2fly/v2ray-core/v4/features/policy"
+	"github.com/v2fly/v2ray-core/v4/features/routing"
 	feature_stats "github.com/v2fly/v2ray-core/v4/features/stats"
-	"net"
-	"sync"
 )
 
 //go:generate go run github.com/v2fly/v2ray-core/v4/common/errors/errorgen
@@ -18,6 +28,13 @@ type restfulService struct {
 
 	stats feature_stats.Manager
 
+	instance      *core.Instance
+	handlerServer proxyman_command.HandlerServiceServer
+	routingServer router_command.RoutingServiceServer
+	statsServer   stats_command.StatsServiceServer
+	policyServer  policy_command.PolicyServiceServer
+	reverseServer reverse_command.ReverseServiceServer
+
 	ctx context.Context
 }
 
@@ -43,13 +60,19 @@ func (rs *restfulService) Close() error {
 func (rs *restfulService) init(config *Config, stats feature_stats.Manager) {
 	rs.stats = stats
 	rs.config = config
+	rs.statsServer = stats_command.NewStatsServer(stats)
+	rs.reverseServer = reverse_command.NewReverseServer(rs.instance)
 }
 
 func newRestfulService(ctx context.Context, config *Config) (features.Feature, error) {
 	r := new(restfulService)
 	r.ctx = ctx
-	if err := core.RequireFeatures(ctx, func(stats feature_stats.Manager) {
+	r.instance = core.FromContext(ctx)
+	if err := core.RequireFeatures(ctx, func(stats feature_stats.Manager, im inbound.Manager, om outbound.Manager, router routing.Router, pm policy.Manager) {
 		r.init(config, stats)
+		r.handlerServer = proxyman_command.NewHandlerServer(r.instance, im, om)
+		r.routingServer = router_command.NewRoutingServer(router, nil)
+		r.policyServer = policy_command.NewPolicyServer(pm)
 	}); err != nil {
 		return nil, err
 	}
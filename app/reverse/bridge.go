@@ -6,10 +6,13 @@ import (
 
 	"google.golang.org/protobuf/proto"
 
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/buf"
 	"github.com/v2fly/v2ray-core/v4/common/mux"
 	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/common/session"
 	"github.com/v2fly/v2ray-core/v4/common/task"
+	"github.com/v2fly/v2ray-core/v4/common/uuid"
 	"github.com/v2fly/v2ray-core/v4/features/routing"
 	"github.com/v2fly/v2ray-core/v4/transport"
 	"github.com/v2fly/v2ray-core/v4/transport/pipe"
@@ -20,6 +23,7 @@ type Bridge struct {
 	dispatcher  routing.Dispatcher
 	tag         string
 	domain      string
+	id          string
 	workers     []*BridgeWorker
 	monitorTask *task.Periodic
 }
@@ -33,10 +37,17 @@ func NewBridge(config *BridgeConfig, dispatcher routing.Dispatcher) (*Bridge, er
 		return nil, newError("bridge domain is empty")
 	}
 
+	id := config.Id
+	if id == "" {
+		u := uuid.New()
+		id = u.String()
+	}
+
 	b := &Bridge{
 		dispatcher: dispatcher,
 		tag:        config.Tag,
 		domain:     config.Domain,
+		id:         id,
 	}
 	b.monitorTask = &task.Periodic{
 		Execute:  b.monitor,
@@ -73,7 +84,7 @@ func (b *Bridge) monitor() error {
 	}
 
 	if numWorker == 0 || numConnections/numWorker > 16 {
-		worker, err := NewBridgeWorker(b.domain, b.tag, b.dispatcher)
+		worker, err := NewBridgeWorker(b.domain, b.tag, b.id, b.dispatcher)
 		if err != nil {
 			newError("failed to create bridge worker").Base(err).AtWarning().WriteToLog()
 			return nil
@@ -94,12 +105,13 @@ func (b *Bridge) Close() error {
 
 type BridgeWorker struct {
 	tag        string
+	id         string
 	worker     *mux.ServerWorker
 	dispatcher routing.Dispatcher
 	state      Control_State
 }
 
-func NewBridgeWorker(domain string, tag string, d routing.Dispatcher) (*BridgeWorker, error) {
+func NewBridgeWorker(domain string, tag string, id string, d routing.Dispatcher) (*BridgeWorker, error) {
 	ctx := context.Background()
 	ctx = session.ContextWithInbound(ctx, &session.Inbound{
 		Tag: tag,
@@ -116,6 +128,7 @@ func NewBridgeWorker(domain string, tag string, d routing.Dispatcher) (*BridgeWo
 	w := &BridgeWorker{
 		dispatcher: d,
 		tag:        tag,
+		id:         id,
 	}
 
 	worker, err := mux.NewServerWorker(context.Background(), w, link)
@@ -164,11 +177,28 @@ func (w *BridgeWorker) handleInternalConn(link transport.Link) {
 				if ctl.State != w.state {
 					w.state = ctl.State
 				}
+				if ctl.ReplyRequested {
+					w.reply(link.Writer)
+				}
 			}
 		}
 	}()
 }
 
+// reply replies to a heartbeat of the portal with the ID of the bridge.
+func (w *BridgeWorker) reply(writer buf.Writer) {
+	msg := &Control{
+		Bridge: w.id,
+	}
+	msg.FillInRandom()
+
+	b, err := proto.Marshal(msg)
+	common.Must(err)
+	if err := writer.WriteMultiBuffer(buf.MergeBytes(nil, b)); err != nil {
+		newError("failed to reply to heartbeat").Base(err).WriteToLog()
+	}
+}
+
 func (w *BridgeWorker) Dispatch(ctx context.Context, dest net.Destination) (*transport.Link, error) {
 	if !isInternalDomain(dest) {
 		ctx = session.ContextWithInbound(ctx, &session.Inbound{
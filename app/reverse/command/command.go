@@ -0,0 +1,64 @@
+package command
+
+//go:generate go run github.com/v2fly/v2ray-core/v4/common/errors/errorgen
+
+import (
+	"context"
+
+	grpc "google.golang.org/grpc"
+
+	core "github.com/v2fly/v2ray-core/v4"
+	"github.com/v2fly/v2ray-core/v4/app/reverse"
+	"github.com/v2fly/v2ray-core/v4/common"
+)
+
+// reverseServer is an implementation of ReverseService.
+type reverseServer struct {
+	instance *core.Instance
+}
+
+// NewReverseServer creates a ReverseService server reporting the portals of the given instance.
+func NewReverseServer(instance *core.Instance) ReverseServiceServer {
+	return &reverseServer{
+		instance: instance,
+	}
+}
+
+func (s *reverseServer) GetPortalStatus(ctx context.Context, request *GetPortalStatusRequest) (*GetPortalStatusResponse, error) {
+	var r *reverse.Reverse
+	if s.instance != nil {
+		r, _ = s.instance.GetFeature((*reverse.Reverse)(nil)).(*reverse.Reverse)
+	}
+	if r == nil {
+		return nil, newError("reverse is not configured")
+	}
+
+	response := new(GetPortalStatusResponse)
+	for _, portal := range r.PortalStatus() {
+		if request.Tag == "" || request.Tag == portal.Tag {
+			response.Portals = append(response.Portals, portal)
+		}
+	}
+	if request.Tag != "" && len(response.Portals) == 0 {
+		return nil, newError("portal not found: ", request.Tag)
+	}
+	return response, nil
+}
+
+func (s *reverseServer) mustEmbedUnimplementedReverseServiceServer() {}
+
+type service struct {
+	instance *core.Instance
+}
+
+func (s *service) Register(server *grpc.Server) {
+	RegisterReverseServiceServer(server, NewReverseServer(s.instance))
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, cfg interface{}) (interface{}, error) {
+		return &service{
+			instance: core.MustFromContext(ctx),
+		}, nil
+	}))
+}
@@ -0,0 +1,101 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package command
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ReverseServiceClient is the client API for ReverseService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReverseServiceClient interface {
+	GetPortalStatus(ctx context.Context, in *GetPortalStatusRequest, opts ...grpc.CallOption) (*GetPortalStatusResponse, error)
+}
+
+type reverseServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReverseServiceClient(cc grpc.ClientConnInterface) ReverseServiceClient {
+	return &reverseServiceClient{cc}
+}
+
+func (c *reverseServiceClient) GetPortalStatus(ctx context.Context, in *GetPortalStatusRequest, opts ...grpc.CallOption) (*GetPortalStatusResponse, error) {
+	out := new(GetPortalStatusResponse)
+	err := c.cc.Invoke(ctx, "/v2ray.core.app.reverse.command.ReverseService/GetPortalStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReverseServiceServer is the server API for ReverseService service.
+// All implementations must embed UnimplementedReverseServiceServer
+// for forward compatibility
+type ReverseServiceServer interface {
+	GetPortalStatus(context.Context, *GetPortalStatusRequest) (*GetPortalStatusResponse, error)
+	mustEmbedUnimplementedReverseServiceServer()
+}
+
+// UnimplementedReverseServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedReverseServiceServer struct {
+}
+
+func (UnimplementedReverseServiceServer) GetPortalStatus(context.Context, *GetPortalStatusRequest) (*GetPortalStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortalStatus not implemented")
+}
+func (UnimplementedReverseServiceServer) mustEmbedUnimplementedReverseServiceServer() {}
+
+// UnsafeReverseServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReverseServiceServer will
+// result in compilation errors.
+type UnsafeReverseServiceServer interface {
+	mustEmbedUnimplementedReverseServiceServer()
+}
+
+func RegisterReverseServiceServer(s grpc.ServiceRegistrar, srv ReverseServiceServer) {
+	s.RegisterService(&ReverseService_ServiceDesc, srv)
+}
+
+func _ReverseService_GetPortalStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortalStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReverseServiceServer).GetPortalStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v2ray.core.app.reverse.command.ReverseService/GetPortalStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReverseServiceServer).GetPortalStatus(ctx, req.(*GetPortalStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReverseService_ServiceDesc is the grpc.ServiceDesc for ReverseService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReverseService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v2ray.core.app.reverse.command.ReverseService",
+	HandlerType: (*ReverseServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPortalStatus",
+			Handler:    _ReverseService_GetPortalStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "app/reverse/command/command.proto",
+}
@@ -2,7 +2,9 @@ package reverse
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/protobuf/proto"
@@ -69,12 +71,7 @@ func (p *Portal) HandleConnection(ctx context.Context, link *transport.Link) err
 	}
 
 	if isDomain(outboundMeta.Target, p.domain) {
-		muxClient, err := mux.NewClientWorker(*link, mux.ClientStrategy{})
-		if err != nil {
-			return newError("failed to create mux client worker").Base(err).AtWarning()
-		}
-
-		worker, err := NewPortalWorker(muxClient)
+		worker, err := NewPortalWorker(link)
 		if err != nil {
 			return newError("failed to create portal worker").Base(err)
 		}
@@ -86,6 +83,15 @@ func (p *Portal) HandleConnection(ctx context.Context, link *transport.Link) err
 	return p.client.Dispatch(ctx, link)
 }
 
+// Status returns the status of the portal and the bridges connected to it.
+func (p *Portal) Status() *PortalStatus {
+	return &PortalStatus{
+		Tag:     p.tag,
+		Domain:  p.domain,
+		Bridges: p.picker.Status(),
+	}
+}
+
 type Outbound struct {
 	portal *Portal
 	tag    string
@@ -114,10 +120,14 @@ type StaticMuxPicker struct {
 	access  sync.Mutex
 	workers []*PortalWorker
 	cTask   *task.Periodic
+	// retired is the traffic of the closed workers of the bridges still connected.
+	retired map[string]*BridgeStatus
 }
 
 func NewStaticMuxPicker() (*StaticMuxPicker, error) {
-	p := &StaticMuxPicker{}
+	p := &StaticMuxPicker{
+		retired: make(map[string]*BridgeStatus),
+	}
 	p.cTask = &task.Periodic{
 		Execute:  p.cleanup,
 		Interval: time.Second * 30,
@@ -131,9 +141,25 @@ func (p *StaticMuxPicker) cleanup() error {
 	defer p.access.Unlock()
 
 	var activeWorkers []*PortalWorker
+	bridges := make(map[string]bool)
 	for _, w := range p.workers {
+		bridge := w.Bridge()
 		if !w.Closed() {
 			activeWorkers = append(activeWorkers, w)
+			bridges[bridge] = true
+			continue
+		}
+		retired := p.retired[bridge]
+		if retired == nil {
+			retired = &BridgeStatus{Id: bridge}
+			p.retired[bridge] = retired
+		}
+		retired.Uplink += atomic.LoadInt64(&w.uplink)
+		retired.Downlink += atomic.LoadInt64(&w.downlink)
+	}
+	for bridge := range p.retired {
+		if !bridges[bridge] {
+			delete(p.retired, bridge)
 		}
 	}
 
@@ -144,6 +170,9 @@ func (p *StaticMuxPicker) cleanup() error {
 	return nil
 }
 
+// PickAvailable picks the worker of the bridge with the fewest active connections, and the fewest among the workers of
+// the bridge. Workers of bridges that have never replied to heartbeats, like those of older versions, have no bridge ID,
+// so they are balanced as the workers of one bridge.
 func (p *StaticMuxPicker) PickAvailable() (*mux.ClientWorker, error) {
 	p.access.Lock()
 	defer p.access.Unlock()
@@ -152,15 +181,23 @@ func (p *StaticMuxPicker) PickAvailable() (*mux.ClientWorker, error) {
 		return nil, newError("empty worker list")
 	}
 
+	loads := make(map[string]uint32)
+	for _, w := range p.workers {
+		loads[w.Bridge()] += w.Connections()
+	}
+
 	var minIdx = -1
-	var minConn uint32 = 9999
+	var minLoad, minConn uint32
 	for i, w := range p.workers {
-		if w.draining {
+		if w.isDraining() || w.Closed() {
 			continue
 		}
-		if w.client.ActiveConnections() < minConn {
-			minConn = w.client.ActiveConnections()
+		load := loads[w.Bridge()]
+		conn := w.Connections()
+		if minIdx == -1 || load < minLoad || (load == minLoad && conn < minConn) {
 			minIdx = i
+			minLoad = load
+			minConn = conn
 		}
 	}
 
@@ -169,9 +206,10 @@ func (p *StaticMuxPicker) PickAvailable() (*mux.ClientWorker, error) {
 			if w.IsFull() {
 				continue
 			}
-			if w.client.ActiveConnections() < minConn {
-				minConn = w.client.ActiveConnections()
+			conn := w.Connections()
+			if minIdx == -1 || conn < minConn {
 				minIdx = i
+				minConn = conn
 			}
 		}
 	}
@@ -190,15 +228,84 @@ func (p *StaticMuxPicker) AddWorker(worker *PortalWorker) {
 	p.workers = append(p.workers, worker)
 }
 
+// Status returns the status of the bridges of the workers, ordered by ID.
+func (p *StaticMuxPicker) Status() []*BridgeStatus {
+	p.access.Lock()
+	defer p.access.Unlock()
+
+	bridges := make(map[string]*BridgeStatus)
+	var ids []string
+	for _, w := range p.workers {
+		id, lastSeen := w.status()
+		s := bridges[id]
+		if s == nil {
+			s = &BridgeStatus{Id: id}
+			if retired := p.retired[id]; retired != nil {
+				s.Uplink = retired.Uplink
+				s.Downlink = retired.Downlink
+			}
+			bridges[id] = s
+			ids = append(ids, id)
+		}
+		s.Uplink += atomic.LoadInt64(&w.uplink)
+		s.Downlink += atomic.LoadInt64(&w.downlink)
+		if w.Closed() {
+			continue
+		}
+		s.Workers++
+		s.ActiveConnections += w.Connections()
+		if !lastSeen.IsZero() && lastSeen.Unix() > s.LastSeen {
+			s.LastSeen = lastSeen.Unix()
+		}
+	}
+
+	sort.Strings(ids)
+	status := make([]*BridgeStatus, 0, len(ids))
+	for _, id := range ids {
+		status = append(status, bridges[id])
+	}
+	return status
+}
+
+// heartbeatTimeout is how long a heartbeat may be left unanswered by the bridge before its worker is closed. Bridges
+// never replying are of older versions, whose workers are only closed with their connections.
+const heartbeatTimeout = time.Second * 10
+
 type PortalWorker struct {
-	client   *mux.ClientWorker
-	control  *task.Periodic
-	writer   buf.Writer
-	reader   buf.Reader
-	draining bool
+	// uplink and downlink are the bytes sent to and received from the bridge.
+	uplink   int64
+	downlink int64
+
+	client  *mux.ClientWorker
+	control *task.Periodic
+	writer  buf.Writer
+	reader  buf.Reader
+	link    *transport.Link
+
+	access   sync.Mutex
+	bridge   string
+	lastSeen time.Time
+	// unanswered is when the first heartbeat not yet replied to is sent.
+	unanswered time.Time
+	draining   bool
+	// disposed is set once the control connection is closed.
+	disposed bool
 }
 
-func NewPortalWorker(client *mux.ClientWorker) (*PortalWorker, error) {
+// NewPortalWorker creates a PortalWorker of the mux connection from a bridge.
+func NewPortalWorker(link *transport.Link) (*PortalWorker, error) {
+	w := &PortalWorker{
+		link: link,
+	}
+	client, err := mux.NewClientWorker(transport.Link{
+		Reader: &countingReader{Reader: link.Reader, counter: &w.downlink},
+		Writer: &countingWriter{Writer: link.Writer, counter: &w.uplink},
+	}, mux.ClientStrategy{})
+	if err != nil {
+		return nil, newError("failed to create mux client worker").Base(err).AtWarning()
+	}
+	w.client = client
+
 	opt := []pipe.Option{pipe.WithSizeLimit(16 * 1024)}
 	uplinkReader, uplinkWriter := pipe.New(opt...)
 	downlinkReader, downlinkWriter := pipe.New(opt...)
@@ -214,11 +321,9 @@ func NewPortalWorker(client *mux.ClientWorker) (*PortalWorker, error) {
 	if !f {
 		return nil, newError("unable to dispatch control connection")
 	}
-	w := &PortalWorker{
-		client: client,
-		reader: downlinkReader,
-		writer: uplinkWriter,
-	}
+	w.reader = downlinkReader
+	w.writer = uplinkWriter
+	go w.receive(downlinkReader)
 	w.control = &task.Periodic{
 		Execute:  w.heartbeat,
 		Interval: time.Second * 2,
@@ -227,33 +332,119 @@ func NewPortalWorker(client *mux.ClientWorker) (*PortalWorker, error) {
 	return w, nil
 }
 
+// heartbeat checks whether the bridge still replies to heartbeats, once it has replied, and sends another one. Draining
+// workers keep their control connections for heartbeats, until the other connections are closed.
 func (w *PortalWorker) heartbeat() error {
 	if w.client.Closed() {
 		return newError("client worker stopped")
 	}
 
-	if w.draining || w.writer == nil {
+	w.access.Lock()
+	bridge, lastSeen, unanswered, disposed := w.bridge, w.lastSeen, w.unanswered, w.disposed
+	w.access.Unlock()
+	if disposed {
 		return newError("already disposed")
 	}
+	if !lastSeen.IsZero() && !unanswered.IsZero() && time.Since(unanswered) > heartbeatTimeout {
+		common.Interrupt(w.link.Reader)
+		common.Interrupt(w.link.Writer)
+		return newError("bridge ", bridge, " not replying to heartbeats since ", unanswered.Format(time.RFC3339)).AtWarning()
+	}
 
-	msg := &Control{}
+	msg := &Control{
+		ReplyRequested: true,
+	}
 	msg.FillInRandom()
 
-	if w.client.TotalConnections() > 256 {
+	if w.isDraining() || w.client.TotalConnections() > 256 {
+		w.access.Lock()
 		w.draining = true
+		w.access.Unlock()
 		msg.State = Control_DRAIN
 
-		defer func() {
-			common.Close(w.writer)
-			common.Interrupt(w.reader)
-			w.writer = nil
-		}()
+		if w.client.ActiveConnections() <= 1 {
+			// Only the control connection is left, which is closed so that the worker closes.
+			msg.ReplyRequested = false
+			defer func() {
+				common.Close(w.writer)
+				common.Interrupt(w.reader)
+				w.access.Lock()
+				w.disposed = true
+				w.access.Unlock()
+			}()
+		}
 	}
 
 	b, err := proto.Marshal(msg)
 	common.Must(err)
 	mb := buf.MergeBytes(nil, b)
-	return w.writer.WriteMultiBuffer(mb)
+	if err := w.writer.WriteMultiBuffer(mb); err != nil {
+		return err
+	}
+	if msg.ReplyRequested {
+		w.access.Lock()
+		if w.unanswered.IsZero() {
+			w.unanswered = time.Now()
+		}
+		w.access.Unlock()
+	}
+	return nil
+}
+
+// receive reads the replies of the bridge to the heartbeats.
+func (w *PortalWorker) receive(reader buf.Reader) {
+	for {
+		mb, err := reader.ReadMultiBuffer()
+		if err != nil {
+			return
+		}
+		for _, b := range mb {
+			var ctl Control
+			if err := proto.Unmarshal(b.Bytes(), &ctl); err != nil {
+				newError("failed to parse proto message").Base(err).WriteToLog()
+				continue
+			}
+			if ctl.Bridge != "" {
+				w.access.Lock()
+				w.bridge = ctl.Bridge
+				w.lastSeen = time.Now()
+				w.unanswered = time.Time{}
+				w.access.Unlock()
+			}
+		}
+		buf.ReleaseMulti(mb)
+	}
+}
+
+func (w *PortalWorker) status() (string, time.Time) {
+	w.access.Lock()
+	defer w.access.Unlock()
+	return w.bridge, w.lastSeen
+}
+
+// Bridge returns the ID of the bridge of the worker, or empty if the bridge has not replied to heartbeats.
+func (w *PortalWorker) Bridge() string {
+	bridge, _ := w.status()
+	return bridge
+}
+
+func (w *PortalWorker) isDraining() bool {
+	w.access.Lock()
+	defer w.access.Unlock()
+	return w.draining
+}
+
+// Connections returns the number of the active connections through the worker, not counting its control connection.
+func (w *PortalWorker) Connections() uint32 {
+	w.access.Lock()
+	disposed := w.disposed
+	w.access.Unlock()
+
+	n := w.client.ActiveConnections()
+	if n > 0 && !disposed {
+		n--
+	}
+	return n
 }
 
 func (w *PortalWorker) IsFull() bool {
@@ -263,3 +454,38 @@ func (w *PortalWorker) IsFull() bool {
 func (w *PortalWorker) Closed() bool {
 	return w.client.Closed()
 }
+
+// countingReader counts the bytes read from a link.
+type countingReader struct {
+	buf.Reader
+	counter *int64
+}
+
+func (r *countingReader) ReadMultiBuffer() (buf.MultiBuffer, error) {
+	mb, err := r.Reader.ReadMultiBuffer()
+	atomic.AddInt64(r.counter, int64(mb.Len()))
+	return mb, err
+}
+
+func (r *countingReader) Interrupt() {
+	common.Interrupt(r.Reader)
+}
+
+// countingWriter counts the bytes written to a link.
+type countingWriter struct {
+	buf.Writer
+	counter *int64
+}
+
+func (w *countingWriter) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	atomic.AddInt64(w.counter, int64(mb.Len()))
+	return w.Writer.WriteMultiBuffer(mb)
+}
+
+func (w *countingWriter) Close() error {
+	return common.Close(w.Writer)
+}
+
+func (w *countingWriter) Interrupt() {
+	common.Interrupt(w.Writer)
+}
@@ -1,10 +1,21 @@
 package reverse_test
 
 import (
+	"context"
 	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
 
 	"github.com/v2fly/v2ray-core/v4/app/reverse"
 	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/mux"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/session"
+	"github.com/v2fly/v2ray-core/v4/features/routing"
+	"github.com/v2fly/v2ray-core/v4/transport"
+	"github.com/v2fly/v2ray-core/v4/transport/pipe"
 )
 
 func TestStaticPickerEmpty(t *testing.T) {
@@ -18,3 +29,175 @@ func TestStaticPickerEmpty(t *testing.T) {
 		t.Error("expected nil worker, but not nil")
 	}
 }
+
+// bridgeDispatcher dispatches the first request of a bridge worker to the portal, and the others to pipes.
+type bridgeDispatcher struct {
+	portal *transport.Link
+}
+
+func (d *bridgeDispatcher) Type() interface{} {
+	return routing.DispatcherType()
+}
+
+func (d *bridgeDispatcher) Start() error {
+	return nil
+}
+
+func (d *bridgeDispatcher) Close() error {
+	return nil
+}
+
+func (d *bridgeDispatcher) Dispatch(ctx context.Context, dest net.Destination) (*transport.Link, error) {
+	if link := d.portal; link != nil {
+		d.portal = nil
+		return link, nil
+	}
+	reader, writer := pipe.New()
+	return &transport.Link{Reader: reader, Writer: writer}, nil
+}
+
+func connectBridge(t *testing.T, id string) *reverse.PortalWorker {
+	uplinkReader, uplinkWriter := pipe.New()
+	downlinkReader, downlinkWriter := pipe.New()
+	_, err := reverse.NewBridgeWorker("test.v2fly.org", "bridge", id, &bridgeDispatcher{
+		portal: &transport.Link{Reader: downlinkReader, Writer: uplinkWriter},
+	})
+	common.Must(err)
+	worker, err := reverse.NewPortalWorker(&transport.Link{Reader: uplinkReader, Writer: downlinkWriter})
+	common.Must(err)
+
+	for i := 0; worker.Bridge() == ""; i++ {
+		if i == 50 {
+			t.Fatal("bridge ", id, " not replying to heartbeats")
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+	if worker.Bridge() != id {
+		t.Fatal("expected bridge ", id, ", but got ", worker.Bridge())
+	}
+	return worker
+}
+
+func TestStaticPickerBalanceBridges(t *testing.T) {
+	picker, err := reverse.NewStaticMuxPicker()
+	common.Must(err)
+	picker.AddWorker(connectBridge(t, "a"))
+	picker.AddWorker(connectBridge(t, "a"))
+	picker.AddWorker(connectBridge(t, "b"))
+
+	for i := 0; i < 4; i++ {
+		client, err := picker.PickAvailable()
+		common.Must(err)
+		reader, writer := pipe.New()
+		ctx := session.ContextWithOutbound(context.Background(), &session.Outbound{
+			Target: net.TCPDestination(net.LocalHostIP, 80),
+		})
+		if !client.Dispatch(ctx, &transport.Link{Reader: reader, Writer: writer}) {
+			t.Fatal("failed to dispatch")
+		}
+	}
+
+	status := picker.Status()
+	if len(status) != 2 {
+		t.Fatal("expected 2 bridges, but got ", len(status))
+	}
+	for i, expected := range []struct {
+		id      string
+		workers uint32
+	}{{"a", 2}, {"b", 1}} {
+		s := status[i]
+		if s.Id != expected.id || s.Workers != expected.workers {
+			t.Error("unexpected bridge ", s.Id, " of ", s.Workers, " workers")
+		}
+		if s.ActiveConnections != 2 {
+			t.Error("expected 2 connections through bridge ", s.Id, ", but got ", s.ActiveConnections)
+		}
+		if s.Uplink == 0 || s.Downlink == 0 || s.LastSeen == 0 {
+			t.Error("unexpected status of bridge ", s.Id, ": ", s)
+		}
+	}
+}
+
+// controlDispatcher dispatches the connections of a mux server, which are only the control connection of the portal, to
+// a bridge replying to the first heartbeats.
+type controlDispatcher struct {
+	id      string
+	replies int
+}
+
+func (d *controlDispatcher) Type() interface{} {
+	return routing.DispatcherType()
+}
+
+func (d *controlDispatcher) Start() error {
+	return nil
+}
+
+func (d *controlDispatcher) Close() error {
+	return nil
+}
+
+func (d *controlDispatcher) Dispatch(ctx context.Context, dest net.Destination) (*transport.Link, error) {
+	heartbeatReader, heartbeatWriter := pipe.New()
+	replyReader, replyWriter := pipe.New()
+	go func() {
+		for replies := d.replies; ; {
+			mb, err := heartbeatReader.ReadMultiBuffer()
+			if err != nil {
+				return
+			}
+			buf.ReleaseMulti(mb)
+			if replies > 0 {
+				replies--
+				b, err := proto.Marshal(&reverse.Control{Bridge: d.id})
+				common.Must(err)
+				common.Must(replyWriter.WriteMultiBuffer(buf.MergeBytes(nil, b)))
+			}
+		}
+	}()
+	return &transport.Link{Reader: replyReader, Writer: heartbeatWriter}, nil
+}
+
+// connectMux connects a portal worker to a mux server dispatching with the dispatcher.
+func connectMux(d routing.Dispatcher) *reverse.PortalWorker {
+	uplinkReader, uplinkWriter := pipe.New()
+	downlinkReader, downlinkWriter := pipe.New()
+	_, err := mux.NewServerWorker(context.Background(), d, &transport.Link{Reader: downlinkReader, Writer: uplinkWriter})
+	common.Must(err)
+	worker, err := reverse.NewPortalWorker(&transport.Link{Reader: uplinkReader, Writer: downlinkWriter})
+	common.Must(err)
+	return worker
+}
+
+func TestPortalWorkerHeartbeatTimeout(t *testing.T) {
+	// The bridge stops replying to heartbeats after the first one.
+	stopped := connectMux(&controlDispatcher{id: "a", replies: 1})
+	// The bridge is of an older version, never replying to heartbeats.
+	silent := connectMux(&controlDispatcher{id: "b"})
+
+	for i := 0; !stopped.Closed(); i++ {
+		if i == 150 {
+			t.Fatal("expected worker of bridge no longer replying to heartbeats to be closed")
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+	if stopped.Bridge() != "a" {
+		t.Error("expected bridge a, but got ", stopped.Bridge())
+	}
+	if silent.Closed() {
+		t.Fatal("worker of bridge never replying to heartbeats is closed with its mux connection open")
+	}
+	if silent.Bridge() != "" {
+		t.Error("unexpected bridge ", silent.Bridge())
+	}
+
+	picker, err := reverse.NewStaticMuxPicker()
+	common.Must(err)
+	picker.AddWorker(stopped)
+	picker.AddWorker(silent)
+	client, err := picker.PickAvailable()
+	common.Must(err)
+	if client.Closed() {
+		t.Error("picked closed worker")
+	}
+}
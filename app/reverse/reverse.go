@@ -62,6 +62,15 @@ func (r *Reverse) Init(config *Config, d routing.Dispatcher, ohm outbound.Manage
 	return nil
 }
 
+// PortalStatus returns the status of the portals and the bridges connected to them.
+func (r *Reverse) PortalStatus() []*PortalStatus {
+	status := make([]*PortalStatus, 0, len(r.portals))
+	for _, p := range r.portals {
+		status = append(status, p.Status())
+	}
+	return status
+}
+
 func (r *Reverse) Type() interface{} {
 	return (*Reverse)(nil)
 }
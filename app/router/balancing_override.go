@@ -5,14 +5,8 @@ import (
 )
 
 func (r *Router) OverrideBalancer(balancer string, target string) error {
-	var b *Balancer
-	for tag, bl := range r.balancers {
-		if tag == balancer {
-			b = bl
-			break
-		}
-	}
-	if b == nil {
+	b, ok := r.getBalancer(balancer)
+	if !ok {
 		return newError("balancer '", balancer, "' not found")
 	}
 	b.override.Put(target)
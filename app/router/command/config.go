@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/process"
 	"github.com/v2fly/v2ray-core/v4/features/routing"
 )
 
@@ -35,6 +36,11 @@ func (c routingContext) GetSkipDNSResolve() bool {
 	return false
 }
 
+// GetSourceProcess implements routing.Context. Processes are not carried by RoutingContext.
+func (c routingContext) GetSourceProcess() *process.Info {
+	return nil
+}
+
 // AsRoutingContext converts a protobuf RoutingContext into an implementation of routing.Context.
 func AsRoutingContext(r *RoutingContext) routing.Context {
 	return routingContext{r}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"github.com/v2fly/v2ray-core/v4/app/router/routercommon"
+	"os"
 	"strings"
 
 	"go.starlark.net/starlark"
@@ -252,6 +253,76 @@ func (v *InboundTagMatcher) Apply(ctx routing.Context) bool {
 	return false
 }
 
+type ProcessMatcher struct {
+	names []string
+	paths []string
+	self  bool
+}
+
+// NewProcessMatcher creates a matcher of the executables of local processes. Entries containing a slash
+// are matched against the path of the executable, others against its name. "self" matches V2Ray itself.
+func NewProcessMatcher(processes []string) *ProcessMatcher {
+	m := new(ProcessMatcher)
+	for _, p := range processes {
+		switch {
+		case len(p) == 0:
+		case p == "self":
+			m.self = true
+		case strings.Contains(p, "/"):
+			m.paths = append(m.paths, p)
+		default:
+			m.names = append(m.names, p)
+		}
+	}
+	return m
+}
+
+// Apply implements Condition.
+func (m *ProcessMatcher) Apply(ctx routing.Context) bool {
+	info := ctx.GetSourceProcess()
+	if info == nil || info.PID == 0 {
+		return false
+	}
+	if m.self && info.PID == os.Getpid() {
+		return true
+	}
+	for _, path := range m.paths {
+		if info.Path == path {
+			return true
+		}
+	}
+	for _, name := range m.names {
+		if info.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+type UIDMatcher struct {
+	uids []uint32
+}
+
+func NewUIDMatcher(uids []uint32) *UIDMatcher {
+	return &UIDMatcher{
+		uids: append([]uint32(nil), uids...),
+	}
+}
+
+// Apply implements Condition.
+func (m *UIDMatcher) Apply(ctx routing.Context) bool {
+	info := ctx.GetSourceProcess()
+	if info == nil {
+		return false
+	}
+	for _, uid := range m.uids {
+		if info.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
 type ProtocolMatcher struct {
 	protocols []string
 }
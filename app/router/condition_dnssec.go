@@ -0,0 +1,47 @@
+package router
+
+import (
+	"strings"
+
+	"github.com/v2fly/v2ray-core/v4/features/dns"
+	"github.com/v2fly/v2ray-core/v4/features/routing"
+)
+
+// dnssecContext is a routing context able to tell the DNSSEC status of the answer of its target domain.
+type dnssecContext interface {
+	GetTargetDNSSEC() dns.DNSSECStatus
+}
+
+// DNSSECMatcher matches the DNSSEC validation status of the answer the target domain is resolved with.
+type DNSSECMatcher struct {
+	statuses [4]bool
+}
+
+// NewDNSSECMatcher creates a DNSSECMatcher matching any of the given statuses.
+func NewDNSSECMatcher(statuses []string) (*DNSSECMatcher, error) {
+	m := &DNSSECMatcher{}
+	for _, s := range statuses {
+		switch strings.ToLower(s) {
+		case dns.DNSSECSecure.String():
+			m.statuses[dns.DNSSECSecure] = true
+		case dns.DNSSECInsecure.String():
+			m.statuses[dns.DNSSECInsecure] = true
+		case dns.DNSSECBogus.String():
+			m.statuses[dns.DNSSECBogus] = true
+		case dns.DNSSECIndeterminate.String():
+			m.statuses[dns.DNSSECIndeterminate] = true
+		default:
+			return nil, newError("unknown DNSSEC status ", s)
+		}
+	}
+	return m, nil
+}
+
+// Apply implements Condition.
+func (m *DNSSECMatcher) Apply(ctx routing.Context) bool {
+	c, ok := ctx.(dnssecContext)
+	if !ok || len(ctx.GetTargetDomain()) == 0 {
+		return false
+	}
+	return m.statuses[c.GetTargetDNSSEC()]
+}
@@ -0,0 +1,50 @@
+package router_test
+
+import (
+	"testing"
+
+	"github.com/v2fly/v2ray-core/v4/app/router"
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/features/dns"
+	"github.com/v2fly/v2ray-core/v4/features/routing"
+)
+
+type dnssecContext struct {
+	routing.Context
+	domain string
+	status dns.DNSSECStatus
+}
+
+func (c *dnssecContext) GetTargetDomain() string {
+	return c.domain
+}
+
+func (c *dnssecContext) GetTargetDNSSEC() dns.DNSSECStatus {
+	return c.status
+}
+
+func TestDNSSECMatcher(t *testing.T) {
+	matcher, err := router.NewDNSSECMatcher([]string{"secure", "Insecure"})
+	common.Must(err)
+
+	cases := []struct {
+		ctx   routing.Context
+		match bool
+	}{
+		{&dnssecContext{Context: withBackground(), domain: "v2fly.org", status: dns.DNSSECSecure}, true},
+		{&dnssecContext{Context: withBackground(), domain: "v2fly.org", status: dns.DNSSECInsecure}, true},
+		{&dnssecContext{Context: withBackground(), domain: "v2fly.org", status: dns.DNSSECBogus}, false},
+		{&dnssecContext{Context: withBackground(), domain: "v2fly.org", status: dns.DNSSECIndeterminate}, false},
+		{&dnssecContext{Context: withBackground(), status: dns.DNSSECSecure}, false},
+		{withBackground(), false},
+	}
+	for i, c := range cases {
+		if r := matcher.Apply(c.ctx); r != c.match {
+			t.Error("case ", i, ": expected ", c.match, ", but got ", r)
+		}
+	}
+
+	if _, err := router.NewDNSSECMatcher([]string{"valid"}); err == nil {
+		t.Error("expected unknown DNSSEC status to be rejected")
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"github.com/v2fly/v2ray-core/v4/app/router/routercommon"
 	"io/fs"
+	gonet "net"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"testing"
@@ -352,6 +354,43 @@ func TestRoutingRule(t *testing.T) {
 	}
 }
 
+func TestProcessCondition(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("finding processes is supported on Linux only")
+	}
+
+	listener, err := gonet.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+	conn, err := gonet.Dial("tcp", listener.Addr().String())
+	common.Must(err)
+	defer conn.Close()
+	source := net.DestinationFromAddr(conn.LocalAddr())
+
+	exe, err := os.Executable()
+	common.Must(err)
+
+	cases := []struct {
+		rule   *router.RoutingRule
+		output bool
+	}{
+		{rule: &router.RoutingRule{Process: []string{"self"}}, output: true},
+		{rule: &router.RoutingRule{Process: []string{filepath.Base(exe)}}, output: true},
+		{rule: &router.RoutingRule{Process: []string{exe}}, output: true},
+		{rule: &router.RoutingRule{Process: []string{"/usr/bin/" + filepath.Base(exe)}}, output: false},
+		{rule: &router.RoutingRule{Uid: []uint32{uint32(os.Getuid())}}, output: true},
+		{rule: &router.RoutingRule{Uid: []uint32{uint32(os.Getuid()) + 1}}, output: false},
+	}
+	for _, test := range cases {
+		cond, err := test.rule.BuildCondition()
+		common.Must(err)
+		actual := cond.Apply(withInbound(&session.Inbound{Source: source}))
+		if actual != test.output {
+			t.Error("test case failed: ", test.rule, " expected ", test.output, " but got ", actual)
+		}
+	}
+}
+
 func loadGeoSite(country string) ([]*routercommon.Domain, error) {
 	geositeBytes, err := filesystem.ReadAsset("geosite.dat")
 	if err != nil {
@@ -0,0 +1,191 @@
+package router
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v4/features/routing"
+)
+
+const secondsPerDay = 24 * 60 * 60
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+type timeRange struct {
+	// Seconds since midnight.
+	start, end int
+}
+
+// TimeMatcher matches the current time against ranges of time of day and days of week.
+type TimeMatcher struct {
+	ranges   []timeRange
+	weekdays [7]bool
+	location *time.Location
+}
+
+// NewTimeMatcher creates a TimeMatcher from the given condition.
+func NewTimeMatcher(cond *TimeCondition) (*TimeMatcher, error) {
+	m := &TimeMatcher{
+		location: time.Local,
+	}
+
+	for _, r := range cond.TimeRange {
+		parsed, err := parseTimeRange(r)
+		if err != nil {
+			return nil, newError("invalid time range ", r).Base(err)
+		}
+		m.ranges = append(m.ranges, parsed)
+	}
+	if len(m.ranges) == 0 {
+		m.ranges = []timeRange{{0, secondsPerDay}}
+	}
+
+	for _, d := range cond.Weekday {
+		if err := m.addWeekdays(d); err != nil {
+			return nil, err
+		}
+	}
+	if len(cond.Weekday) == 0 {
+		for i := range m.weekdays {
+			m.weekdays[i] = true
+		}
+	}
+
+	if cond.TimeZone != "" {
+		location, err := parseTimeZone(cond.TimeZone)
+		if err != nil {
+			return nil, newError("invalid time zone ", cond.TimeZone).Base(err)
+		}
+		m.location = location
+	}
+	return m, nil
+}
+
+func (m *TimeMatcher) addWeekdays(s string) error {
+	from, to := s, s
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		from, to = s[:i], s[i+1:]
+	}
+	first, found := weekdayNames[strings.ToLower(strings.TrimSpace(from))]
+	if !found {
+		return newError("invalid weekday ", s)
+	}
+	last, found := weekdayNames[strings.ToLower(strings.TrimSpace(to))]
+	if !found {
+		return newError("invalid weekday ", s)
+	}
+	for d := first; ; d = (d + 1) % 7 {
+		m.weekdays[d] = true
+		if d == last {
+			return nil
+		}
+	}
+}
+
+func parseTimeRange(s string) (timeRange, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return timeRange{}, newError("expecting start-end")
+	}
+	start, err := parseTimeOfDay(parts[0])
+	if err != nil {
+		return timeRange{}, err
+	}
+	end, err := parseTimeOfDay(parts[1])
+	if err != nil {
+		return timeRange{}, err
+	}
+	if start == end {
+		return timeRange{}, newError("empty range")
+	}
+	return timeRange{start, end}, nil
+}
+
+// parseTimeOfDay parses "hh:mm" or "hh:mm:ss" into seconds since midnight. "24:00" is accepted as the end of day.
+func parseTimeOfDay(s string) (int, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, newError("invalid time of day ", s)
+	}
+	limits := []int{24, 59, 59}
+	seconds := 0
+	for i, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil || v < 0 || v > limits[i] {
+			return 0, newError("invalid time of day ", s)
+		}
+		seconds = seconds*60 + v
+	}
+	if len(parts) == 2 {
+		seconds *= 60
+	}
+	if seconds > secondsPerDay {
+		return 0, newError("invalid time of day ", s)
+	}
+	return seconds, nil
+}
+
+// parseTimeZone accepts IANA time zone names, and fixed offsets like "+08:00", "-0530" or "UTC+8".
+func parseTimeZone(s string) (*time.Location, error) {
+	offset := strings.TrimPrefix(strings.TrimPrefix(s, "UTC"), "GMT")
+	if offset == "" || (offset[0] != '+' && offset[0] != '-') {
+		return time.LoadLocation(s)
+	}
+	sign := 1
+	if offset[0] == '-' {
+		sign = -1
+	}
+	hours, minutes := offset[1:], "0"
+	if i := strings.IndexByte(hours, ':'); i >= 0 {
+		hours, minutes = hours[:i], hours[i+1:]
+	} else if len(hours) == 4 {
+		hours, minutes = hours[:2], hours[2:]
+	}
+	h, err := strconv.Atoi(hours)
+	if err != nil || h > 14 {
+		return nil, newError("invalid offset ", s)
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m > 59 {
+		return nil, newError("invalid offset ", s)
+	}
+	return time.FixedZone(s, sign*(h*3600+m*60)), nil
+}
+
+// Match reports whether the given time is in the ranges on the days of the matcher.
+func (m *TimeMatcher) Match(t time.Time) bool {
+	t = t.In(m.location)
+	weekday := t.Weekday()
+	yesterday := (weekday + 6) % 7
+	seconds := t.Hour()*3600 + t.Minute()*60 + t.Second()
+	for _, r := range m.ranges {
+		if r.start < r.end {
+			if m.weekdays[weekday] && seconds >= r.start && seconds < r.end {
+				return true
+			}
+			continue
+		}
+		// The range wraps over midnight.
+		if m.weekdays[weekday] && seconds >= r.start {
+			return true
+		}
+		if m.weekdays[yesterday] && seconds < r.end {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply implements Condition.
+func (m *TimeMatcher) Apply(ctx routing.Context) bool {
+	return m.Match(time.Now())
+}
@@ -0,0 +1,90 @@
+package router_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v4/app/router"
+)
+
+func TestTimeMatcher(t *testing.T) {
+	zone := time.FixedZone("test", 8*3600)
+	// 2021-06-04 is a Friday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2021, 6, day, hour, minute, 0, 0, zone)
+	}
+
+	cases := []struct {
+		cond  *router.TimeCondition
+		time  time.Time
+		match bool
+	}{
+		{
+			cond:  &router.TimeCondition{TimeRange: []string{"01:00-07:00"}, TimeZone: "+08:00"},
+			time:  at(4, 1, 0),
+			match: true,
+		},
+		{
+			cond:  &router.TimeCondition{TimeRange: []string{"01:00-07:00"}, TimeZone: "+08:00"},
+			time:  at(4, 7, 0),
+			match: false,
+		},
+		{
+			// 01:00 at UTC+8 is 17:00 of the previous day at UTC.
+			cond:  &router.TimeCondition{TimeRange: []string{"17:00-18:00"}, TimeZone: "UTC"},
+			time:  at(4, 1, 30),
+			match: true,
+		},
+		{
+			cond:  &router.TimeCondition{TimeRange: []string{"09:00-18:00"}, Weekday: []string{"mon-fri"}, TimeZone: "+08:00"},
+			time:  at(4, 10, 0),
+			match: true,
+		},
+		{
+			cond:  &router.TimeCondition{TimeRange: []string{"09:00-18:00"}, Weekday: []string{"mon-fri"}, TimeZone: "+08:00"},
+			time:  at(5, 10, 0),
+			match: false,
+		},
+		{
+			cond:  &router.TimeCondition{Weekday: []string{"Saturday", "sun"}, TimeZone: "+08:00"},
+			time:  at(6, 23, 59), // Sunday
+			match: true,
+		},
+		{
+			// The range starts on Friday and ends on Saturday.
+			cond:  &router.TimeCondition{TimeRange: []string{"22:00-02:00"}, Weekday: []string{"fri"}, TimeZone: "+08:00"},
+			time:  at(5, 1, 0),
+			match: true,
+		},
+		{
+			cond:  &router.TimeCondition{TimeRange: []string{"22:00-02:00"}, Weekday: []string{"fri"}, TimeZone: "+08:00"},
+			time:  at(4, 1, 0),
+			match: false,
+		},
+	}
+
+	for i, c := range cases {
+		matcher, err := router.NewTimeMatcher(c.cond)
+		if err != nil {
+			t.Fatal("case ", i, ": ", err)
+		}
+		if matcher.Match(c.time) != c.match {
+			t.Error("case ", i, ": expected ", c.match, " for ", c.time)
+		}
+	}
+}
+
+func TestTimeMatcherInvalid(t *testing.T) {
+	for _, cond := range []*router.TimeCondition{
+		{TimeRange: []string{"01:00"}},
+		{TimeRange: []string{"25:00-26:00"}},
+		{TimeRange: []string{"01:00-01:00"}},
+		{Weekday: []string{"someday"}},
+		{TimeZone: "+25:00"},
+		{TimeZone: "Nowhere/Nothing"},
+	} {
+		if _, err := router.NewTimeMatcher(cond); err == nil {
+			t.Error("expected error for ", cond)
+		}
+	}
+}
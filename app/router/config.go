@@ -120,6 +120,30 @@ func (rr *RoutingRule) BuildCondition() (Condition, error) {
 		conds.Add(cond)
 	}
 
+	if len(rr.Process) > 0 {
+		conds.Add(NewProcessMatcher(rr.Process))
+	}
+
+	if len(rr.Uid) > 0 {
+		conds.Add(NewUIDMatcher(rr.Uid))
+	}
+
+	if len(rr.Dnssec) > 0 {
+		cond, err := NewDNSSECMatcher(rr.Dnssec)
+		if err != nil {
+			return nil, err
+		}
+		conds.Add(cond)
+	}
+
+	if rr.Time != nil {
+		cond, err := NewTimeMatcher(rr.Time)
+		if err != nil {
+			return nil, err
+		}
+		conds.Add(cond)
+	}
+
 	if conds.Len() == 0 {
 		return nil, newError("this rule has no effective fields").AtWarning()
 	}
@@ -4,20 +4,25 @@ package router
 
 import (
 	"context"
+	"sync"
+
 	core "github.com/v2fly/v2ray-core/v4"
 	"github.com/v2fly/v2ray-core/v4/common"
 	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/common/platform"
+	"github.com/v2fly/v2ray-core/v4/features"
 	"github.com/v2fly/v2ray-core/v4/features/dns"
 	"github.com/v2fly/v2ray-core/v4/features/outbound"
 	"github.com/v2fly/v2ray-core/v4/features/routing"
 	routing_dns "github.com/v2fly/v2ray-core/v4/features/routing/dns"
+	"github.com/v2fly/v2ray-core/v4/features/tracing"
 	"github.com/v2fly/v2ray-core/v4/infra/conf/cfgcommon"
 	"github.com/v2fly/v2ray-core/v4/infra/conf/geodata"
 )
 
 // Router is an implementation of routing.Router.
 type Router struct {
+	access         sync.RWMutex
 	domainStrategy DomainStrategy
 	rules          []*Rule
 	balancers      map[string]*Balancer
@@ -72,6 +77,24 @@ func (r *Router) Init(ctx context.Context, config *Config, d dns.Client, ohm out
 
 // PickRoute implements routing.Router.
 func (r *Router) PickRoute(ctx routing.Context) (routing.Route, error) {
+	if parent := tracing.SpanOf(ctx); parent != nil {
+		span := parent.StartChild("route")
+		defer span.End()
+		route, err := r.pickRoute(&tracedContext{Context: ctx, span: span})
+		switch {
+		case err == common.ErrNoClue:
+			span.SetAttributes(tracing.Bool("route.default", true))
+		case err != nil:
+			span.RecordError(err)
+		default:
+			span.SetAttributes(tracing.String("outbound.tag", route.GetOutboundTag()))
+		}
+		return route, err
+	}
+	return r.pickRoute(ctx)
+}
+
+func (r *Router) pickRoute(ctx routing.Context) (routing.Route, error) {
 	rule, ctx, err := r.pickRouteInternal(ctx)
 	if err != nil {
 		return nil, err
@@ -83,30 +106,46 @@ func (r *Router) PickRoute(ctx routing.Context) (routing.Route, error) {
 	return &Route{Context: ctx, outboundTag: tag}, nil
 }
 
+// tracedContext is a routing context carrying the span of picking its route, so that DNS lookups for the route are
+// traced under it.
+type tracedContext struct {
+	routing.Context
+	span tracing.Span
+}
+
+// GetSpan implements tracing.SpanCarrier.
+func (ctx *tracedContext) GetSpan() tracing.Span {
+	return ctx.span
+}
+
 func (r *Router) pickRouteInternal(ctx routing.Context) (*Rule, routing.Context, error) {
 	// SkipDNSResolve is set from DNS module.
 	// the DOH remote server maybe a domain name,
 	// this prevents cycle resolving dead loop
 	skipDNSResolve := ctx.GetSkipDNSResolve()
 
-	if r.domainStrategy == DomainStrategy_IpOnDemand && !skipDNSResolve {
-		ctx = routing_dns.ContextWithDNSClient(ctx, r.dns)
+	r.access.RLock()
+	domainStrategy, rules, d := r.domainStrategy, r.rules, r.dns
+	r.access.RUnlock()
+
+	if domainStrategy == DomainStrategy_IpOnDemand && !skipDNSResolve {
+		ctx = routing_dns.ContextWithDNSClient(ctx, d)
 	}
 
-	for _, rule := range r.rules {
+	for _, rule := range rules {
 		if rule.Apply(ctx) {
 			return rule, ctx, nil
 		}
 	}
 
-	if r.domainStrategy != DomainStrategy_IpIfNonMatch || len(ctx.GetTargetDomain()) == 0 || skipDNSResolve {
+	if domainStrategy != DomainStrategy_IpIfNonMatch || len(ctx.GetTargetDomain()) == 0 || skipDNSResolve {
 		return nil, ctx, common.ErrNoClue
 	}
 
-	ctx = routing_dns.ContextWithDNSClient(ctx, r.dns)
+	ctx = routing_dns.ContextWithDNSClient(ctx, d)
 
 	// Try applying rules again if we have IPs.
-	for _, rule := range r.rules {
+	for _, rule := range rules {
 		if rule.Apply(ctx) {
 			return rule, ctx, nil
 		}
@@ -125,6 +164,40 @@ func (r *Router) Close() error {
 	return nil
 }
 
+// ReplaceWith implements features.Reloadable. Rules and balancers of the new router take effect at once,
+// routes being picked at the moment finish with the old ones. Override targets of balancers kept by the
+// new config are preserved.
+func (r *Router) ReplaceWith(feature features.Feature) error {
+	next, ok := feature.(*Router)
+	if !ok {
+		return newError("cannot replace router with ", feature.Type())
+	}
+
+	r.access.Lock()
+	defer r.access.Unlock()
+
+	for tag, balancer := range next.balancers {
+		if old, found := r.balancers[tag]; found {
+			if target := old.override.Get(); target != "" {
+				balancer.override.Put(target)
+			}
+		}
+	}
+	r.domainStrategy = next.domainStrategy
+	r.rules = next.rules
+	r.balancers = next.balancers
+	r.dns = next.dns
+	return nil
+}
+
+func (r *Router) getBalancer(tag string) (*Balancer, bool) {
+	r.access.RLock()
+	defer r.access.RUnlock()
+
+	b, ok := r.balancers[tag]
+	return b, ok
+}
+
 // Type implement common.HasType.
 func (*Router) Type() interface{} {
 	return routing.RouterType()
@@ -174,6 +247,13 @@ func init() {
 		for _, v := range simplifiedConfig.Rule {
 
 			rule := new(RoutingRule)
+			switch target := v.TargetTag.(type) {
+			case *SimplifiedRoutingRule_Tag:
+				rule.TargetTag = &RoutingRule_Tag{Tag: target.Tag}
+			case *SimplifiedRoutingRule_BalancingTag:
+				rule.TargetTag = &RoutingRule_BalancingTag{BalancingTag: target.BalancingTag}
+			}
+			rule.Domain = v.Domain
 
 			for _, geo := range v.Geoip {
 				if geo.Code != "" {
@@ -239,13 +319,18 @@ func init() {
 				}
 				rule.SourcePortList = portList.Build()
 			}
-			rule.Domain = v.Domain
 			rule.Networks = net.ParseNetworks(v.Networks)
 			rule.Protocol = v.Protocol
 			rule.Attributes = v.Attributes
 			rule.UserEmail = v.UserEmail
 			rule.InboundTag = v.InboundTag
 			rule.DomainMatcher = v.DomainMatcher
+			rule.Time = v.Time
+			rule.Process = v.Process
+			rule.Uid = v.Uid
+			rule.Dnssec = v.Dnssec
+
+			routingRules = append(routingRules, rule)
 		}
 
 		fullConfig := &Config{
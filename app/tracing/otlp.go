@@ -0,0 +1,210 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v4/features/tracing"
+)
+
+// The OTLP JSON encoding of ExportTraceServiceRequest. IDs are in hex, and 64-bit integers are in strings.
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              spanKind        `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+// Status codes, as numbered by OTLP.
+const (
+	statusCodeUnset = 0
+	statusCodeError = 2
+)
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+}
+
+func toOTLPAttributes(attributes []tracing.Attribute) []otlpAttribute {
+	result := make([]otlpAttribute, 0, len(attributes))
+	for _, a := range attributes {
+		var value otlpValue
+		switch v := a.Value.(type) {
+		case string:
+			value.StringValue = &v
+		case int64:
+			s := strconv.FormatInt(v, 10)
+			value.IntValue = &s
+		case bool:
+			value.BoolValue = &v
+		default:
+			continue
+		}
+		result = append(result, otlpAttribute{Key: a.Key, Value: value})
+	}
+	return result
+}
+
+func toOTLPSpan(s *span) otlpSpan {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	result := otlpSpan{
+		TraceID:           hex.EncodeToString(s.traceID[:]),
+		SpanID:            hex.EncodeToString(s.id[:]),
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		Attributes:        toOTLPAttributes(s.attributes),
+		Status:            otlpStatus{Code: statusCodeUnset},
+	}
+	if s.parentID != (spanID{}) {
+		result.ParentSpanID = hex.EncodeToString(s.parentID[:])
+	}
+	if s.err != nil {
+		result.Status = otlpStatus{Code: statusCodeError, Message: s.err.Error()}
+	}
+	return result
+}
+
+// encodeSpans encodes the spans into an OTLP request in JSON.
+func encodeSpans(resource []tracing.Attribute, scope otlpScope, spans []*span) ([]byte, error) {
+	otlpSpans := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		otlpSpans = append(otlpSpans, toOTLPSpan(s))
+	}
+	return json.Marshal(&otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource:   otlpResource{Attributes: toOTLPAttributes(resource)},
+			ScopeSpans: []otlpScopeSpans{{Scope: scope, Spans: otlpSpans}},
+		}},
+	})
+}
+
+// exporter sends encoded spans to where they are collected.
+type exporter interface {
+	export(request []byte) error
+	Close() error
+}
+
+const defaultOTLPEndpoint = "http://127.0.0.1:4318/v1/traces"
+
+type otlpExporter struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+}
+
+func newOTLPExporter(config *OTLPExporter) *otlpExporter {
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = defaultOTLPEndpoint
+	}
+	return &otlpExporter{
+		endpoint: endpoint,
+		headers:  config.Headers,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (e *otlpExporter) export(request []byte) error {
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(request))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return newError("failed to export spans to ", e.endpoint).Base(err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newError("failed to export spans to ", e.endpoint, ": ", resp.Status)
+	}
+	return nil
+}
+
+func (e *otlpExporter) Close() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+type fileExporter struct {
+	access sync.Mutex
+	file   *os.File
+}
+
+func newFileExporter(config *FileExporter) (*fileExporter, error) {
+	file, err := os.OpenFile(config.Path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, newError("failed to open trace file ", config.Path).Base(err)
+	}
+	return &fileExporter{file: file}, nil
+}
+
+func (e *fileExporter) export(request []byte) error {
+	e.access.Lock()
+	defer e.access.Unlock()
+
+	_, err := e.file.Write(append(request, '\n'))
+	return err
+}
+
+func (e *fileExporter) Close() error {
+	e.access.Lock()
+	defer e.access.Unlock()
+
+	return e.file.Close()
+}
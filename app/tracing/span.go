@@ -0,0 +1,110 @@
+package tracing
+
+import (
+	"sync"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v4/common/dice"
+	"github.com/v2fly/v2ray-core/v4/features/tracing"
+)
+
+type spanKind int
+
+// Kinds of spans, as numbered by OTLP.
+const (
+	spanKindInternal spanKind = 1
+	spanKindServer   spanKind = 2
+)
+
+type traceID [16]byte
+
+type spanID [8]byte
+
+func newTraceID() traceID {
+	var id traceID
+	putUint64(id[:8], dice.RollUint64())
+	putUint64(id[8:], dice.RollUint64())
+	return id
+}
+
+func newSpanID() spanID {
+	var id spanID
+	for id == (spanID{}) {
+		putUint64(id[:], dice.RollUint64())
+	}
+	return id
+}
+
+func putUint64(b []byte, v uint64) {
+	for i := range b {
+		b[i] = byte(v >> (8 * (len(b) - 1 - i)))
+	}
+}
+
+// span is a tracing.Span, which is queued for export by its tracer once it ends.
+type span struct {
+	tracer   *Tracer
+	traceID  traceID
+	id       spanID
+	parentID spanID
+	name     string
+	kind     spanKind
+	start    time.Time
+
+	access     sync.Mutex
+	end        time.Time
+	attributes []tracing.Attribute
+	err        error
+	ended      bool
+}
+
+// SetAttributes implements tracing.Span.
+func (s *span) SetAttributes(attributes ...tracing.Attribute) {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	if !s.ended {
+		s.attributes = append(s.attributes, attributes...)
+	}
+}
+
+// RecordError implements tracing.Span.
+func (s *span) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	if !s.ended {
+		s.err = err
+	}
+}
+
+// End implements tracing.Span.
+func (s *span) End() {
+	s.access.Lock()
+	if s.ended {
+		s.access.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.access.Unlock()
+
+	s.tracer.enqueue(s)
+}
+
+// StartChild implements tracing.Span.
+func (s *span) StartChild(name string, attributes ...tracing.Attribute) tracing.Span {
+	return &span{
+		tracer:     s.tracer,
+		traceID:    s.traceID,
+		id:         newSpanID(),
+		parentID:   s.id,
+		name:       name,
+		kind:       spanKindInternal,
+		start:      time.Now(),
+		attributes: attributes,
+	}
+}
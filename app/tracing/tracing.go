@@ -0,0 +1,161 @@
+// Package tracing traces the lifecycle of sessions with OpenTelemetry spans, which are exported with OTLP.
+package tracing
+
+//go:generate go run github.com/v2fly/v2ray-core/v4/common/errors/errorgen
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	core "github.com/v2fly/v2ray-core/v4"
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/session"
+	"github.com/v2fly/v2ray-core/v4/common/task"
+	"github.com/v2fly/v2ray-core/v4/features/tracing"
+)
+
+const (
+	defaultServiceName = "v2ray"
+
+	// exportInterval is how often ended spans are exported.
+	exportInterval = 5 * time.Second
+	// maxQueuedSpans is how many ended spans are kept for export. Spans beyond are dropped.
+	maxQueuedSpans = 2048
+)
+
+// Tracer is an implementation of tracing.Tracer.
+type Tracer struct {
+	ratio     float64
+	resource  []tracing.Attribute
+	exporters []exporter
+	task      *task.Periodic
+
+	access  sync.Mutex
+	queue   []*span
+	dropped int
+}
+
+// New creates a new Tracer with the config.
+func New(ctx context.Context, config *Config) (*Tracer, error) {
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	t := &Tracer{
+		ratio: config.SamplingRatio,
+		resource: []tracing.Attribute{
+			tracing.String("service.name", serviceName),
+			tracing.String("service.version", core.Version()),
+		},
+	}
+	if config.Otlp != nil {
+		t.exporters = append(t.exporters, newOTLPExporter(config.Otlp))
+	}
+	if config.File != nil {
+		e, err := newFileExporter(config.File)
+		if err != nil {
+			return nil, err
+		}
+		t.exporters = append(t.exporters, e)
+	}
+	if len(t.exporters) == 0 {
+		return nil, newError("no exporter of spans")
+	}
+	t.task = &task.Periodic{
+		Interval: exportInterval,
+		Execute: func() error {
+			t.export()
+			return nil
+		},
+	}
+	return t, nil
+}
+
+// Type implements common.HasType.
+func (*Tracer) Type() interface{} {
+	return tracing.TracerType()
+}
+
+// Start implements common.Runnable.
+func (t *Tracer) Start() error {
+	return t.task.Start()
+}
+
+// Close implements common.Closable. Spans ended are exported before it returns.
+func (t *Tracer) Close() error {
+	t.task.Close()
+	t.export()
+	var errs []error
+	for _, e := range t.exporters {
+		if err := e.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return newError("failed to close exporters").Base(errs[0])
+	}
+	return nil
+}
+
+// StartSession implements tracing.Tracer. The trace of the session is labelled with its ID.
+func (t *Tracer) StartSession(ctx context.Context, name string, attributes ...tracing.Attribute) tracing.Span {
+	if t.ratio > 0 && t.ratio < 1 && rand.Float64() >= t.ratio {
+		return nil
+	}
+	if id := session.IDFromContext(ctx); id != 0 {
+		attributes = append([]tracing.Attribute{tracing.Int("session.id", int64(id))}, attributes...)
+	}
+	return &span{
+		tracer:     t,
+		traceID:    newTraceID(),
+		id:         newSpanID(),
+		name:       name,
+		kind:       spanKindServer,
+		start:      time.Now(),
+		attributes: attributes,
+	}
+}
+
+func (t *Tracer) enqueue(s *span) {
+	t.access.Lock()
+	defer t.access.Unlock()
+
+	if len(t.queue) >= maxQueuedSpans {
+		t.dropped++
+		return
+	}
+	t.queue = append(t.queue, s)
+}
+
+// export exports the spans ended since last export. Spans failing to export are dropped.
+func (t *Tracer) export() {
+	t.access.Lock()
+	spans, dropped := t.queue, t.dropped
+	t.queue, t.dropped = nil, 0
+	t.access.Unlock()
+
+	if dropped > 0 {
+		newError("dropped ", dropped, " spans over the limit of the export queue").AtWarning().WriteToLog()
+	}
+	if len(spans) == 0 {
+		return
+	}
+	request, err := encodeSpans(t.resource, otlpScope{Name: defaultServiceName, Version: core.Version()}, spans)
+	if err != nil {
+		newError("failed to encode ", len(spans), " spans").Base(err).AtWarning().WriteToLog()
+		return
+	}
+	for _, e := range t.exporters {
+		if err := e.export(request); err != nil {
+			newError("failed to export ", len(spans), " spans").Base(err).AtWarning().WriteToLog()
+		}
+	}
+}
+
+func init() {
+	common.Must(common.RegisterConfig((*Config)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return New(ctx, config.(*Config))
+	}))
+}
@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/session"
+	"github.com/v2fly/v2ray-core/v4/features/tracing"
+)
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.json")
+	tracer, err := New(context.Background(), &Config{File: &FileExporter{Path: path}})
+	common.Must(err)
+
+	ctx := session.ContextWithID(context.Background(), 42)
+	root := tracer.StartSession(ctx, "session", tracing.String("inbound.tag", "socks"))
+	if root == nil {
+		t.Fatal("expected session to be traced")
+	}
+	_, child := tracing.Start(tracing.ContextWithSpan(ctx, root), "dial")
+	child.RecordError(errors.New("refused"))
+	child.End()
+	root.End()
+	root.End()
+	common.Must(tracer.Close())
+
+	file, err := os.Open(path)
+	common.Must(err)
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		t.Fatal("expected a request in the file")
+	}
+	var request otlpRequest
+	common.Must(json.Unmarshal(scanner.Bytes(), &request))
+	if scanner.Scan() {
+		t.Error("unexpected line ", scanner.Text())
+	}
+
+	resource := request.ResourceSpans[0].Resource.Attributes
+	if resource[0].Key != "service.name" || *resource[0].Value.StringValue != defaultServiceName {
+		t.Error("unexpected resource ", resource)
+	}
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatal("expected 2 spans, but got ", len(spans))
+	}
+	dial, sess := spans[0], spans[1]
+	if dial.Name != "dial" || sess.Name != "session" {
+		t.Error("unexpected spans ", dial.Name, " ", sess.Name)
+	}
+	if dial.TraceID != sess.TraceID || dial.ParentSpanID != sess.SpanID || sess.ParentSpanID != "" {
+		t.Error("unexpected span hierarchy ", dial, " ", sess)
+	}
+	if dial.Status.Code != statusCodeError || dial.Status.Message != "refused" {
+		t.Error("unexpected status ", dial.Status)
+	}
+	if sess.Kind != spanKindServer || sess.Attributes[0].Key != "session.id" || *sess.Attributes[0].Value.IntValue != "42" {
+		t.Error("unexpected session span ", sess)
+	}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	requests := make(chan *http.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		requests <- r
+	}))
+	defer server.Close()
+
+	tracer, err := New(context.Background(), &Config{Otlp: &OTLPExporter{
+		Endpoint: server.URL + "/v1/traces",
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+	}})
+	common.Must(err)
+	tracer.StartSession(context.Background(), "session").End()
+	common.Must(tracer.Close())
+
+	r := <-requests
+	if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/json" {
+		t.Error("unexpected request ", r.URL, " ", r.Header)
+	}
+	if r.Header.Get("Authorization") != "Bearer token" {
+		t.Error("expected header of the config, but got ", r.Header)
+	}
+}
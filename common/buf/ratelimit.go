@@ -0,0 +1,191 @@
+package buf
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/signal/done"
+)
+
+// RateLimiter is a token bucket limiting the rate of bytes. It may be shared by many connections.
+type RateLimiter struct {
+	access sync.Mutex
+	rate   int64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a RateLimiter allowing the given bytes per second. 0 for unlimited.
+func NewRateLimiter(rate int64) *RateLimiter {
+	l := &RateLimiter{
+		last: time.Now(),
+	}
+	l.SetRate(rate)
+	return l
+}
+
+// Rate returns the bytes per second allowed, or 0 if unlimited.
+func (l *RateLimiter) Rate() int64 {
+	l.access.Lock()
+	defer l.access.Unlock()
+
+	return l.rate
+}
+
+// SetRate changes the bytes per second allowed. 0 for unlimited. It takes effect on the connections at once.
+func (l *RateLimiter) SetRate(rate int64) {
+	l.access.Lock()
+	defer l.access.Unlock()
+
+	if rate < 0 {
+		rate = 0
+	}
+	l.rate = rate
+	if burst := l.burst(); l.tokens > burst {
+		l.tokens = burst
+	}
+}
+
+// burst is the bytes allowed at once after being idle, which is what is allowed in a second. l.access must be held.
+func (l *RateLimiter) burst() float64 {
+	if l.rate < Size {
+		return Size
+	}
+	return float64(l.rate)
+}
+
+// reserve takes n bytes from the bucket, and returns how long to wait before sending them.
+func (l *RateLimiter) reserve(n int64) time.Duration {
+	l.access.Lock()
+	defer l.access.Unlock()
+
+	if l.rate == 0 {
+		return 0
+	}
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * float64(l.rate)
+	if burst := l.burst(); l.tokens > burst {
+		l.tokens = burst
+	}
+	l.last = now
+	// Going into debt allows sending more bytes than the burst at once.
+	l.tokens -= float64(n)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / float64(l.rate) * float64(time.Second))
+}
+
+// WaitRateLimiters blocks until n bytes are allowed by all the limiters. It returns io.ErrClosedPipe if cancel is closed
+// while waiting.
+func WaitRateLimiters(limiters []*RateLimiter, n int64, cancel <-chan struct{}) error {
+	var wait time.Duration
+	for _, l := range limiters {
+		if d := l.reserve(n); d > wait {
+			wait = d
+		}
+	}
+	if wait == 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-cancel:
+		return io.ErrClosedPipe
+	}
+}
+
+// RateLimitedWriter is a Writer whose throughput is limited by RateLimiters.
+type RateLimitedWriter struct {
+	writer   Writer
+	limiters []*RateLimiter
+	done     *done.Instance
+}
+
+// NewRateLimitedWriter creates a new RateLimitedWriter.
+func NewRateLimitedWriter(writer Writer, limiters ...*RateLimiter) *RateLimitedWriter {
+	return &RateLimitedWriter{
+		writer:   writer,
+		limiters: limiters,
+		done:     done.New(),
+	}
+}
+
+// WriteMultiBuffer implements Writer.
+func (w *RateLimitedWriter) WriteMultiBuffer(mb MultiBuffer) error {
+	if err := WaitRateLimiters(w.limiters, int64(mb.Len()), w.done.Wait()); err != nil {
+		ReleaseMulti(mb)
+		return err
+	}
+	return w.writer.WriteMultiBuffer(mb)
+}
+
+// Close implements common.Closable.
+func (w *RateLimitedWriter) Close() error {
+	w.done.Close()
+	return common.Close(w.writer)
+}
+
+// Interrupt implements common.Interruptible.
+func (w *RateLimitedWriter) Interrupt() {
+	w.done.Close()
+	common.Interrupt(w.writer)
+}
+
+// RateLimitedReader is a Reader whose throughput is limited by RateLimiters.
+type RateLimitedReader struct {
+	reader   Reader
+	limiters []*RateLimiter
+	done     *done.Instance
+}
+
+// NewRateLimitedReader creates a new RateLimitedReader.
+func NewRateLimitedReader(reader Reader, limiters ...*RateLimiter) *RateLimitedReader {
+	return &RateLimitedReader{
+		reader:   reader,
+		limiters: limiters,
+		done:     done.New(),
+	}
+}
+
+func (r *RateLimitedReader) wait(mb MultiBuffer) (MultiBuffer, error) {
+	if err := WaitRateLimiters(r.limiters, int64(mb.Len()), r.done.Wait()); err != nil {
+		ReleaseMulti(mb)
+		return nil, err
+	}
+	return mb, nil
+}
+
+// ReadMultiBuffer implements Reader.
+func (r *RateLimitedReader) ReadMultiBuffer() (MultiBuffer, error) {
+	mb, err := r.reader.ReadMultiBuffer()
+	if err != nil {
+		return mb, err
+	}
+	return r.wait(mb)
+}
+
+// ReadMultiBufferTimeout implements TimeoutReader, if the underlying Reader does.
+func (r *RateLimitedReader) ReadMultiBufferTimeout(timeout time.Duration) (MultiBuffer, error) {
+	reader, ok := r.reader.(TimeoutReader)
+	if !ok {
+		return nil, ErrNotTimeoutReader
+	}
+	mb, err := reader.ReadMultiBufferTimeout(timeout)
+	if err != nil {
+		return mb, err
+	}
+	return r.wait(mb)
+}
+
+// Interrupt implements common.Interruptible.
+func (r *RateLimitedReader) Interrupt() {
+	r.done.Close()
+	common.Interrupt(r.reader)
+}
@@ -0,0 +1,64 @@
+package buf_test
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	. "github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/signal/done"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(64 * 1024)
+
+	start := time.Now()
+	// The burst is what is allowed in a second, so the rest takes half a second.
+	for i := 0; i < 3; i++ {
+		if err := WaitRateLimiters([]*RateLimiter{limiter}, 32*1024, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond || elapsed > 2*time.Second {
+		t.Error("unexpected time to send 96KB at 64KB/s: ", elapsed)
+	}
+
+	limiter.SetRate(0)
+	start = time.Now()
+	if err := WaitRateLimiters([]*RateLimiter{limiter}, 1024*1024, nil); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Error("unexpected wait of unlimited limiter: ", elapsed)
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	limiter := NewRateLimiter(1024)
+	cancel := done.New()
+	time.AfterFunc(100*time.Millisecond, func() {
+		cancel.Close()
+	})
+	if err := WaitRateLimiters([]*RateLimiter{limiter}, 1024*1024, cancel.Wait()); err != io.ErrClosedPipe {
+		t.Error("expected waiting to be canceled, but got ", err)
+	}
+}
+
+func TestRateLimitedWriter(t *testing.T) {
+	writer := NewRateLimitedWriter(Discard, NewRateLimiter(1024))
+	b := New()
+	b.Extend(Size)
+	if err := writer.WriteMultiBuffer(MultiBuffer{b}); err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		writer.Interrupt()
+	}()
+	b = New()
+	b.Extend(Size)
+	if err := writer.WriteMultiBuffer(MultiBuffer{b}); err == nil {
+		t.Error("expected interrupted write to fail")
+	}
+}
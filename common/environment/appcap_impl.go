@@ -0,0 +1,93 @@
+package environment
+
+import (
+	"github.com/v2fly/v2ray-core/v4/common/log"
+	"github.com/v2fly/v2ray-core/v4/common/platform/filesystem"
+	"github.com/v2fly/v2ray-core/v4/common/platform/filesystem/fsifce"
+	"github.com/v2fly/v2ray-core/v4/features/extension/storage"
+	"github.com/v2fly/v2ray-core/v4/transport/internet"
+	"github.com/v2fly/v2ray-core/v4/transport/internet/tagged"
+)
+
+type appEnvImpl struct {
+	root             *rootEnvImpl
+	scope            [][]byte
+	transientStorage storage.ScopedTransientStorage
+}
+
+func (a *appEnvImpl) doNotImpl() {
+	panic("placeholder doNotImpl")
+}
+
+func (a *appEnvImpl) RequireFeatures(callback interface{}) error {
+	return a.root.instance.RequireFeatures(callback)
+}
+
+func (a *appEnvImpl) RecordLog(msg log.Message) {
+	log.Record(msg)
+}
+
+func (a *appEnvImpl) Dialer() internet.SystemDialer {
+	return internet.EffectiveSystemDialer()
+}
+
+func (a *appEnvImpl) Listener() internet.SystemListener {
+	return internet.EffectiveSystemListener()
+}
+
+func (a *appEnvImpl) OutboundDialer() tagged.DialFunc {
+	return internet.DialTaggedOutbound
+}
+
+func (a *appEnvImpl) OpenFileForReadSeek() fsifce.FileSeekerFunc {
+	return filesystem.NewFileSeeker
+}
+
+func (a *appEnvImpl) OpenFileForRead() fsifce.FileReaderFunc {
+	return filesystem.NewFileReader
+}
+
+func (a *appEnvImpl) OpenFileForWrite() fsifce.FileWriterFunc {
+	return filesystem.NewFileWriter
+}
+
+// PersistentStorage returns the scope of the persistent storage of the instance reserved for this environment, or nil
+// if the instance has no persistent storage. It is looked up on each call, as the storage may be added to the
+// instance after the environment is created.
+func (a *appEnvImpl) PersistentStorage() storage.ScopedPersistentStorage {
+	service, ok := a.root.instance.GetFeature(storage.ScopedPersistentStorageServiceType()).(storage.ScopedPersistentStorageService)
+	if !ok {
+		return nil
+	}
+	var scope storage.ScopedPersistentStorage = service
+	for _, key := range a.scope {
+		narrowed, err := scope.NarrowScope(a.root.ctx, key)
+		if err != nil {
+			log.Record(&log.GeneralMessage{
+				Severity: log.Severity_Warning,
+				Content:  "failed to open persistent storage: " + err.Error(),
+			})
+			return nil
+		}
+		scope = narrowed
+	}
+	return scope
+}
+
+func (a *appEnvImpl) TransientStorage() storage.ScopedTransientStorage {
+	return a.transientStorage
+}
+
+func (a *appEnvImpl) NarrowScope(key []byte) (AppEnvironment, error) {
+	transientStorage, err := a.transientStorage.NarrowScope(a.root.ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	scope := make([][]byte, len(a.scope), len(a.scope)+1)
+	copy(scope, a.scope)
+	return &appEnvImpl{
+		root:             a.root,
+		scope:            append(scope, key),
+		transientStorage: transientStorage,
+	}, nil
+}
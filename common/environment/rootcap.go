@@ -0,0 +1,6 @@
+package environment
+
+type RootEnvironment interface {
+	AppEnvironment(tag string) AppEnvironment
+	doNotImpl()
+}
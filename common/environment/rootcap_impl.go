@@ -0,0 +1,47 @@
+package environment
+
+import (
+	"context"
+
+	"github.com/v2fly/v2ray-core/v4/features"
+	"github.com/v2fly/v2ray-core/v4/features/extension/storage"
+)
+
+// InstanceFeatures looks up the features of a V2Ray instance.
+type InstanceFeatures interface {
+	FeaturesLookupCapabilitySet
+	GetFeature(featureType interface{}) features.Feature
+}
+
+// NewRootEnvImpl creates the root environment of an instance. The persistent storage of the environments is the
+// ScopedPersistentStorageService feature of the instance, if any.
+func NewRootEnvImpl(ctx context.Context, instance InstanceFeatures, transientStorage storage.ScopedTransientStorage) RootEnvironment {
+	return &rootEnvImpl{
+		ctx:              ctx,
+		instance:         instance,
+		transientStorage: transientStorage,
+	}
+}
+
+type rootEnvImpl struct {
+	ctx              context.Context
+	instance         InstanceFeatures
+	transientStorage storage.ScopedTransientStorage
+}
+
+func (r *rootEnvImpl) doNotImpl() {
+	panic("placeholder doNotImpl")
+}
+
+// AppEnvironment returns the environment of the app with the given tag, whose storages are scoped to the tag.
+func (r *rootEnvImpl) AppEnvironment(tag string) AppEnvironment {
+	transientStorage, err := r.transientStorage.NarrowScope(r.ctx, []byte(tag))
+	if err != nil {
+		return nil
+	}
+	return &appEnvImpl{
+		root:             r,
+		scope:            [][]byte{[]byte(tag)},
+		transientStorage: transientStorage,
+	}
+}
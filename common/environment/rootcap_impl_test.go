@@ -0,0 +1,69 @@
+package environment_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/v2fly/v2ray-core/v4/app/persistentstorage/filesystemstorage"
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/environment"
+	"github.com/v2fly/v2ray-core/v4/common/environment/transientstorageimpl"
+	"github.com/v2fly/v2ray-core/v4/features"
+)
+
+type testInstance struct {
+	features []features.Feature
+}
+
+func (i *testInstance) RequireFeatures(callback interface{}) error {
+	return nil
+}
+
+func (i *testInstance) GetFeature(featureType interface{}) features.Feature {
+	for _, f := range i.features {
+		if f.Type() == featureType {
+			return f
+		}
+	}
+	return nil
+}
+
+func TestAppEnvironmentStorage(t *testing.T) {
+	ctx := context.Background()
+	instance := &testInstance{}
+	root := environment.NewRootEnvImpl(ctx, instance, transientstorageimpl.NewScopedTransientStorageImpl())
+
+	app := root.AppEnvironment("app")
+	if app.PersistentStorage() != nil {
+		t.Error("expected no persistent storage without the storage feature")
+	}
+
+	fs := filesystemstorage.NewFileSystemStorage(ctx, &filesystemstorage.Config{
+		StateStorageRoot: t.TempDir(),
+	})
+	common.Must(fs.Start())
+	instance.features = append(instance.features, fs)
+
+	sub, err := app.NarrowScope([]byte("sub"))
+	common.Must(err)
+	common.Must(sub.PersistentStorage().Put(ctx, []byte("k"), []byte("persistent")))
+	common.Must(sub.TransientStorage().Put(ctx, []byte("k"), "transient"))
+
+	appScope, err := fs.NarrowScope(ctx, []byte("app"))
+	common.Must(err)
+	subScope, err := appScope.NarrowScope(ctx, []byte("sub"))
+	common.Must(err)
+	if value, err := subScope.Get(ctx, []byte("k")); err != nil || !bytes.Equal(value, []byte("persistent")) {
+		t.Error("unexpected persistent value ", string(value), err)
+	}
+
+	sameSub, err := root.AppEnvironment("app").NarrowScope([]byte("sub"))
+	common.Must(err)
+	if value, err := sameSub.TransientStorage().Get(ctx, []byte("k")); err != nil || value.(string) != "transient" {
+		t.Error("unexpected transient value ", value, err)
+	}
+	if _, err := root.AppEnvironment("other").TransientStorage().Get(ctx, []byte("k")); err == nil {
+		t.Error("expected the scopes of apps to be separated")
+	}
+}
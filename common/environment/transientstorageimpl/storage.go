@@ -0,0 +1,94 @@
+package transientstorageimpl
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/v2fly/v2ray-core/v4/features/extension/storage"
+)
+
+// ScopedTransientStorageImpl is an in-memory ScopedTransientStorage. Values do not survive restarts.
+type ScopedTransientStorageImpl struct {
+	access sync.RWMutex
+	values map[string]interface{}
+	scopes map[string]*ScopedTransientStorageImpl
+}
+
+// NewScopedTransientStorageImpl creates an empty root scope.
+func NewScopedTransientStorageImpl() storage.ScopedTransientStorage {
+	return newScope()
+}
+
+func newScope() *ScopedTransientStorageImpl {
+	return &ScopedTransientStorageImpl{
+		values: make(map[string]interface{}),
+		scopes: make(map[string]*ScopedTransientStorageImpl),
+	}
+}
+
+func (s *ScopedTransientStorageImpl) ScopedTransientStorage() {}
+
+// Put stores value under key. A nil value removes the key.
+func (s *ScopedTransientStorageImpl) Put(ctx context.Context, key []byte, value interface{}) error {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	if value == nil {
+		delete(s.values, string(key))
+		return nil
+	}
+	s.values[string(key)] = value
+	return nil
+}
+
+func (s *ScopedTransientStorageImpl) Get(ctx context.Context, key []byte) (interface{}, error) {
+	s.access.RLock()
+	defer s.access.RUnlock()
+
+	value, found := s.values[string(key)]
+	if !found {
+		return nil, storage.ErrNotFound
+	}
+	return value, nil
+}
+
+func (s *ScopedTransientStorageImpl) List(ctx context.Context, keyPrefix []byte) ([][]byte, error) {
+	s.access.RLock()
+	defer s.access.RUnlock()
+
+	var keys [][]byte
+	for key := range s.values {
+		if bytes.HasPrefix([]byte(key), keyPrefix) {
+			keys = append(keys, []byte(key))
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+	return keys, nil
+}
+
+// Clear removes all values of this scope and all scopes narrowed from it.
+func (s *ScopedTransientStorageImpl) Clear(ctx context.Context) {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	s.values = make(map[string]interface{})
+	for _, scope := range s.scopes {
+		scope.Clear(ctx)
+	}
+}
+
+func (s *ScopedTransientStorageImpl) NarrowScope(ctx context.Context, key []byte) (storage.ScopedTransientStorage, error) {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	if scope, found := s.scopes[string(key)]; found {
+		return scope, nil
+	}
+	scope := newScope()
+	s.scopes[string(key)] = scope
+	return scope, nil
+}
@@ -0,0 +1,39 @@
+package transientstorageimpl_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/environment/transientstorageimpl"
+	"github.com/v2fly/v2ray-core/v4/features/extension/storage"
+)
+
+func TestTransientStorage(t *testing.T) {
+	ctx := context.Background()
+	root := transientstorageimpl.NewScopedTransientStorageImpl()
+
+	common.Must(root.Put(ctx, []byte("k1"), 1))
+	common.Must(root.Put(ctx, []byte("k2"), "2"))
+
+	sub, err := root.NarrowScope(ctx, []byte("sub"))
+	common.Must(err)
+	common.Must(sub.Put(ctx, []byte("k1"), 3))
+
+	if value, err := root.Get(ctx, []byte("k1")); err != nil || value.(int) != 1 {
+		t.Error("unexpected value ", value, err)
+	}
+	if keys, _ := root.List(ctx, []byte("k")); len(keys) != 2 {
+		t.Error("unexpected keys ", keys)
+	}
+
+	sameSub, _ := root.NarrowScope(ctx, []byte("sub"))
+	if value, err := sameSub.Get(ctx, []byte("k1")); err != nil || value.(int) != 3 {
+		t.Error("unexpected value ", value, err)
+	}
+
+	root.Clear(ctx)
+	if _, err := sub.Get(ctx, []byte("k1")); err != storage.ErrNotFound {
+		t.Error("expected narrowed scope to be cleared, got ", err)
+	}
+}
@@ -44,6 +44,11 @@ func (err *Error) pkgPath() string {
 	return path
 }
 
+// Package returns the path of the package the error originates from, relative to the module.
+func (err *Error) Package() string {
+	return err.pkgPath()
+}
+
 // Error implements error.Error().
 func (err *Error) Error() string {
 	builder := strings.Builder{}
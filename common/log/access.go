@@ -2,7 +2,9 @@ package log
 
 import (
 	"context"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/v2fly/v2ray-core/v4/common/serial"
 )
@@ -18,6 +20,8 @@ type AccessStatus string
 const (
 	AccessAccepted = AccessStatus("accepted")
 	AccessRejected = AccessStatus("rejected")
+	// AccessClosed is the status of the connection when it is closed, which is recorded with its traffic.
+	AccessClosed = AccessStatus("closed")
 )
 
 type AccessMessage struct {
@@ -27,6 +31,18 @@ type AccessMessage struct {
 	Reason interface{}
 	Email  string
 	Detour string
+	// InboundTag is the tag of the inbound the connection comes from.
+	InboundTag string
+	// Domain is the domain sniffed from the connection.
+	Domain string
+	// Protocol is the protocol sniffed from the connection.
+	Protocol string
+
+	// Uplink and Downlink are the bytes sent and received through the connection, and Duration is how long it
+	// lasts. They are recorded when the connection is closed.
+	Uplink   int64
+	Downlink int64
+	Duration time.Duration
 }
 
 func (m *AccessMessage) String() string {
@@ -53,6 +69,15 @@ func (m *AccessMessage) String() string {
 		builder.WriteString(m.Email)
 	}
 
+	if m.Status == AccessClosed {
+		builder.WriteString(" uplink: ")
+		builder.WriteString(strconv.FormatInt(m.Uplink, 10))
+		builder.WriteString(" downlink: ")
+		builder.WriteString(strconv.FormatInt(m.Downlink, 10))
+		builder.WriteString(" duration: ")
+		builder.WriteString(m.Duration.Round(time.Millisecond).String())
+	}
+
 	return builder.String()
 }
 
@@ -0,0 +1,83 @@
+package log
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v4/common/serial"
+)
+
+// hasPackage is a log content knowing the package it originates from.
+type hasPackage interface {
+	Package() string
+}
+
+type jsonAccessMessage struct {
+	Time       string `json:"time"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Status     string `json:"status"`
+	Reason     string `json:"reason,omitempty"`
+	Email      string `json:"email,omitempty"`
+	Detour     string `json:"detour,omitempty"`
+	InboundTag string `json:"inboundTag,omitempty"`
+	Domain     string `json:"domain,omitempty"`
+	Protocol   string `json:"protocol,omitempty"`
+	// Traffic of closed connections, with the duration in seconds.
+	Uplink   *int64   `json:"uplink,omitempty"`
+	Downlink *int64   `json:"downlink,omitempty"`
+	Duration *float64 `json:"duration,omitempty"`
+}
+
+type jsonGeneralMessage struct {
+	Time     string `json:"time"`
+	Severity string `json:"level,omitempty"`
+	Package  string `json:"package,omitempty"`
+	Message  string `json:"message"`
+}
+
+func formatJSON(msg Message) string {
+	now := time.Now().Format(time.RFC3339Nano)
+	var v interface{}
+	switch msg := msg.(type) {
+	case *AccessMessage:
+		m := &jsonAccessMessage{
+			Time:       now,
+			From:       serial.ToString(msg.From),
+			To:         serial.ToString(msg.To),
+			Status:     string(msg.Status),
+			Reason:     serial.ToString(msg.Reason),
+			Email:      msg.Email,
+			Detour:     msg.Detour,
+			InboundTag: msg.InboundTag,
+			Domain:     msg.Domain,
+			Protocol:   msg.Protocol,
+		}
+		if msg.Status == AccessClosed {
+			duration := msg.Duration.Seconds()
+			m.Uplink, m.Downlink, m.Duration = &msg.Uplink, &msg.Downlink, &duration
+		}
+		v = m
+	case *GeneralMessage:
+		m := &jsonGeneralMessage{
+			Time:     now,
+			Severity: strings.ToLower(msg.Severity.String()),
+			Message:  serial.ToString(msg.Content),
+		}
+		if content, ok := msg.Content.(hasPackage); ok {
+			m.Package = content.Package()
+		}
+		v = m
+	default:
+		v = &jsonGeneralMessage{
+			Time:    now,
+			Message: msg.String(),
+		}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return msg.String()
+	}
+	return string(b)
+}
@@ -20,8 +20,15 @@ type Writer interface {
 // WriterCreator is a function to create LogWriters.
 type WriterCreator func() Writer
 
+// SeverityWriter is a Writer of a system log, which records messages with their severity.
+type SeverityWriter interface {
+	Writer
+	WriteSeverity(string, Severity) error
+}
+
 type generalLogger struct {
 	creator WriterCreator
+	json    bool
 	buffer  chan Message
 	access  *semaphore.Instance
 	done    *done.Instance
@@ -29,14 +36,48 @@ type generalLogger struct {
 
 // NewLogger returns a generic log handler that can handle all type of messages.
 func NewLogger(logWriterCreator WriterCreator) Handler {
+	return newLogger(logWriterCreator, false)
+}
+
+// NewJSONLogger returns a log handler that writes each message as a JSON object on its own line.
+func NewJSONLogger(logWriterCreator WriterCreator) Handler {
+	return newLogger(logWriterCreator, true)
+}
+
+func newLogger(logWriterCreator WriterCreator, json bool) Handler {
 	return &generalLogger{
 		creator: logWriterCreator,
+		json:    json,
 		buffer:  make(chan Message, 16),
 		access:  semaphore.New(1),
 		done:    done.New(),
 	}
 }
 
+func (l *generalLogger) write(w Writer, msg Message) error {
+	var s string
+	if l.json {
+		s = formatJSON(msg)
+	} else {
+		s = msg.String()
+	}
+	// System logs record the time of messages by themselves.
+	if w, ok := w.(SeverityWriter); ok {
+		return w.WriteSeverity(s, severityOf(msg))
+	}
+	if !l.json {
+		s = time.Now().Format("2006/01/02 15:04:05 ") + s
+	}
+	return w.Write(s + platform.LineSeparator())
+}
+
+func severityOf(msg Message) Severity {
+	if msg, ok := msg.(*GeneralMessage); ok {
+		return msg.Severity
+	}
+	return Severity_Info
+}
+
 func (l *generalLogger) run() {
 	defer l.access.Signal()
 
@@ -55,7 +96,7 @@ func (l *generalLogger) run() {
 		case <-l.done.Wait():
 			return
 		case msg := <-l.buffer:
-			logger.Write(msg.String() + platform.LineSeparator())
+			l.write(logger, msg)
 			dataWritten = true
 		case <-ticker.C:
 			if !dataWritten {
@@ -114,7 +155,7 @@ func (w *fileLogWriter) Close() error {
 func CreateStdoutLogWriter() WriterCreator {
 	return func() Writer {
 		return &consoleLogWriter{
-			logger: log.New(os.Stdout, "", log.Ldate|log.Ltime),
+			logger: log.New(os.Stdout, "", 0),
 		}
 	}
 }
@@ -123,7 +164,7 @@ func CreateStdoutLogWriter() WriterCreator {
 func CreateStderrLogWriter() WriterCreator {
 	return func() Writer {
 		return &consoleLogWriter{
-			logger: log.New(os.Stderr, "", log.Ldate|log.Ltime),
+			logger: log.New(os.Stderr, "", 0),
 		}
 	}
 }
@@ -142,7 +183,7 @@ func CreateFileLogWriter(path string) (WriterCreator, error) {
 		}
 		return &fileLogWriter{
 			file:   file,
-			logger: log.New(file, "", log.Ldate|log.Ltime),
+			logger: log.New(file, "", 0),
 		}
 	}, nil
 }
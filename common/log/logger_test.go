@@ -1,6 +1,7 @@
 package log_test
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"strings"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/v2fly/v2ray-core/v4/common"
 	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/errors"
 	. "github.com/v2fly/v2ray-core/v4/common/log"
 )
 
@@ -37,3 +39,51 @@ func TestFileLogger(t *testing.T) {
 		t.Fatal("Expect log text contains 'Test Log', but actually: ", string(b))
 	}
 }
+
+func TestJSONLogger(t *testing.T) {
+	f, err := ioutil.TempFile("", "vtest")
+	common.Must(err)
+	path := f.Name()
+	common.Must(f.Close())
+
+	creator, err := CreateFileLogWriter(path)
+	common.Must(err)
+
+	handler := NewJSONLogger(creator)
+	handler.Handle(&AccessMessage{
+		From:       "127.0.0.1:1080",
+		To:         "tcp:v2fly.org:443",
+		Status:     AccessAccepted,
+		Detour:     "direct",
+		InboundTag: "socks",
+		Domain:     "v2fly.org",
+	})
+	handler.Handle(&GeneralMessage{
+		Severity: Severity_Warning,
+		Content:  errors.New("Test Log").WithPathObj(testLogger{}),
+	})
+	time.Sleep(2 * time.Second)
+
+	common.Must(common.Close(handler))
+
+	b, err := ioutil.ReadFile(path)
+	common.Must(err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 2 {
+		t.Fatal("expected 2 lines, but actually: ", string(b))
+	}
+
+	var access map[string]string
+	common.Must(json.Unmarshal([]byte(lines[0]), &access))
+	for k, v := range map[string]string{"from": "127.0.0.1:1080", "status": "accepted", "detour": "direct", "inboundTag": "socks", "domain": "v2fly.org"} {
+		if access[k] != v {
+			t.Error("expected ", k, " to be ", v, ", but actually: ", access[k])
+		}
+	}
+
+	var general map[string]string
+	common.Must(json.Unmarshal([]byte(lines[1]), &general))
+	if general["level"] != "warning" || general["package"] != "common/log_test" || general["time"] == "" {
+		t.Error("unexpected error log: ", lines[1])
+	}
+}
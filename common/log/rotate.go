@@ -0,0 +1,216 @@
+package log
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// backupTimeFormat is the format of the time rotated files are named after, which sorts as the time does.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotationOptions are when a log file is rotated, and how the rotated files are kept.
+type RotationOptions struct {
+	// MaxSize is the size in bytes a file is rotated at. Zero means no limit.
+	MaxSize int64
+	// Interval is how often a file is rotated, aligned to UTC. Zero means the file is not rotated by time.
+	Interval time.Duration
+	// MaxBackups is how many rotated files are kept. Zero means all of them.
+	MaxBackups int
+	// MaxAge is how long rotated files are kept. Zero means forever.
+	MaxAge time.Duration
+	// Compress is whether rotated files are compressed with gzip.
+	Compress bool
+}
+
+// rotatingFile is a log file rotated by size and time. The file is named after the time it is rotated at, with the
+// path as prefix, e.g. access.log.2021-06-04T15-04-05.000.gz.
+type rotatingFile struct {
+	path    string
+	options RotationOptions
+
+	file *os.File
+	size int64
+	// start is when the current file is started, or last written if it is not started by this writer.
+	start time.Time
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	f.start = time.Now()
+	if f.size > 0 {
+		f.start = info.ModTime()
+	}
+	return nil
+}
+
+func (f *rotatingFile) due(now time.Time, n int) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.options.MaxSize > 0 && f.size+int64(n) > f.options.MaxSize {
+		return true
+	}
+	return f.options.Interval > 0 && !now.Truncate(f.options.Interval).Equal(f.start.Truncate(f.options.Interval))
+}
+
+func (f *rotatingFile) rotate(now time.Time) error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+	backup := f.path + "." + now.Format(backupTimeFormat)
+	if err := os.Rename(f.path, backup); err != nil {
+		return err
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	// Cleaned up before writing on, so that the next rotation never runs into a cleanup in progress.
+	f.cleanup(backup)
+	return nil
+}
+
+// Write implements Writer.
+func (f *rotatingFile) Write(s string) error {
+	if f.file == nil {
+		return os.ErrClosed
+	}
+	now := time.Now()
+	if f.due(now, len(s)) {
+		if err := f.rotate(now); err != nil {
+			return err
+		}
+	}
+	n, err := f.file.WriteString(s)
+	f.size += int64(n)
+	return err
+}
+
+// Close implements Writer.
+func (f *rotatingFile) Close() error {
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// backupFile is a rotated file.
+type backupFile struct {
+	name    string
+	rotated time.Time
+}
+
+// cleanup compresses the file just rotated, and removes the rotated files no longer kept.
+func (f *rotatingFile) cleanup(backup string) {
+	if f.options.Compress {
+		if err := compressFile(backup); err != nil {
+			return
+		}
+	}
+
+	dir, prefix := filepath.Split(f.path)
+	if dir == "" {
+		dir = "."
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	var backups []backupFile
+	for _, entry := range entries {
+		if rotated, ok := parseBackupName(entry.Name(), prefix); ok {
+			backups = append(backups, backupFile{name: entry.Name(), rotated: rotated})
+		}
+	}
+	// Newest first.
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].rotated.After(backups[j].rotated)
+	})
+	now := time.Now()
+	for i, backup := range backups {
+		remove := f.options.MaxBackups > 0 && i >= f.options.MaxBackups
+		if f.options.MaxAge > 0 && now.Sub(backup.rotated) > f.options.MaxAge {
+			remove = true
+		}
+		if remove {
+			os.Remove(filepath.Join(dir, backup.name))
+		}
+	}
+}
+
+// parseBackupName returns the time the file is rotated at, if it is named as a rotated file of the prefix, that is
+// the prefix followed by the time, and optionally by .gz.
+func parseBackupName(name string, prefix string) (time.Time, bool) {
+	if !strings.HasPrefix(name, prefix+".") {
+		return time.Time{}, false
+	}
+	timestamp := strings.TrimSuffix(name[len(prefix)+1:], ".gz")
+	rotated, err := time.ParseInLocation(backupTimeFormat, timestamp, time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return rotated, true
+}
+
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmp := path + ".gz.tmp"
+	dst, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	w := gzip.NewWriter(dst)
+	_, err = io.Copy(w, src)
+	if err == nil {
+		err = w.Close()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path+".gz")
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Remove(path)
+}
+
+// CreateRotatingFileLogWriter returns a LogWriterCreator that creates LogWriter for the given file, which is rotated
+// with the options.
+func CreateRotatingFileLogWriter(path string, options RotationOptions) (WriterCreator, error) {
+	f := &rotatingFile{path: path, options: options}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	f.Close()
+	return func() Writer {
+		if err := f.open(); err != nil {
+			return nil
+		}
+		return f
+	}, nil
+}
@@ -0,0 +1,93 @@
+package log_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	. "github.com/v2fly/v2ray-core/v4/common/log"
+)
+
+func TestRotatingFileLogger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vtest")
+	common.Must(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "access.log")
+
+	creator, err := CreateRotatingFileLogWriter(path, RotationOptions{
+		MaxSize:    64,
+		MaxBackups: 2,
+		Compress:   true,
+	})
+	common.Must(err)
+
+	handler := NewLogger(creator)
+	for i := 0; i < 5; i++ {
+		handler.Handle(&GeneralMessage{Content: strings.Repeat("x", 40)})
+		time.Sleep(100 * time.Millisecond)
+	}
+	time.Sleep(time.Second)
+	common.Must(common.Close(handler))
+
+	b, err := ioutil.ReadFile(path)
+	common.Must(err)
+	if strings.Count(string(b), "x") != 40 {
+		t.Error("expected the current file to have only the last message, but actually: ", string(b))
+	}
+
+	backups, err := filepath.Glob(path + ".*")
+	common.Must(err)
+	if len(backups) != 2 {
+		t.Fatal("expected 2 rotated files kept, but actually: ", backups)
+	}
+	for _, backup := range backups {
+		if !strings.HasSuffix(backup, ".gz") {
+			t.Error("expected rotated file to be compressed, but actually: ", backup)
+		}
+	}
+}
+
+func TestRotatingFileKeepsUnrelatedFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vtest")
+	common.Must(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "access.log")
+
+	unrelated := []string{"access.log.err", "access.log.old.gz", "access.log.2021-06-04T15-04-05.000.gz.tmp", "access.log.2021-06-04.gz"}
+	for _, name := range unrelated {
+		common.Must(ioutil.WriteFile(filepath.Join(dir, name), []byte("keep"), 0o600))
+	}
+
+	creator, err := CreateRotatingFileLogWriter(path, RotationOptions{
+		MaxSize:    64,
+		MaxBackups: 1,
+	})
+	common.Must(err)
+	w := creator()
+	for i := 0; i < 4; i++ {
+		common.Must(w.Write(strings.Repeat("x", 40)))
+		time.Sleep(10 * time.Millisecond)
+	}
+	common.Must(w.Close())
+
+	for _, name := range unrelated {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Error("unrelated file is removed: ", name)
+		}
+	}
+	backups, err := filepath.Glob(path + ".20*")
+	common.Must(err)
+	var rotated []string
+	for _, backup := range backups {
+		if !strings.HasSuffix(backup, ".tmp") && !strings.HasSuffix(backup, "2021-06-04.gz") {
+			rotated = append(rotated, backup)
+		}
+	}
+	if len(rotated) != 1 {
+		t.Error("expected 1 rotated file kept, but actually: ", rotated)
+	}
+}
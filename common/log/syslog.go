@@ -0,0 +1,148 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package log
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log/syslog"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// defaultJournalSocket is where systemd-journald receives entries of its native protocol.
+const defaultJournalSocket = "/run/systemd/journal/socket"
+
+// priorityOf returns the syslog priority of the severity.
+func priorityOf(severity Severity) syslog.Priority {
+	switch severity {
+	case Severity_Error:
+		return syslog.LOG_ERR
+	case Severity_Warning:
+		return syslog.LOG_WARNING
+	case Severity_Debug:
+		return syslog.LOG_DEBUG
+	default:
+		return syslog.LOG_INFO
+	}
+}
+
+type syslogWriter struct {
+	writer *syslog.Writer
+}
+
+func (w *syslogWriter) Write(s string) error {
+	return w.WriteSeverity(s, Severity_Info)
+}
+
+func (w *syslogWriter) WriteSeverity(s string, severity Severity) error {
+	s = strings.TrimSuffix(s, "\n")
+	switch priorityOf(severity) {
+	case syslog.LOG_ERR:
+		return w.writer.Err(s)
+	case syslog.LOG_WARNING:
+		return w.writer.Warning(s)
+	case syslog.LOG_DEBUG:
+		return w.writer.Debug(s)
+	default:
+		return w.writer.Info(s)
+	}
+}
+
+func (w *syslogWriter) Close() error {
+	return w.writer.Close()
+}
+
+func dialSyslog(path string, tag string) (*syslog.Writer, error) {
+	if path == "" {
+		return syslog.New(syslog.LOG_DAEMON, tag)
+	}
+	writer, err := syslog.Dial("unixgram", path, syslog.LOG_DAEMON, tag)
+	if err != nil {
+		writer, err = syslog.Dial("unix", path, syslog.LOG_DAEMON, tag)
+	}
+	return writer, err
+}
+
+// CreateSyslogLogWriter returns a LogWriterCreator that creates LogWriter for the local syslog daemon listening on
+// the unix socket path, or the default one of the system if path is empty. Entries are identified by the tag.
+func CreateSyslogLogWriter(path string, tag string) (WriterCreator, error) {
+	writer, err := dialSyslog(path, tag)
+	if err != nil {
+		return nil, err
+	}
+	writer.Close()
+	return func() Writer {
+		writer, err := dialSyslog(path, tag)
+		if err != nil {
+			return nil
+		}
+		return &syslogWriter{writer: writer}
+	}, nil
+}
+
+type journalWriter struct {
+	conn net.Conn
+	tag  string
+}
+
+// writeJournalField writes a field in the native protocol of systemd-journald. Values of multiple lines are written
+// with their length.
+func writeJournalField(b *bytes.Buffer, name string, value string) {
+	b.WriteString(name)
+	if !strings.Contains(value, "\n") {
+		b.WriteByte('=')
+		b.WriteString(value)
+		b.WriteByte('\n')
+		return
+	}
+	b.WriteByte('\n')
+	var size [8]byte
+	binary.LittleEndian.PutUint64(size[:], uint64(len(value)))
+	b.Write(size[:])
+	b.WriteString(value)
+	b.WriteByte('\n')
+}
+
+func (w *journalWriter) Write(s string) error {
+	return w.WriteSeverity(s, Severity_Info)
+}
+
+func (w *journalWriter) WriteSeverity(s string, severity Severity) error {
+	var b bytes.Buffer
+	writeJournalField(&b, "PRIORITY", strconv.Itoa(int(priorityOf(severity))))
+	writeJournalField(&b, "SYSLOG_IDENTIFIER", w.tag)
+	writeJournalField(&b, "MESSAGE", strings.TrimSuffix(s, "\n"))
+	_, err := w.conn.Write(b.Bytes())
+	return err
+}
+
+func (w *journalWriter) Close() error {
+	return w.conn.Close()
+}
+
+func dialJournal(path string) (net.Conn, error) {
+	if path == "" {
+		path = defaultJournalSocket
+	}
+	return net.DialUnix("unixgram", nil, &net.UnixAddr{Name: path, Net: "unixgram"})
+}
+
+// CreateJournaldLogWriter returns a LogWriterCreator that creates LogWriter for systemd-journald listening on the
+// unix socket path, or the default one if path is empty. Entries are identified by the tag.
+func CreateJournaldLogWriter(path string, tag string) (WriterCreator, error) {
+	conn, err := dialJournal(path)
+	if err != nil {
+		return nil, err
+	}
+	conn.Close()
+	return func() Writer {
+		conn, err := dialJournal(path)
+		if err != nil {
+			return nil
+		}
+		return &journalWriter{conn: conn, tag: tag}
+	}, nil
+}
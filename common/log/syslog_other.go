@@ -0,0 +1,20 @@
+//go:build windows || plan9
+// +build windows plan9
+
+package log
+
+import (
+	"errors"
+)
+
+var errSyslogNotSupported = errors.New("system log is not supported on this platform")
+
+// CreateSyslogLogWriter is not supported on this platform.
+func CreateSyslogLogWriter(path string, tag string) (WriterCreator, error) {
+	return nil, errSyslogNotSupported
+}
+
+// CreateJournaldLogWriter is not supported on this platform.
+func CreateJournaldLogWriter(path string, tag string) (WriterCreator, error) {
+	return nil, errSyslogNotSupported
+}
@@ -0,0 +1,46 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package log_test
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	. "github.com/v2fly/v2ray-core/v4/common/log"
+)
+
+func TestJournaldLogger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vtest")
+	common.Must(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "journal.socket")
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	common.Must(err)
+	defer conn.Close()
+
+	creator, err := CreateJournaldLogWriter(path, "v2ray")
+	common.Must(err)
+	handler := NewLogger(creator)
+	defer handler.(common.Closable).Close()
+
+	handler.Handle(&GeneralMessage{Severity: Severity_Warning, Content: "Test Log"})
+
+	common.Must(conn.SetReadDeadline(time.Now().Add(2 * time.Second)))
+	b := make([]byte, 1024)
+	n, err := conn.Read(b)
+	common.Must(err)
+	entry := string(b[:n])
+	for _, field := range []string{"PRIORITY=4\n", "SYSLOG_IDENTIFIER=v2ray\n", "MESSAGE=[Warning] Test Log\n"} {
+		if !strings.Contains(entry, field) {
+			t.Error("expected entry to contain ", field, ", but actually: ", entry)
+		}
+	}
+}
@@ -84,3 +84,10 @@ func GetConfDirPath() string {
 	configPath := NewEnvFlag(name).GetValue(func() string { return "" })
 	return configPath
 }
+
+// GetStateDirectory reads "v2ray.location.state", the root directory of persistent state.
+func GetStateDirectory() string {
+	const name = "v2ray.location.state"
+	stateDir := NewEnvFlag(name).GetValue(getExecutableSubDir("state"))
+	return stateDir
+}
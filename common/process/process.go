@@ -0,0 +1,31 @@
+// Package process finds the local processes owning network connections.
+package process
+
+//go:generate go run github.com/v2fly/v2ray-core/v4/common/errors/errorgen
+
+import (
+	"errors"
+
+	"github.com/v2fly/v2ray-core/v4/common/net"
+)
+
+// ErrNotFound is returned when no local socket matches the given address.
+var ErrNotFound = errors.New("process not found")
+
+// Info describes the local process that owns a socket.
+type Info struct {
+	// PID is 0 if the socket is found but not the process, for example without the permission to inspect it.
+	PID int
+	UID uint32
+	// Name is the file name of the executable, and Path is its absolute path. Either may be empty.
+	Name string
+	Path string
+}
+
+// FindProcess returns the local process owning the socket bound to the given source address.
+func FindProcess(source net.Destination) (*Info, error) {
+	if !source.IsValid() || !source.Address.Family().IsIP() {
+		return nil, newError("invalid source address ", source)
+	}
+	return findProcess(source.Network, source.Address.IP(), source.Port)
+}
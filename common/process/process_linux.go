@@ -0,0 +1,270 @@
+//go:build linux
+// +build linux
+
+package process
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/v2fly/v2ray-core/v4/common/net"
+)
+
+var nativeEndian binary.ByteOrder
+
+func init() {
+	var x uint16 = 1
+	if *(*byte)(unsafe.Pointer(&x)) == 1 {
+		nativeEndian = binary.LittleEndian
+	} else {
+		nativeEndian = binary.BigEndian
+	}
+}
+
+type socketEntry struct {
+	uid   uint32
+	inode string
+}
+
+func findProcess(network net.Network, ip net.IP, port net.Port) (*Info, error) {
+	var tables []string
+	switch network {
+	case net.Network_TCP:
+		tables = []string{"tcp", "tcp6"}
+	case net.Network_UDP:
+		tables = []string{"udp", "udp6"}
+	default:
+		tables = []string{"tcp", "tcp6", "udp", "udp6"}
+	}
+
+	var wildcard *socketEntry
+	for _, table := range tables {
+		exact, unspecified, err := findSocket(filepath.Join("/proc/net", table), ip, port)
+		if err != nil {
+			return nil, err
+		}
+		if exact != nil {
+			return findSocketOwner(exact)
+		}
+		// Unconnected UDP sockets are often bound to the unspecified address. A TCP socket bound to it is a
+		// listener, which does not own the connections from its port.
+		if wildcard == nil && strings.HasPrefix(table, "udp") {
+			wildcard = unspecified
+		}
+	}
+	if wildcard != nil {
+		return findSocketOwner(wildcard)
+	}
+	return nil, ErrNotFound
+}
+
+// findSocket looks for the socket with the given local address in a table like /proc/net/tcp.
+// It returns the socket bound to exactly the address if any, and otherwise one bound to the
+// unspecified address with the same port.
+func findSocket(path string, ip net.IP, port net.Port) (exact *socketEntry, unspecified *socketEntry, err error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, newError("failed to open ", path).Base(err)
+	}
+	defer f.Close()
+
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	scanner := bufio.NewScanner(f)
+	scanner.Scan() // Skip the header.
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		localIP, localPort, ok := parseSocketAddress(fields[1])
+		if !ok || localPort != port {
+			continue
+		}
+		uid, err := strconv.ParseUint(fields[7], 10, 32)
+		if err != nil {
+			continue
+		}
+		entry := &socketEntry{uid: uint32(uid), inode: fields[9]}
+		if entry.inode == "0" {
+			// Sockets in TIME_WAIT have no owner.
+			continue
+		}
+		switch {
+		case localIP.Equal(ip):
+			return entry, nil, nil
+		case localIP.IsUnspecified() && unspecified == nil:
+			unspecified = entry
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, newError("failed to read ", path).Base(err)
+	}
+	return nil, unspecified, nil
+}
+
+// parseSocketAddress parses an address like "0100007F:1F90". The IP is printed as 32-bit words in native byte order.
+func parseSocketAddress(s string) (net.IP, net.Port, bool) {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return nil, 0, false
+	}
+	raw, err := hex.DecodeString(s[:i])
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return nil, 0, false
+	}
+	port, err := strconv.ParseUint(s[i+1:], 16, 16)
+	if err != nil {
+		return nil, 0, false
+	}
+	ip := make(net.IP, len(raw))
+	for j := 0; j < len(raw); j += 4 {
+		nativeEndian.PutUint32(ip[j:], binary.BigEndian.Uint32(raw[j:]))
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	return ip, net.Port(port), true
+}
+
+// socketOwnerTTL is how long the sockets found by a scan of all processes are trusted. A socket not among them
+// is looked up in the processes found recently first, and a full scan is only made if the last one is older.
+const socketOwnerTTL = 10 * time.Second
+
+// maxRecentOwners is how many processes found recently are kept.
+const maxRecentOwners = 8
+
+type socketOwner struct {
+	pid int
+	fd  string
+}
+
+// ownerCache maps socket inodes to the processes holding them. Scanning the file descriptors of all processes is
+// costly, and connections mostly come from a few processes, so a miss is looked up in those first.
+type ownerCache struct {
+	sync.Mutex
+	owners  map[string]socketOwner
+	scanned time.Time
+	recent  []int
+}
+
+var owners = &ownerCache{owners: make(map[string]socketOwner)}
+
+// findSocketOwner finds the process holding the socket.
+func findSocketOwner(entry *socketEntry) (*Info, error) {
+	info := &Info{UID: entry.uid}
+	pid, found := owners.find(entry)
+	if !found {
+		return info, nil
+	}
+	info.PID = pid
+	dir := filepath.Join("/proc", strconv.Itoa(pid))
+	if path, err := os.Readlink(filepath.Join(dir, "exe")); err == nil {
+		info.Path = strings.TrimSuffix(path, " (deleted)")
+		info.Name = filepath.Base(info.Path)
+	} else if comm, err := os.ReadFile(filepath.Join(dir, "comm")); err == nil {
+		info.Name = strings.TrimSpace(string(comm))
+	}
+	return info, nil
+}
+
+func (c *ownerCache) find(entry *socketEntry) (int, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	target := "socket:[" + entry.inode + "]"
+	if owner, found := c.owners[entry.inode]; found {
+		// The process may have closed the socket since, and the inode been taken by another one.
+		if link, err := os.Readlink(filepath.Join("/proc", strconv.Itoa(owner.pid), "fd", owner.fd)); err == nil && link == target {
+			c.touch(owner.pid)
+			return owner.pid, true
+		}
+		delete(c.owners, entry.inode)
+	}
+
+	now := time.Now()
+	if now.Sub(c.scanned) < socketOwnerTTL {
+		for _, pid := range c.recent {
+			scanProcess(pid, c.owners)
+		}
+		if owner, found := c.owners[entry.inode]; found {
+			c.touch(owner.pid)
+			return owner.pid, true
+		}
+	}
+
+	c.owners = scanProcesses(entry.uid)
+	c.scanned = now
+	if owner, found := c.owners[entry.inode]; found {
+		c.touch(owner.pid)
+		return owner.pid, true
+	}
+	return 0, false
+}
+
+// touch puts the pid at the front of the processes found recently.
+func (c *ownerCache) touch(pid int) {
+	for i, p := range c.recent {
+		if p == pid {
+			copy(c.recent[1:i+1], c.recent[:i])
+			c.recent[0] = pid
+			return
+		}
+	}
+	if len(c.recent) < maxRecentOwners {
+		c.recent = append(c.recent, 0)
+	}
+	copy(c.recent[1:], c.recent)
+	c.recent[0] = pid
+}
+
+// scanProcesses returns the sockets held by the processes of the given user.
+func scanProcesses(uid uint32) map[string]socketOwner {
+	sockets := make(map[string]socketOwner)
+	procs, err := os.ReadDir("/proc")
+	if err != nil {
+		return sockets
+	}
+	for _, proc := range procs {
+		pid, err := strconv.Atoi(proc.Name())
+		if err != nil || !proc.IsDir() {
+			continue
+		}
+		if stat, err := os.Stat(filepath.Join("/proc", proc.Name())); err != nil {
+			continue
+		} else if sys, ok := stat.Sys().(*syscall.Stat_t); ok && sys.Uid != uid {
+			continue
+		}
+		scanProcess(pid, sockets)
+	}
+	return sockets
+}
+
+// scanProcess adds the sockets held by the process to the given map.
+func scanProcess(pid int, sockets map[string]socketOwner) {
+	dir := filepath.Join("/proc", strconv.Itoa(pid), "fd")
+	fds, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, fd := range fds {
+		link, err := os.Readlink(filepath.Join(dir, fd.Name()))
+		if err != nil || !strings.HasPrefix(link, "socket:[") {
+			continue
+		}
+		sockets[link[len("socket:["):len(link)-1]] = socketOwner{pid: pid, fd: fd.Name()}
+	}
+}
@@ -0,0 +1,89 @@
+//go:build linux
+// +build linux
+
+package process_test
+
+import (
+	gonet "net"
+	"os"
+	"testing"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/process"
+)
+
+func TestFindProcess(t *testing.T) {
+	listener, err := gonet.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	conn, err := gonet.Dial("tcp", listener.Addr().String())
+	common.Must(err)
+	defer conn.Close()
+
+	info, err := process.FindProcess(net.DestinationFromAddr(conn.LocalAddr()))
+	common.Must(err)
+	if info.PID != os.Getpid() {
+		t.Error("expected pid ", os.Getpid(), ", but got ", info.PID)
+	}
+	if info.UID != uint32(os.Getuid()) {
+		t.Error("expected uid ", os.Getuid(), ", but got ", info.UID)
+	}
+	exe, err := os.Executable()
+	common.Must(err)
+	if info.Path != exe {
+		t.Error("expected path ", exe, ", but got ", info.Path)
+	}
+}
+
+func TestFindProcessNewSockets(t *testing.T) {
+	listener, err := gonet.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	// Sockets opened after the first lookup are found as well, while the owners found before are cached.
+	for i := 0; i < 3; i++ {
+		conn, err := gonet.Dial("tcp", listener.Addr().String())
+		common.Must(err)
+		info, err := process.FindProcess(net.DestinationFromAddr(conn.LocalAddr()))
+		common.Must(err)
+		if info.PID != os.Getpid() {
+			t.Error("expected pid ", os.Getpid(), ", but got ", info.PID)
+		}
+		conn.Close()
+	}
+}
+
+func TestFindProcessNotFound(t *testing.T) {
+	listener, err := gonet.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	addr := listener.Addr()
+	listener.Close()
+
+	if _, err := process.FindProcess(net.DestinationFromAddr(addr)); err != process.ErrNotFound {
+		t.Error("expected ErrNotFound, but got ", err)
+	}
+}
+
+func TestFindProcessUnspecifiedAddress(t *testing.T) {
+	packetConn, err := gonet.ListenPacket("udp", "0.0.0.0:0")
+	common.Must(err)
+	defer packetConn.Close()
+	port := net.Port(packetConn.LocalAddr().(*gonet.UDPAddr).Port)
+
+	info, err := process.FindProcess(net.UDPDestination(net.LocalHostIP, port))
+	common.Must(err)
+	if info.PID != os.Getpid() {
+		t.Error("expected pid ", os.Getpid(), ", but got ", info.PID)
+	}
+
+	listener, err := gonet.Listen("tcp", "0.0.0.0:0")
+	common.Must(err)
+	defer listener.Close()
+	port = net.Port(listener.Addr().(*gonet.TCPAddr).Port)
+
+	if _, err := process.FindProcess(net.TCPDestination(net.LocalHostIP, port)); err != process.ErrNotFound {
+		t.Error("expected ErrNotFound for a TCP listener on the unspecified address, but got ", err)
+	}
+}
@@ -1,6 +1,8 @@
 package dns
 
 import (
+	"context"
+
 	"github.com/v2fly/v2ray-core/v4/common/errors"
 	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/common/serial"
@@ -38,6 +40,74 @@ type IPv6Lookup interface {
 	LookupIPv6(domain string) ([]net.IP, error)
 }
 
+// ContextIPLookup is an optional feature for querying IP addresses within a context, so that the query is traced
+// under the span of the context.
+//
+// v2ray:api:beta
+type ContextIPLookup interface {
+	// LookupIPContext returns IP addresses for the given domain, of the families enabled in the option.
+	LookupIPContext(ctx context.Context, domain string, option IPOption) ([]net.IP, error)
+}
+
+// CNAMELookup is an optional feature for querying the canonical names of domains.
+//
+// v2ray:api:beta
+type CNAMELookup interface {
+	// LookupCNAME returns the domain that the given domain is an alias of, or an empty string if it is not an alias.
+	LookupCNAME(domain string) string
+}
+
+// PTRLookup is an optional feature for querying the domains of IP addresses.
+//
+// v2ray:api:beta
+type PTRLookup interface {
+	// LookupPTR returns the domains that are known to resolve to the given IP.
+	LookupPTR(ip net.IP) []string
+}
+
+// TXTLookup is an optional feature for querying the text records of domains.
+//
+// v2ray:api:beta
+type TXTLookup interface {
+	// LookupTXT returns the text records of the given domain, with the strings of each record concatenated.
+	LookupTXT(ctx context.Context, domain string) ([]string, error)
+}
+
+// DNSSECStatus is the result of DNSSEC validation of an answer.
+type DNSSECStatus byte
+
+const (
+	// DNSSECIndeterminate means the answer is not validated, as validation is disabled or not supported.
+	DNSSECIndeterminate DNSSECStatus = iota
+	// DNSSECSecure means the answer is signed, and the signatures chain up to a trust anchor.
+	DNSSECSecure
+	// DNSSECInsecure means the answer is proven to come from an unsigned zone.
+	DNSSECInsecure
+	// DNSSECBogus means the answer fails validation.
+	DNSSECBogus
+)
+
+func (s DNSSECStatus) String() string {
+	switch s {
+	case DNSSECSecure:
+		return "secure"
+	case DNSSECInsecure:
+		return "insecure"
+	case DNSSECBogus:
+		return "bogus"
+	default:
+		return "indeterminate"
+	}
+}
+
+// DNSSECLookup is an optional feature for querying the DNSSEC validation results of answers.
+//
+// v2ray:api:beta
+type DNSSECLookup interface {
+	// LookupDNSSEC returns the validation status of the answer of the domain that was last resolved.
+	LookupDNSSEC(domain string) DNSSECStatus
+}
+
 // ClientWithIPOption is an optional feature for querying DNS information.
 //
 // v2ray:api:beta
@@ -64,16 +134,30 @@ var ErrEmptyResponse = errors.New("empty response")
 
 type RCodeError uint16
 
+// RCodeBogus is the RCodeError of answers failing DNSSEC validation. It is beyond the range of DNS response codes,
+// so it is told apart from failures of upstream servers.
+const RCodeBogus RCodeError = 0x1000
+
+// rcodeServerFailure is SERVFAIL, as bogus answers are answered to DNS clients.
+const rcodeServerFailure = 2
+
 func (e RCodeError) Error() string {
+	if e == RCodeBogus {
+		return "rcode: DNSSEC bogus"
+	}
 	return serial.Concat("rcode: ", uint16(e))
 }
 
+// RCodeFromError returns the DNS response code of the error. Errors of bogus answers are SERVFAIL.
 func RCodeFromError(err error) uint16 {
 	if err == nil {
 		return 0
 	}
 	cause := errors.Cause(err)
 	if r, ok := cause.(RCodeError); ok {
+		if r == RCodeBogus {
+			return rcodeServerFailure
+		}
 		return uint16(r)
 	}
 	return 0
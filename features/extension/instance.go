@@ -2,14 +2,51 @@ package extension
 
 import (
 	"context"
+
 	"github.com/v2fly/v2ray-core/v4/features"
 )
 
+// InstanceState is the state of an instance managed by InstanceManagement.
+type InstanceState int
+
+const (
+	// InstanceCreated means the instance is added, or stopped, and not running.
+	InstanceCreated InstanceState = iota
+	// InstanceRunning means the instance is started.
+	InstanceRunning
+	// InstanceFailed means the last attempt to create or start the instance failed.
+	InstanceFailed
+)
+
+func (s InstanceState) String() string {
+	switch s {
+	case InstanceCreated:
+		return "created"
+	case InstanceRunning:
+		return "running"
+	case InstanceFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// InstanceStatus reports the state of an instance.
+type InstanceStatus struct {
+	Name  string
+	State InstanceState
+	// LastError is the error of the last failed attempt, nil if there is none.
+	LastError error
+	// RestartCount is the number of attempts made to start the instance again after failures.
+	RestartCount int
+}
+
 // InstanceManagement : unstable
 type InstanceManagement interface {
 	features.Feature
 
 	ListInstance(ctx context.Context) ([]string, error)
+	ListInstanceStatus(ctx context.Context) ([]InstanceStatus, error)
 	AddInstance(ctx context.Context, name string, config []byte, configType string) error
 	StartInstance(ctx context.Context, name string) error
 	StopInstance(ctx context.Context, name string) error
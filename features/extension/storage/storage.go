@@ -2,8 +2,14 @@ package storage
 
 import (
 	"context"
+
+	"github.com/v2fly/v2ray-core/v4/common/errors"
+	"github.com/v2fly/v2ray-core/v4/features"
 )
 
+// ErrNotFound indicates that the requested key does not exist in the current scope.
+var ErrNotFound = errors.New("key not found")
+
 type ScopedPersistentStorage interface {
 	ScopedPersistentStorageEngine()
 
@@ -15,11 +21,21 @@ type ScopedPersistentStorage interface {
 	NarrowScope(ctx context.Context, key []byte) (ScopedPersistentStorage, error)
 }
 
+// ScopedPersistentStorageService is a feature that provides the root scope of persistent storage.
+type ScopedPersistentStorageService interface {
+	ScopedPersistentStorage
+	features.Feature
+}
+
+func ScopedPersistentStorageServiceType() interface{} {
+	return (*ScopedPersistentStorageService)(nil)
+}
+
 type ScopedTransientStorage interface {
 	ScopedTransientStorage()
 	Put(ctx context.Context, key []byte, value interface{}) error
 	Get(ctx context.Context, key []byte) (interface{}, error)
 	List(ctx context.Context, keyPrefix []byte) ([][]byte, error)
 	Clear(ctx context.Context)
-	NarrowScope(ctx context.Context, key []byte) (ScopedPersistentStorage, error)
+	NarrowScope(ctx context.Context, key []byte) (ScopedTransientStorage, error)
 }
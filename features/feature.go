@@ -21,3 +21,12 @@ type TaggedFeatures interface {
 	GetFeaturesByTag(tag string) (Feature, error)
 	common.Runnable
 }
+
+// Reloadable is a feature that can adopt the settings of a freshly created feature of the same kind in place,
+// so that its users keep working with the same object.
+type Reloadable interface {
+	Feature
+
+	// ReplaceWith makes the feature behave like the given one from now on. The given feature is never started.
+	ReplaceWith(feature Feature) error
+}
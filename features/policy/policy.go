@@ -5,7 +5,10 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/common/platform"
+	"github.com/v2fly/v2ray-core/v4/common/protocol"
 	"github.com/v2fly/v2ray-core/v4/features"
 )
 
@@ -35,6 +38,22 @@ type Buffer struct {
 	PerConnection int32
 }
 
+// Bandwidth contains limits of throughput, in bytes per second. 0 for unlimited.
+type Bandwidth struct {
+	// Limit of uplink traffic, i.e., from the client to the target.
+	Uplink int64
+	// Limit of downlink traffic, i.e., from the target to the client.
+	Downlink int64
+}
+
+// ConnectionLimit contains limits of simultaneous connections of a user. 0 for unlimited.
+type ConnectionLimit struct {
+	// Connections of the user.
+	PerUser uint32
+	// Distinct source IPs the user has connections from.
+	IPsPerUser uint32
+}
+
 // SystemStats contains stat policy settings on system level.
 type SystemStats struct {
 	// Whether or not to enable stat counter for uplink traffic in inbound handlers.
@@ -51,13 +70,17 @@ type SystemStats struct {
 type System struct {
 	Stats  SystemStats
 	Buffer Buffer
+	// Limit of simultaneous connections from each source IP. 0 for unlimited.
+	ConnectionsPerIP uint32
 }
 
 // Session is session based settings for controlling V2Ray requests. It contains various settings (or limits) that may differ for different users in the context.
 type Session struct {
-	Timeouts Timeout // Timeout settings
-	Stats    Stats
-	Buffer   Buffer
+	Timeouts   Timeout // Timeout settings
+	Stats      Stats
+	Buffer     Buffer
+	Bandwidth  Bandwidth
+	Connection ConnectionLimit
 }
 
 // Manager is a feature that provides Policy for the given user by its id or level.
@@ -73,6 +96,44 @@ type Manager interface {
 	ForSystem() System
 }
 
+// RateLimiters are the limiters of uplink and downlink traffic. Each of them is nil if the traffic is not limited.
+type RateLimiters struct {
+	Uplink   *buf.RateLimiter
+	Downlink *buf.RateLimiter
+}
+
+// BandwidthManager is a Manager that also limits the bandwidth of users and handlers. Limiters are shared by all
+// connections of the same user or handler, and adjusting the limits takes effect on them at once.
+type BandwidthManager interface {
+	Manager
+
+	// ForUser returns the limiters of the user, by the bandwidth policy of its level.
+	ForUser(user *protocol.MemoryUser) RateLimiters
+	// ForInbound returns the limiters of the inbound handler with the given tag.
+	ForInbound(tag string) RateLimiters
+	// ForOutbound returns the limiters of the outbound handler with the given tag.
+	ForOutbound(tag string) RateLimiters
+	// ReleaseUser discards the limiters of the user with the given email, once the user is removed.
+	ReleaseUser(email string)
+}
+
+// Connection is an inbound connection counted against the connection limits.
+type Connection interface {
+	// SetUser counts the connection for the user once it is authenticated. It returns an error if a limit of the user
+	// is exceeded. The connection is counted for the first user only.
+	SetUser(user *protocol.MemoryUser) error
+	// Close stops counting the connection.
+	Close() error
+}
+
+// ConnectionManager is a Manager that also limits simultaneous connections of users and source IPs.
+type ConnectionManager interface {
+	Manager
+
+	// NewConnection counts a connection from the source. It returns an error if the limit of the source is exceeded.
+	NewConnection(source net.Destination) (Connection, error)
+}
+
 // ManagerType returns the type of Manager interface. Can be used to implement common.HasType.
 //
 // v2ray:api:stable
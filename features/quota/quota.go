@@ -0,0 +1,27 @@
+package quota
+
+import (
+	"context"
+
+	"github.com/v2fly/v2ray-core/v4/features"
+)
+
+// Usage accounts the traffic of a session against the quotas applying to it.
+type Usage interface {
+	// Add records n bytes of traffic. It returns an error if the session has to be cut because a quota is exceeded.
+	Add(n int64) error
+}
+
+// Manager is the feature that enforces traffic quotas.
+type Manager interface {
+	features.Feature
+
+	// Track returns the Usage of the session in the context, or an error if the session is rejected because it is
+	// over a quota. The Usage is nil if no quota applies to the session.
+	Track(ctx context.Context) (Usage, error)
+}
+
+// ManagerType returns the type of Manager interface. Can be used to implement common.HasType.
+func ManagerType() interface{} {
+	return (*Manager)(nil)
+}
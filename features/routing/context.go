@@ -2,6 +2,7 @@ package routing
 
 import (
 	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/process"
 )
 
 // Context is a feature to store connection information for routing.
@@ -40,4 +41,7 @@ type Context interface {
 
 	// GetSkipDNSResolve returns a flag switch for weather skip dns resolve during route pick.
 	GetSkipDNSResolve() bool
+
+	// GetSourceProcess returns the local process that opened the connection, or nil if it is not found.
+	GetSourceProcess() *process.Info
 }
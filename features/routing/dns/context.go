@@ -3,9 +3,12 @@ package dns
 //go:generate go run github.com/v2fly/v2ray-core/v4/common/errors/errorgen
 
 import (
+	"context"
+
 	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/features/dns"
 	"github.com/v2fly/v2ray-core/v4/features/routing"
+	"github.com/v2fly/v2ray-core/v4/features/tracing"
 )
 
 // ResolvableContext is an implementation of routing.Context, with domain resolving capability.
@@ -54,7 +57,15 @@ func (ctx *ResolvableContext) GetTargetIPs() []net.IP {
 			}
 		}
 
-		ips, err := lookupFunc(domain)
+		var ips []net.IP
+		var err error
+		if lookup, ok := ctx.dnsClient.(dns.ContextIPLookup); ok && ctx.GetSpan() != nil {
+			option := *ipOption
+			option.FakeEnable = false
+			ips, err = lookup.LookupIPContext(tracing.ContextWithSpan(context.Background(), ctx.GetSpan()), domain, option)
+		} else {
+			ips, err = lookupFunc(domain)
+		}
 		if err == nil {
 			ctx.resolvedIPs = ips
 			return ips
@@ -65,8 +76,28 @@ func (ctx *ResolvableContext) GetTargetIPs() []net.IP {
 	return nil
 }
 
+// GetSpan implements tracing.SpanCarrier. Lookups are traced under the span of the original context.
+func (ctx *ResolvableContext) GetSpan() tracing.Span {
+	return tracing.SpanOf(ctx.Context)
+}
+
 // ContextWithDNSClient creates a new routing context with domain resolving capability.
 // Resolved domain IPs can be retrieved by GetTargetIPs().
 func ContextWithDNSClient(ctx routing.Context, client dns.Client) routing.Context {
 	return &ResolvableContext{Context: ctx, dnsClient: client}
 }
+
+// GetTargetDNSSEC returns the DNSSEC validation status of the answer the target domain is resolved with. It resolves
+// the target domain if it has not been.
+func (ctx *ResolvableContext) GetTargetDNSSEC() dns.DNSSECStatus {
+	domain := ctx.GetTargetDomain()
+	if len(domain) == 0 {
+		return dns.DNSSECIndeterminate
+	}
+	lookup, ok := ctx.dnsClient.(dns.DNSSECLookup)
+	if !ok {
+		return dns.DNSSECIndeterminate
+	}
+	ctx.GetTargetIPs()
+	return lookup.LookupDNSSEC(domain)
+}
@@ -1,11 +1,15 @@
 package session
 
+//go:generate go run github.com/v2fly/v2ray-core/v4/common/errors/errorgen
+
 import (
 	"context"
 
 	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/process"
 	"github.com/v2fly/v2ray-core/v4/common/session"
 	"github.com/v2fly/v2ray-core/v4/features/routing"
+	"github.com/v2fly/v2ray-core/v4/features/tracing"
 )
 
 // Context is an implementation of routing.Context, which is a wrapper of context.context with session info.
@@ -13,6 +17,10 @@ type Context struct {
 	Inbound  *session.Inbound
 	Outbound *session.Outbound
 	Content  *session.Content
+	Span     tracing.Span
+
+	process         *process.Info
+	processResolved bool
 }
 
 // GetInboundTag implements routing.Context.
@@ -117,11 +125,39 @@ func (ctx *Context) GetSkipDNSResolve() bool {
 	return ctx.Content.SkipDNSResolve
 }
 
+// GetSourceProcess implements routing.Context. The process is looked up on first call only.
+func (ctx *Context) GetSourceProcess() *process.Info {
+	if ctx.processResolved {
+		return ctx.process
+	}
+	ctx.processResolved = true
+	if ctx.Inbound == nil || !ctx.Inbound.Source.IsValid() {
+		return nil
+	}
+	source := ctx.Inbound.Source
+	if source.Network == net.Network_Unknown && ctx.Outbound != nil {
+		source.Network = ctx.Outbound.Target.Network
+	}
+	info, err := process.FindProcess(source)
+	if err != nil {
+		newError("failed to find process of ", source).Base(err).AtDebug().WriteToLog()
+		return nil
+	}
+	ctx.process = info
+	return info
+}
+
+// GetSpan implements tracing.SpanCarrier.
+func (ctx *Context) GetSpan() tracing.Span {
+	return ctx.Span
+}
+
 // AsRoutingContext creates a context from context.context with session info.
 func AsRoutingContext(ctx context.Context) routing.Context {
 	return &Context{
 		Inbound:  session.InboundFromContext(ctx),
 		Outbound: session.OutboundFromContext(ctx),
 		Content:  session.ContentFromContext(ctx),
+		Span:     tracing.SpanFromContext(ctx),
 	}
 }
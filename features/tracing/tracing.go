@@ -0,0 +1,107 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/v2fly/v2ray-core/v4/features"
+)
+
+// Attribute is a key-value pair describing a span. The value is a string, an int64 or a bool.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns an Attribute of a string value.
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an Attribute of an integer value.
+func Int(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool returns an Attribute of a bool value.
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span is an operation in the lifecycle of a session.
+//
+// v2ray:api:beta
+type Span interface {
+	// SetAttributes adds attributes to the span.
+	SetAttributes(attributes ...Attribute)
+	// RecordError marks the span failed with the error. Nil errors are ignored.
+	RecordError(err error)
+	// End ends the span. Calls after the first are ignored.
+	End()
+	// StartChild starts a span under this span.
+	StartChild(name string, attributes ...Attribute) Span
+}
+
+// Tracer is the feature that traces sessions.
+//
+// v2ray:api:beta
+type Tracer interface {
+	features.Feature
+
+	// StartSession starts the root span of the session in the context. It returns nil if the session is not traced.
+	StartSession(ctx context.Context, name string, attributes ...Attribute) Span
+}
+
+// TracerType returns the type of Tracer interface. Can be used to implement common.HasType.
+//
+// v2ray:api:beta
+func TracerType() interface{} {
+	return (*Tracer)(nil)
+}
+
+// SpanCarrier is implemented by values carrying a span, such as routing contexts.
+type SpanCarrier interface {
+	GetSpan() Span
+}
+
+type spanKey int
+
+const currentSpanKey spanKey = 0
+
+// ContextWithSpan returns a context carrying the span, under which spans are started with Start.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, currentSpanKey, span)
+}
+
+// SpanFromContext returns the span in the context, or nil if the context is not traced.
+func SpanFromContext(ctx context.Context) Span {
+	if span, ok := ctx.Value(currentSpanKey).(Span); ok {
+		return span
+	}
+	return nil
+}
+
+// Start starts a span under the span in the context, and returns a context carrying the new span. If the context is
+// not traced, the context is returned as is, with a span doing nothing.
+func Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span) {
+	parent := SpanFromContext(ctx)
+	if parent == nil {
+		return ctx, noopSpan{}
+	}
+	span := parent.StartChild(name, attributes...)
+	return ContextWithSpan(ctx, span), span
+}
+
+// SpanOf returns the span of the carrier, or nil if it is not a SpanCarrier or it has no span.
+func SpanOf(carrier interface{}) Span {
+	if c, ok := carrier.(SpanCarrier); ok {
+		return c.GetSpan()
+	}
+	return nil
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute)           {}
+func (noopSpan) RecordError(error)                    {}
+func (noopSpan) End()                                 {}
+func (noopSpan) StartChild(string, ...Attribute) Span { return noopSpan{} }
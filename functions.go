@@ -5,6 +5,8 @@ import (
 	"context"
 
 	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/environment"
+	"github.com/v2fly/v2ray-core/v4/common/environment/envctx"
 	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/features/routing"
 	"github.com/v2fly/v2ray-core/v4/transport/internet/udp"
@@ -19,6 +21,13 @@ func CreateObject(v *Instance, config interface{}) (interface{}, error) {
 	return common.CreateObject(ctx, config)
 }
 
+// CreateObjectWithEnvironment creates a new object like CreateObject, with the given app environment in its context.
+// The environment can be retrieved from the context by envctx.EnvironmentFromContext.
+func CreateObjectWithEnvironment(v *Instance, config interface{}, env environment.AppEnvironment) (interface{}, error) {
+	ctx := envctx.ContextWithEnvironment(toContext(v.ctx, v), env)
+	return common.CreateObject(ctx, config)
+}
+
 // StartInstance starts a new V2Ray instance with given serialized config.
 // By default V2Ray only support config in protobuf format, i.e., configFormat = "protobuf". Caller need to load other packages to add JSON support.
 //
@@ -201,6 +201,31 @@ func toCidrList(ctx context.Context, ips cfgcommon.StringList) ([]*routercommon.
 	return geoipList, nil
 }
 
+// TimeConfig is the time condition of a routing rule, like
+// {"range": "01:00-07:00", "weekday": "mon-fri", "timezone": "Asia/Shanghai"}.
+type TimeConfig struct {
+	Range    *cfgcommon.StringList `json:"range"`
+	Weekday  *cfgcommon.StringList `json:"weekday"`
+	TimeZone string                `json:"timezone"`
+}
+
+// Build implements Buildable.
+func (c *TimeConfig) Build() (*router.TimeCondition, error) {
+	cond := &router.TimeCondition{
+		TimeZone: c.TimeZone,
+	}
+	if c.Range != nil {
+		cond.TimeRange = append(cond.TimeRange, *c.Range...)
+	}
+	if c.Weekday != nil {
+		cond.Weekday = append(cond.Weekday, *c.Weekday...)
+	}
+	if _, err := router.NewTimeMatcher(cond); err != nil {
+		return nil, err
+	}
+	return cond, nil
+}
+
 func parseFieldRule(ctx context.Context, msg json.RawMessage) (*router.RoutingRule, error) {
 	type RawFieldRule struct {
 		RouterRule
@@ -215,6 +240,10 @@ func parseFieldRule(ctx context.Context, msg json.RawMessage) (*router.RoutingRu
 		InboundTag *cfgcommon.StringList  `json:"inboundTag"`
 		Protocols  *cfgcommon.StringList  `json:"protocol"`
 		Attributes string                 `json:"attrs"`
+		Time       *TimeConfig            `json:"time"`
+		Process    *cfgcommon.StringList  `json:"process"`
+		UID        []uint32               `json:"uid"`
+		DNSSEC     *cfgcommon.StringList  `json:"dnssec"`
 	}
 	rawFieldRule := new(RawFieldRule)
 	err := json.Unmarshal(msg, rawFieldRule)
@@ -310,6 +339,29 @@ func parseFieldRule(ctx context.Context, msg json.RawMessage) (*router.RoutingRu
 		rule.Attributes = rawFieldRule.Attributes
 	}
 
+	if rawFieldRule.Process != nil {
+		for _, s := range *rawFieldRule.Process {
+			rule.Process = append(rule.Process, s)
+		}
+	}
+
+	rule.Uid = rawFieldRule.UID
+
+	if rawFieldRule.DNSSEC != nil {
+		rule.Dnssec = *rawFieldRule.DNSSEC
+		if _, err := router.NewDNSSECMatcher(rule.Dnssec); err != nil {
+			return nil, err
+		}
+	}
+
+	if rawFieldRule.Time != nil {
+		cond, err := rawFieldRule.Time.Build()
+		if err != nil {
+			return nil, newError("invalid time condition").Base(err)
+		}
+		rule.Time = cond
+	}
+
 	return rule, nil
 }
 
@@ -13,8 +13,10 @@ import (
 	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/common/platform"
 	"github.com/v2fly/v2ray-core/v4/infra/conf/cfgcommon"
+	"github.com/v2fly/v2ray-core/v4/infra/conf/cfgcommon/tlscfg"
 	"github.com/v2fly/v2ray-core/v4/infra/conf/geodata"
 	rule2 "github.com/v2fly/v2ray-core/v4/infra/conf/rule"
+	"github.com/v2fly/v2ray-core/v4/transport/internet/tls"
 )
 
 type NameServerConfig struct {
@@ -22,12 +24,39 @@ type NameServerConfig struct {
 	ClientIP     *cfgcommon.Address
 	Port         uint16
 	SkipFallback bool
-	Domains      []string
+	Domains      []*NameServerDomainConfig
 	ExpectIPs    cfgcommon.StringList
+	TLSSettings  *tlscfg.TLSConfig
 
 	cfgctx context.Context
 }
 
+// NameServerDomainConfig is a domain rule of a name server, which overrides the client IP and query strategy of
+// queries for domains matching it.
+type NameServerDomainConfig struct {
+	Rule          string             `json:"rule"`
+	ClientIP      *cfgcommon.Address `json:"clientIp"`
+	QueryStrategy string             `json:"queryStrategy"`
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.UnmarshalJSON
+func (c *NameServerDomainConfig) UnmarshalJSON(data []byte) error {
+	var rule string
+	if err := json.Unmarshal(data, &rule); err == nil {
+		c.Rule = rule
+		return nil
+	}
+
+	type config NameServerDomainConfig
+	if err := json.Unmarshal(data, (*config)(c)); err != nil {
+		return newError("failed to parse domain rule: ", string(data)).Base(err)
+	}
+	if c.Rule == "" {
+		return newError("domain rule is not specified: ", string(data))
+	}
+	return nil
+}
+
 func (c *NameServerConfig) UnmarshalJSON(data []byte) error {
 	var address cfgcommon.Address
 	if err := json.Unmarshal(data, &address); err == nil {
@@ -36,12 +65,13 @@ func (c *NameServerConfig) UnmarshalJSON(data []byte) error {
 	}
 
 	var advanced struct {
-		Address      *cfgcommon.Address   `json:"address"`
-		ClientIP     *cfgcommon.Address   `json:"clientIp"`
-		Port         uint16               `json:"port"`
-		SkipFallback bool                 `json:"skipFallback"`
-		Domains      []string             `json:"domains"`
-		ExpectIPs    cfgcommon.StringList `json:"expectIps"`
+		Address      *cfgcommon.Address        `json:"address"`
+		ClientIP     *cfgcommon.Address        `json:"clientIp"`
+		Port         uint16                    `json:"port"`
+		SkipFallback bool                      `json:"skipFallback"`
+		Domains      []*NameServerDomainConfig `json:"domains"`
+		ExpectIPs    cfgcommon.StringList      `json:"expectIps"`
+		TLSSettings  *tlscfg.TLSConfig         `json:"tlsSettings"`
 	}
 	if err := json.Unmarshal(data, &advanced); err == nil {
 		c.Address = advanced.Address
@@ -50,6 +80,7 @@ func (c *NameServerConfig) UnmarshalJSON(data []byte) error {
 		c.SkipFallback = advanced.SkipFallback
 		c.Domains = advanced.Domains
 		c.ExpectIPs = advanced.ExpectIPs
+		c.TLSSettings = advanced.TLSSettings
 		return nil
 	}
 
@@ -86,7 +117,8 @@ func (c *NameServerConfig) Build() (*dns.NameServer, error) {
 	var domains []*dns.NameServer_PriorityDomain
 	var originalRules []*dns.NameServer_OriginalRule
 
-	for _, rule := range c.Domains {
+	for _, domain := range c.Domains {
+		rule := domain.Rule
 		parsedDomain, err := rule2.ParseDomainRule(cfgctx, rule)
 		if err != nil {
 			return nil, newError("invalid domain rule: ", rule).Base(err)
@@ -98,10 +130,18 @@ func (c *NameServerConfig) Build() (*dns.NameServer, error) {
 				Domain: pd.Value,
 			})
 		}
-		originalRules = append(originalRules, &dns.NameServer_OriginalRule{
-			Rule: rule,
-			Size: uint32(len(parsedDomain)),
-		})
+		originalRule := &dns.NameServer_OriginalRule{
+			Rule:          rule,
+			Size:          uint32(len(parsedDomain)),
+			QueryStrategy: parseQueryStrategy(domain.QueryStrategy),
+		}
+		if domain.ClientIP != nil {
+			if !domain.ClientIP.Family().IsIP() {
+				return nil, newError("not an IP address:", domain.ClientIP.String())
+			}
+			originalRule.ClientIp = []byte(domain.ClientIP.IP())
+		}
+		originalRules = append(originalRules, originalRule)
 	}
 
 	geoipList, err := rule2.ToCidrList(cfgctx, c.ExpectIPs)
@@ -117,6 +157,15 @@ func (c *NameServerConfig) Build() (*dns.NameServer, error) {
 		myClientIP = []byte(c.ClientIP.IP())
 	}
 
+	var tlsSettings *tls.Config
+	if c.TLSSettings != nil {
+		ts, err := c.TLSSettings.Build()
+		if err != nil {
+			return nil, newError("invalid TLS settings").Base(err)
+		}
+		tlsSettings = ts.(*tls.Config)
+	}
+
 	return &dns.NameServer{
 		Address: &net.Endpoint{
 			Network: net.Network_UDP,
@@ -128,9 +177,21 @@ func (c *NameServerConfig) Build() (*dns.NameServer, error) {
 		PrioritizedDomain: domains,
 		Geoip:             geoipList,
 		OriginalRules:     originalRules,
+		TlsSettings:       tlsSettings,
 	}, nil
 }
 
+func parseQueryStrategy(s string) dns.QueryStrategy {
+	switch strings.ToLower(s) {
+	case "useip4", "useipv4", "use_ip4", "use_ipv4", "use_ip_v4", "use-ip4", "use-ipv4", "use-ip-v4":
+		return dns.QueryStrategy_USE_IP4
+	case "useip6", "useipv6", "use_ip6", "use_ipv6", "use_ip_v6", "use-ip6", "use-ipv6", "use-ip-v6":
+		return dns.QueryStrategy_USE_IP6
+	default:
+		return dns.QueryStrategy_USE_IP
+	}
+}
+
 var typeMap = map[routercommon.Domain_Type]dns.DomainMatchingType{
 	routercommon.Domain_Full:       dns.DomainMatchingType_Full,
 	routercommon.Domain_RootDomain: dns.DomainMatchingType_Subdomain,
@@ -148,6 +209,13 @@ type DNSConfig struct {
 	DisableCache           bool                    `json:"disableCache"`
 	DisableFallback        bool                    `json:"disableFallback"`
 	DisableFallbackIfMatch bool                    `json:"disableFallbackIfMatch"`
+	ServeStale             uint32                  `json:"serveStale"`
+	Prefetch               bool                    `json:"prefetch"`
+	MinTTL                 uint32                  `json:"minTtl"`
+	MaxTTL                 uint32                  `json:"maxTtl"`
+	PersistCache           bool                    `json:"persistCache"`
+	DNSSEC                 bool                    `json:"dnssec"`
+	TrustAnchors           []string                `json:"trustAnchors"`
 	cfgctx context.Context
 }
 
@@ -228,6 +296,13 @@ func (c *DNSConfig) Build() (*dns.Config, error) {
 		DisableCache:           c.DisableCache,
 		DisableFallback:        c.DisableFallback,
 		DisableFallbackIfMatch: c.DisableFallbackIfMatch,
+		ServeStale:             c.ServeStale,
+		Prefetch:               c.Prefetch,
+		MinTtl:                 c.MinTTL,
+		MaxTtl:                 c.MaxTTL,
+		PersistCache:           c.PersistCache,
+		Dnssec:                 c.DNSSEC,
+		TrustAnchor:            c.TrustAnchors,
 	}
 
 	if c.ClientIP != nil {
@@ -237,15 +312,7 @@ func (c *DNSConfig) Build() (*dns.Config, error) {
 		config.ClientIp = []byte(c.ClientIP.IP())
 	}
 
-	config.QueryStrategy = dns.QueryStrategy_USE_IP
-	switch strings.ToLower(c.QueryStrategy) {
-	case "useip", "use_ip", "use-ip":
-		config.QueryStrategy = dns.QueryStrategy_USE_IP
-	case "useip4", "useipv4", "use_ip4", "use_ipv4", "use_ip_v4", "use-ip4", "use-ipv4", "use-ip-v4":
-		config.QueryStrategy = dns.QueryStrategy_USE_IP4
-	case "useip6", "useipv6", "use_ip6", "use_ipv6", "use_ip_v6", "use-ip6", "use-ipv6", "use-ip-v6":
-		config.QueryStrategy = dns.QueryStrategy_USE_IP6
-	}
+	config.QueryStrategy = parseQueryStrategy(c.QueryStrategy)
 
 	for _, server := range c.Servers {
 		server.cfgctx = c.cfgctx
@@ -81,7 +81,11 @@ func TestDNSConfigParsing(t *testing.T) {
 				"clientIp": "10.0.0.1",
 				"queryStrategy": "UseIPv4",
 				"disableCache": true,
-				"disableFallback": true
+				"disableFallback": true,
+				"serveStale": 86400,
+				"prefetch": true,
+				"minTtl": 60,
+				"maxTtl": 3600
 			}`,
 			Parser: parserCreator(),
 			Output: &dns.Config{
@@ -148,6 +152,10 @@ func TestDNSConfigParsing(t *testing.T) {
 				QueryStrategy:   dns.QueryStrategy_USE_IP4,
 				DisableCache:    true,
 				DisableFallback: true,
+				ServeStale:      86400,
+				Prefetch:        true,
+				MinTtl:          60,
+				MaxTtl:          3600,
 			},
 		},
 	})
@@ -15,9 +15,55 @@ func DefaultLogConfig() *log.Config {
 }
 
 type LogConfig struct {
-	AccessLog string `json:"access"`
-	ErrorLog  string `json:"error"`
-	LogLevel  string `json:"loglevel"`
+	AccessLog string             `json:"access"`
+	ErrorLog  string             `json:"error"`
+	LogLevel  string             `json:"loglevel"`
+	Format    string             `json:"format"`
+	Rotation  *LogRotationConfig `json:"rotation"`
+	Tag       string             `json:"tag"`
+}
+
+// LogRotationConfig is the rotation of log files.
+type LogRotationConfig struct {
+	MaxSize    uint32 `json:"maxSize"`
+	Interval   uint32 `json:"interval"`
+	MaxBackups uint32 `json:"maxBackups"`
+	MaxAge     uint32 `json:"maxAge"`
+	Compress   bool   `json:"compress"`
+}
+
+func (c *LogRotationConfig) Build() *log.LogRotation {
+	if c == nil {
+		return nil
+	}
+	return &log.LogRotation{
+		MaxSize:    c.MaxSize,
+		Interval:   c.Interval,
+		MaxBackups: c.MaxBackups,
+		MaxAge:     c.MaxAge,
+		Compress:   c.Compress,
+	}
+}
+
+// buildDestination sets the type and path of the log from its destination, which is "none", a file path, or a
+// system log as "syslog" or "journald", optionally followed by the path of its socket as "syslog:/dev/log".
+func (v *LogConfig) buildDestination(spec *log.LogSpecification, destination string) {
+	switch {
+	case destination == "none":
+		spec.Type = log.LogType_None
+	case destination == "syslog" || strings.HasPrefix(destination, "syslog:"):
+		spec.Type = log.LogType_Syslog
+		spec.Path = strings.TrimPrefix(strings.TrimPrefix(destination, "syslog"), ":")
+		spec.Tag = v.Tag
+	case destination == "journald" || strings.HasPrefix(destination, "journald:"):
+		spec.Type = log.LogType_Journald
+		spec.Path = strings.TrimPrefix(strings.TrimPrefix(destination, "journald"), ":")
+		spec.Tag = v.Tag
+	case len(destination) > 0:
+		spec.Path = destination
+		spec.Type = log.LogType_File
+		spec.Rotation = v.Rotation.Build()
+	}
 }
 
 func (v *LogConfig) Build() *log.Config {
@@ -29,17 +75,12 @@ func (v *LogConfig) Build() *log.Config {
 		Error:  &log.LogSpecification{Type: log.LogType_Console},
 	}
 
-	if v.AccessLog == "none" {
-		config.Access.Type = log.LogType_None
-	} else if len(v.AccessLog) > 0 {
-		config.Access.Path = v.AccessLog
-		config.Access.Type = log.LogType_File
-	}
-	if v.ErrorLog == "none" {
-		config.Error.Type = log.LogType_None
-	} else if len(v.ErrorLog) > 0 {
-		config.Error.Path = v.ErrorLog
-		config.Error.Type = log.LogType_File
+	v.buildDestination(config.Access, v.AccessLog)
+	v.buildDestination(config.Error, v.ErrorLog)
+
+	if strings.EqualFold(v.Format, "json") {
+		config.Access.Format = log.LogFormat_JSON
+		config.Error.Format = log.LogFormat_JSON
 	}
 
 	level := strings.ToLower(v.LogLevel)
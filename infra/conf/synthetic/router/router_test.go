@@ -69,6 +69,19 @@ func TestRouterConfig(t *testing.T) {
 							"type": "field",
 							"port": 123,
 							"outboundTag": "test"
+						},{
+							"type": "field",
+							"time": {
+								"range": "01:00-07:00",
+								"weekday": ["sat", "sun"],
+								"timezone": "+08:00"
+							},
+							"outboundTag": "cheap"
+						},{
+							"type": "field",
+							"process": ["firefox", "/usr/bin/apt"],
+							"uid": [0],
+							"outboundTag": "direct"
 						}
 					]
 				},
@@ -211,6 +224,23 @@ func TestRouterConfig(t *testing.T) {
 							Tag: "test",
 						},
 					},
+					{
+						Time: &router.TimeCondition{
+							TimeRange: []string{"01:00-07:00"},
+							Weekday:   []string{"sat", "sun"},
+							TimeZone:  "+08:00",
+						},
+						TargetTag: &router.RoutingRule_Tag{
+							Tag: "cheap",
+						},
+					},
+					{
+						Process: []string{"firefox", "/usr/bin/apt"},
+						Uid:     []uint32{0},
+						TargetTag: &router.RoutingRule_Tag{
+							Tag: "direct",
+						},
+					},
 				},
 			},
 		},
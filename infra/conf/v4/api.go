@@ -8,9 +8,12 @@ import (
 	"github.com/jhump/protoreflect/dynamic"
 
 	"github.com/v2fly/v2ray-core/v4/app/commander"
+	instmanservice "github.com/v2fly/v2ray-core/v4/app/instman/command"
 	loggerservice "github.com/v2fly/v2ray-core/v4/app/log/command"
 	observatoryservice "github.com/v2fly/v2ray-core/v4/app/observatory/command"
+	policyservice "github.com/v2fly/v2ray-core/v4/app/policy/command"
 	handlerservice "github.com/v2fly/v2ray-core/v4/app/proxyman/command"
+	reverseservice "github.com/v2fly/v2ray-core/v4/app/reverse/command"
 	routerservice "github.com/v2fly/v2ray-core/v4/app/router/command"
 	statsservice "github.com/v2fly/v2ray-core/v4/app/stats/command"
 	"github.com/v2fly/v2ray-core/v4/common/serial"
@@ -41,6 +44,12 @@ func (c *APIConfig) Build() (*commander.Config, error) {
 			services = append(services, serial.ToTypedMessage(&observatoryservice.Config{}))
 		case "routingservice":
 			services = append(services, serial.ToTypedMessage(&routerservice.Config{}))
+		case "policyservice":
+			services = append(services, serial.ToTypedMessage(&policyservice.Config{}))
+		case "reverseservice":
+			services = append(services, serial.ToTypedMessage(&reverseservice.Config{}))
+		case "instancemanagementservice":
+			services = append(services, serial.ToTypedMessage(&instmanservice.Config{}))
 		default:
 			if !strings.HasPrefix(s, "#") {
 				continue
@@ -1,6 +1,8 @@
 package v4
 
 import (
+	"strings"
+
 	"github.com/golang/protobuf/proto"
 
 	"github.com/v2fly/v2ray-core/v4/common/net"
@@ -28,3 +30,39 @@ func (c *DNSOutboundConfig) Build() (proto.Message, error) {
 	}
 	return config, nil
 }
+
+type DNSInboundConfig struct {
+	NetworkList     *cfgcommon.NetworkList `json:"network"`
+	UserLevel       uint32                 `json:"userLevel"`
+	TTL             uint32                 `json:"ttl"`
+	UpstreamNetwork cfgcommon.Network      `json:"upstreamNetwork"`
+	UpstreamAddress *cfgcommon.Address     `json:"upstreamAddress"`
+	UpstreamPort    uint16                 `json:"upstreamPort"`
+	DOHPath         string                 `json:"dohPath"`
+}
+
+func (c *DNSInboundConfig) Build() (proto.Message, error) {
+	config := &dns.ServerConfig{
+		UserLevel: c.UserLevel,
+		Ttl:       c.TTL,
+		DohPath:   c.DOHPath,
+	}
+	if c.NetworkList != nil {
+		config.Networks = c.NetworkList.Build()
+	}
+	if c.UpstreamAddress != nil {
+		port := c.UpstreamPort
+		if port == 0 {
+			port = 53
+		}
+		config.Upstream = &net.Endpoint{
+			Network: c.UpstreamNetwork.Build(),
+			Address: c.UpstreamAddress.Build(),
+			Port:    uint32(port),
+		}
+	}
+	if c.DOHPath != "" && !strings.HasPrefix(c.DOHPath, "/") {
+		return nil, newError("invalid DoH path: ", c.DOHPath)
+	}
+	return config, nil
+}
@@ -33,3 +33,35 @@ func TestDnsProxyConfig(t *testing.T) {
 		},
 	})
 }
+
+func TestDnsInboundConfig(t *testing.T) {
+	creator := func() cfgcommon.Buildable {
+		return new(v4.DNSInboundConfig)
+	}
+
+	testassist.RunMultiTestCase(t, []testassist.TestCase{
+		{
+			Input: `{
+				"network": "udp",
+				"ttl": 60,
+				"upstreamAddress": "8.8.8.8",
+				"dohPath": "/dns-query"
+			}`,
+			Parser: testassist.LoadJSON(creator),
+			Output: &dns.ServerConfig{
+				Networks: []net.Network{net.Network_UDP},
+				Ttl:      60,
+				Upstream: &net.Endpoint{
+					Address: net.NewIPOrDomain(net.IPAddress([]byte{8, 8, 8, 8})),
+					Port:    53,
+				},
+				DohPath: "/dns-query",
+			},
+		},
+		{
+			Input:  `{}`,
+			Parser: testassist.LoadJSON(creator),
+			Output: &dns.ServerConfig{},
+		},
+	})
+}
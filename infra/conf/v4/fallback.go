@@ -0,0 +1,72 @@
+package v4
+
+import (
+	"encoding/json"
+	"runtime"
+	"strconv"
+	"syscall"
+
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/proxy/fallback"
+)
+
+// InboundFallbackConfig is the configuration of a fallback of VMess, Shadowsocks, VLESS and Trojan inbounds.
+type InboundFallbackConfig struct {
+	Alpn string          `json:"alpn"`
+	Path string          `json:"path"`
+	Type string          `json:"type"`
+	Dest json.RawMessage `json:"dest"`
+	Xver uint64          `json:"xver"`
+}
+
+// Build implements Buildable
+func (c *InboundFallbackConfig) Build() (*fallback.Fallback, error) {
+	fb := &fallback.Fallback{
+		Alpn: c.Alpn,
+		Path: c.Path,
+		Type: c.Type,
+		Xver: c.Xver,
+	}
+	var port uint16
+	if err := json.Unmarshal(c.Dest, &port); err == nil {
+		fb.Dest = strconv.Itoa(int(port))
+	} else {
+		_ = json.Unmarshal(c.Dest, &fb.Dest)
+	}
+
+	if fb.Path != "" && fb.Path[0] != '/' {
+		return nil, newError(`fallbacks: "path" must be empty or start with "/"`)
+	}
+	if fb.Dest == "" {
+		return nil, newError(`fallbacks: please fill in a valid value for every "dest"`)
+	}
+	if fb.Type == "" && fb.Dest == "serve-ws-none" {
+		// The legacy dest of VLESS and Trojan is kept, but never served.
+		fb.Type = "serve"
+	}
+	if fb.Type == "" {
+		switch fb.Dest[0] {
+		case '@', '/':
+			fb.Type = "unix"
+			if fb.Dest[0] == '@' && len(fb.Dest) > 1 && fb.Dest[1] == '@' && (runtime.GOOS == "linux" || runtime.GOOS == "android") {
+				fullAddr := make([]byte, len(syscall.RawSockaddrUnix{}.Path)) // may need padding to work with haproxy
+				copy(fullAddr, fb.Dest[1:])
+				fb.Dest = string(fullAddr)
+			}
+		default:
+			if _, err := strconv.Atoi(fb.Dest); err == nil {
+				fb.Dest = "127.0.0.1:" + fb.Dest
+			}
+			if _, _, err := net.SplitHostPort(fb.Dest); err == nil {
+				fb.Type = "tcp"
+			}
+		}
+	}
+	if fb.Type != "tcp" && fb.Type != "unix" && fb.Type != "serve" {
+		return nil, newError(`fallbacks: please fill in a valid value for every "dest", or set "type" to "tcp" or "unix"`)
+	}
+	if fb.Xver > 2 {
+		return nil, newError(`fallbacks: invalid PROXY protocol version, "xver" only accepts 0, 1, 2`)
+	}
+	return fb, nil
+}
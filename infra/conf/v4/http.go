@@ -8,7 +8,9 @@ import (
 	"github.com/v2fly/v2ray-core/v4/common/protocol"
 	"github.com/v2fly/v2ray-core/v4/common/serial"
 	"github.com/v2fly/v2ray-core/v4/infra/conf/cfgcommon"
+	"github.com/v2fly/v2ray-core/v4/infra/conf/cfgcommon/tlscfg"
 	"github.com/v2fly/v2ray-core/v4/proxy/http"
+	"github.com/v2fly/v2ray-core/v4/transport/internet/tls"
 )
 
 type HTTPAccount struct {
@@ -28,6 +30,23 @@ type HTTPServerConfig struct {
 	Accounts    []*HTTPAccount `json:"accounts"`
 	Transparent bool           `json:"allowTransparent"`
 	UserLevel   uint32         `json:"userLevel"`
+	HTTP3       *HTTP3Config   `json:"http3"`
+}
+
+type HTTP3Config struct {
+	TLSSettings *tlscfg.TLSConfig `json:"tlsSettings"`
+}
+
+func (c *HTTP3Config) Build() (*http.HTTP3Config, error) {
+	config := new(http.HTTP3Config)
+	if c.TLSSettings != nil {
+		ts, err := c.TLSSettings.Build()
+		if err != nil {
+			return nil, newError("invalid TLS settings").Base(err)
+		}
+		config.TlsSettings = ts.(*tls.Config)
+	}
+	return config, nil
 }
 
 func (c *HTTPServerConfig) Build() (proto.Message, error) {
@@ -44,6 +63,14 @@ func (c *HTTPServerConfig) Build() (proto.Message, error) {
 		}
 	}
 
+	if c.HTTP3 != nil {
+		http3, err := c.HTTP3.Build()
+		if err != nil {
+			return nil, err
+		}
+		config.Http3 = http3
+	}
+
 	return config, nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/v2fly/v2ray-core/v4/proxy/http"
+	"github.com/v2fly/v2ray-core/v4/transport/internet/tls"
 )
 
 func TestHTTPServerConfig(t *testing.T) {
@@ -37,5 +38,22 @@ func TestHTTPServerConfig(t *testing.T) {
 				Timeout:          10,
 			},
 		},
+		{
+			Input: `{
+				"http3": {
+					"tlsSettings": {
+						"serverName": "www.v2fly.org"
+					}
+				}
+			}`,
+			Parser: testassist.LoadJSON(creator),
+			Output: &http.ServerConfig{
+				Http3: &http.HTTP3Config{
+					TlsSettings: &tls.Config{
+						ServerName: "www.v2fly.org",
+					},
+				},
+			},
+		},
 	})
 }
@@ -0,0 +1,33 @@
+package v4
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/v2fly/v2ray-core/v4/app/metrics"
+)
+
+type MetricsConfig struct {
+	ListenAddr string `json:"listenAddr"`
+	ListenPort int32  `json:"listenPort"`
+	Path       string `json:"path"`
+}
+
+func (m *MetricsConfig) Build() (proto.Message, error) {
+	m.ListenAddr = strings.TrimSpace(m.ListenAddr)
+	if m.ListenAddr == "" {
+		m.ListenAddr = "127.0.0.1"
+	}
+	if m.ListenPort == 0 {
+		return nil, newError("metrics requires a listen port")
+	}
+	if m.Path != "" && !strings.HasPrefix(m.Path, "/") {
+		return nil, newError("metrics path must start with /: ", m.Path)
+	}
+	return &metrics.Config{
+		ListenAddr: m.ListenAddr,
+		ListenPort: m.ListenPort,
+		Path:       m.Path,
+	}, nil
+}
@@ -4,14 +4,30 @@ import (
 	"github.com/v2fly/v2ray-core/v4/app/policy"
 )
 
+// BandwidthConfig is the limit of throughput in bytes per second. 0 for unlimited.
+type BandwidthConfig struct {
+	Uplink   uint64 `json:"uplink"`
+	Downlink uint64 `json:"downlink"`
+}
+
+func (b *BandwidthConfig) Build() *policy.Policy_Bandwidth {
+	return &policy.Policy_Bandwidth{
+		Uplink:   b.Uplink,
+		Downlink: b.Downlink,
+	}
+}
+
 type Policy struct {
-	Handshake         *uint32 `json:"handshake"`
-	ConnectionIdle    *uint32 `json:"connIdle"`
-	UplinkOnly        *uint32 `json:"uplinkOnly"`
-	DownlinkOnly      *uint32 `json:"downlinkOnly"`
-	StatsUserUplink   bool    `json:"statsUserUplink"`
-	StatsUserDownlink bool    `json:"statsUserDownlink"`
-	BufferSize        *int32  `json:"bufferSize"`
+	Handshake          *uint32          `json:"handshake"`
+	ConnectionIdle     *uint32          `json:"connIdle"`
+	UplinkOnly         *uint32          `json:"uplinkOnly"`
+	DownlinkOnly       *uint32          `json:"downlinkOnly"`
+	StatsUserUplink    bool             `json:"statsUserUplink"`
+	StatsUserDownlink  bool             `json:"statsUserDownlink"`
+	BufferSize         *int32           `json:"bufferSize"`
+	Bandwidth          *BandwidthConfig `json:"bandwidth"`
+	ConnectionsPerUser uint32           `json:"connectionsPerUser"`
+	IPsPerUser         uint32           `json:"ipsPerUser"`
 }
 
 func (t *Policy) Build() (*policy.Policy, error) {
@@ -47,25 +63,53 @@ func (t *Policy) Build() (*policy.Policy, error) {
 		}
 	}
 
+	if t.Bandwidth != nil {
+		p.Bandwidth = t.Bandwidth.Build()
+	}
+
+	if t.ConnectionsPerUser > 0 || t.IPsPerUser > 0 {
+		p.Connection = &policy.Policy_Connection{
+			PerUser:    t.ConnectionsPerUser,
+			IpsPerUser: t.IPsPerUser,
+		}
+	}
+
 	return p, nil
 }
 
 type SystemPolicy struct {
-	StatsInboundUplink    bool `json:"statsInboundUplink"`
-	StatsInboundDownlink  bool `json:"statsInboundDownlink"`
-	StatsOutboundUplink   bool `json:"statsOutboundUplink"`
-	StatsOutboundDownlink bool `json:"statsOutboundDownlink"`
+	StatsInboundUplink    bool                        `json:"statsInboundUplink"`
+	StatsInboundDownlink  bool                        `json:"statsInboundDownlink"`
+	StatsOutboundUplink   bool                        `json:"statsOutboundUplink"`
+	StatsOutboundDownlink bool                        `json:"statsOutboundDownlink"`
+	InboundBandwidth      map[string]*BandwidthConfig `json:"inboundBandwidth"`
+	OutboundBandwidth     map[string]*BandwidthConfig `json:"outboundBandwidth"`
+	ConnectionsPerIP      uint32                      `json:"connectionsPerIp"`
 }
 
 func (p *SystemPolicy) Build() (*policy.SystemPolicy, error) {
-	return &policy.SystemPolicy{
+	config := &policy.SystemPolicy{
 		Stats: &policy.SystemPolicy_Stats{
 			InboundUplink:    p.StatsInboundUplink,
 			InboundDownlink:  p.StatsInboundDownlink,
 			OutboundUplink:   p.StatsOutboundUplink,
 			OutboundDownlink: p.StatsOutboundDownlink,
 		},
-	}, nil
+		ConnectionsPerIp: p.ConnectionsPerIP,
+	}
+	if len(p.InboundBandwidth) > 0 {
+		config.InboundBandwidth = make(map[string]*policy.Policy_Bandwidth, len(p.InboundBandwidth))
+		for tag, b := range p.InboundBandwidth {
+			config.InboundBandwidth[tag] = b.Build()
+		}
+	}
+	if len(p.OutboundBandwidth) > 0 {
+		config.OutboundBandwidth = make(map[string]*policy.Policy_Bandwidth, len(p.OutboundBandwidth))
+		for tag, b := range p.OutboundBandwidth {
+			config.OutboundBandwidth[tag] = b.Build()
+		}
+	}
+	return config, nil
 }
 
 type PolicyConfig struct {
@@ -38,3 +38,45 @@ func TestBufferSize(t *testing.T) {
 		}
 	}
 }
+
+func TestBandwidth(t *testing.T) {
+	pConf := v4.PolicyConfig{
+		Levels: map[uint32]*v4.Policy{
+			0: {Bandwidth: &v4.BandwidthConfig{Uplink: 1024, Downlink: 2048}},
+		},
+		System: &v4.SystemPolicy{
+			InboundBandwidth: map[string]*v4.BandwidthConfig{"in": {Uplink: 4096}},
+		},
+	}
+	p, err := pConf.Build()
+	common.Must(err)
+	if b := p.Level[0].Bandwidth; b.Uplink != 1024 || b.Downlink != 2048 {
+		t.Error("unexpected level bandwidth: ", b)
+	}
+	if b := p.System.InboundBandwidth["in"]; b.Uplink != 4096 || b.Downlink != 0 {
+		t.Error("unexpected inbound bandwidth: ", b)
+	}
+}
+
+func TestConnectionLimit(t *testing.T) {
+	pConf := v4.PolicyConfig{
+		Levels: map[uint32]*v4.Policy{
+			0: {ConnectionsPerUser: 8, IPsPerUser: 2},
+			1: {},
+		},
+		System: &v4.SystemPolicy{
+			ConnectionsPerIP: 16,
+		},
+	}
+	p, err := pConf.Build()
+	common.Must(err)
+	if c := p.Level[0].Connection; c.PerUser != 8 || c.IpsPerUser != 2 {
+		t.Error("unexpected connection limit: ", c)
+	}
+	if c := p.Level[1].Connection; c != nil {
+		t.Error("expected no connection limit, but got ", c)
+	}
+	if p.System.ConnectionsPerIp != 16 {
+		t.Error("unexpected connections per IP: ", p.System.ConnectionsPerIp)
+	}
+}
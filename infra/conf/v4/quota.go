@@ -0,0 +1,99 @@
+package v4
+
+import (
+	"strings"
+
+	"github.com/v2fly/v2ray-core/v4/app/quota"
+	"github.com/v2fly/v2ray-core/v4/infra/conf/cfgcommon"
+)
+
+type QuotaLimitConfig struct {
+	Bytes    uint64 `json:"bytes"`
+	Period   string `json:"period"`
+	ResetDay uint32 `json:"resetDay"`
+}
+
+func (c *QuotaLimitConfig) Build() (*quota.Limit, error) {
+	limit := &quota.Limit{
+		Bytes:    c.Bytes,
+		ResetDay: c.ResetDay,
+	}
+	switch strings.ToLower(c.Period) {
+	case "", "never":
+		limit.Period = quota.Period_Never
+	case "daily":
+		limit.Period = quota.Period_Daily
+	case "monthly":
+		limit.Period = quota.Period_Monthly
+	default:
+		return nil, newError("unknown quota period: ", c.Period)
+	}
+	if c.ResetDay > 28 {
+		return nil, newError("invalid quota reset day: ", c.ResetDay)
+	}
+	return limit, nil
+}
+
+type UserQuotaConfig struct {
+	Email *cfgcommon.StringList `json:"email"`
+	QuotaLimitConfig
+}
+
+type InboundQuotaConfig struct {
+	Tag *cfgcommon.StringList `json:"tag"`
+	QuotaLimitConfig
+}
+
+type QuotaConfig struct {
+	Users       []*UserQuotaConfig           `json:"users"`
+	Inbounds    []*InboundQuotaConfig        `json:"inbounds"`
+	Levels      map[uint32]*QuotaLimitConfig `json:"levels"`
+	CutExisting bool                         `json:"cutExisting"`
+	Persist     bool                         `json:"persist"`
+	TimeZone    string                       `json:"timezone"`
+}
+
+func (c *QuotaConfig) Build() (*quota.Config, error) {
+	config := &quota.Config{
+		CutExisting: c.CutExisting,
+		Persist:     c.Persist,
+		TimeZone:    c.TimeZone,
+	}
+	for _, u := range c.Users {
+		if u.Email == nil || len(*u.Email) == 0 {
+			return nil, newError("user quota without email")
+		}
+		limit, err := u.QuotaLimitConfig.Build()
+		if err != nil {
+			return nil, err
+		}
+		config.User = append(config.User, &quota.UserQuota{
+			Email: *u.Email,
+			Limit: limit,
+		})
+	}
+	for _, i := range c.Inbounds {
+		if i.Tag == nil || len(*i.Tag) == 0 {
+			return nil, newError("inbound quota without tag")
+		}
+		limit, err := i.QuotaLimitConfig.Build()
+		if err != nil {
+			return nil, err
+		}
+		config.Inbound = append(config.Inbound, &quota.InboundQuota{
+			Tag:   *i.Tag,
+			Limit: limit,
+		})
+	}
+	if len(c.Levels) > 0 {
+		config.Level = make(map[uint32]*quota.Limit, len(c.Levels))
+		for level, l := range c.Levels {
+			limit, err := l.Build()
+			if err != nil {
+				return nil, err
+			}
+			config.Level[level] = limit
+		}
+	}
+	return config, nil
+}
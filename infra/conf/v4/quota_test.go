@@ -0,0 +1,52 @@
+package v4_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/v2fly/v2ray-core/v4/app/quota"
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/infra/conf/v4"
+)
+
+func TestQuotaConfig(t *testing.T) {
+	config := new(v4.QuotaConfig)
+	common.Must(json.Unmarshal([]byte(`{
+		"users": [{"email": ["a@v2fly.org", "b@v2fly.org"], "bytes": 1073741824, "period": "monthly", "resetDay": 15}],
+		"inbounds": [{"tag": "in", "bytes": 1024, "period": "daily"}],
+		"levels": {"1": {"bytes": 2048}},
+		"cutExisting": true,
+		"persist": true,
+		"timezone": "Asia/Shanghai"
+	}`), config))
+	result, err := config.Build()
+	common.Must(err)
+
+	expected := &quota.Config{
+		User: []*quota.UserQuota{{
+			Email: []string{"a@v2fly.org", "b@v2fly.org"},
+			Limit: &quota.Limit{Bytes: 1073741824, Period: quota.Period_Monthly, ResetDay: 15},
+		}},
+		Inbound: []*quota.InboundQuota{{
+			Tag:   []string{"in"},
+			Limit: &quota.Limit{Bytes: 1024, Period: quota.Period_Daily},
+		}},
+		Level: map[uint32]*quota.Limit{
+			1: {Bytes: 2048},
+		},
+		CutExisting: true,
+		Persist:     true,
+		TimeZone:    "Asia/Shanghai",
+	}
+	if !proto.Equal(result, expected) {
+		t.Error("unexpected quota config: ", result)
+	}
+
+	invalid := new(v4.QuotaConfig)
+	common.Must(json.Unmarshal([]byte(`{"users": [{"email": "a@v2fly.org", "period": "weekly"}]}`), invalid))
+	if _, err := invalid.Build(); err == nil {
+		t.Error("expected error of unknown period")
+	}
+}
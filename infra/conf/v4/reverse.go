@@ -9,12 +9,14 @@ import (
 type BridgeConfig struct {
 	Tag    string `json:"tag"`
 	Domain string `json:"domain"`
+	ID     string `json:"id"`
 }
 
 func (c *BridgeConfig) Build() (*reverse.BridgeConfig, error) {
 	return &reverse.BridgeConfig{
 		Tag:    c.Tag,
 		Domain: c.Domain,
+		Id:     c.ID,
 	}, nil
 }
 
@@ -29,6 +29,21 @@ func TestReverseConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			Input: `{
+				"bridges": [{
+					"tag": "test",
+					"domain": "test.v2fly.org",
+					"id": "home-1"
+				}]
+			}`,
+			Parser: testassist.LoadJSON(creator),
+			Output: &reverse.Config{
+				BridgeConfig: []*reverse.BridgeConfig{
+					{Tag: "test", Domain: "test.v2fly.org", Id: "home-1"},
+				},
+			},
+		},
 		{
 			Input: `{
 				"portals": [{
@@ -9,14 +9,24 @@ import (
 	"github.com/v2fly/v2ray-core/v4/proxy/shadowsocks"
 )
 
+type ShadowsocksUserConfig struct {
+	Cipher   string `json:"method"`
+	Password string `json:"password"`
+	Level    byte   `json:"level"`
+	Email    string `json:"email"`
+	IVCheck  bool   `json:"ivCheck"`
+}
+
 type ShadowsocksServerConfig struct {
-	Cipher      string                 `json:"method"`
-	Password    string                 `json:"password"`
-	UDP         bool                   `json:"udp"`
-	Level       byte                   `json:"level"`
-	Email       string                 `json:"email"`
-	NetworkList *cfgcommon.NetworkList `json:"network"`
-	IVCheck     bool                   `json:"ivCheck"`
+	Cipher      string                   `json:"method"`
+	Password    string                   `json:"password"`
+	UDP         bool                     `json:"udp"`
+	Level       byte                     `json:"level"`
+	Email       string                   `json:"email"`
+	NetworkList *cfgcommon.NetworkList   `json:"network"`
+	IVCheck     bool                     `json:"ivCheck"`
+	Clients     []*ShadowsocksUserConfig `json:"clients"`
+	Fallbacks   []*InboundFallbackConfig `json:"fallbacks"`
 }
 
 func (v *ShadowsocksServerConfig) Build() (proto.Message, error) {
@@ -24,22 +34,81 @@ func (v *ShadowsocksServerConfig) Build() (proto.Message, error) {
 	config.UdpEnabled = v.UDP
 	config.Network = v.NetworkList.Build()
 
-	if v.Password == "" {
+	if v.Password == "" && len(v.Clients) == 0 {
 		return nil, newError("Shadowsocks password is not specified.")
 	}
-	account := &shadowsocks.Account{
-		Password: v.Password,
-		IvCheck:  v.IVCheck,
+	cipherType := shadowsocks.CipherFromString(v.Cipher)
+	if v.Password != "" || v.Cipher != "" {
+		if cipherType == shadowsocks.CipherType_UNKNOWN {
+			return nil, newError("unknown cipher method: ", v.Cipher)
+		}
+	}
+
+	// Clients of a Shadowsocks 2022 server are identified by identity headers, which are encrypted with the
+	// password of the server.
+	identified := false
+	switch cipherType {
+	case shadowsocks.CipherType_BLAKE3_AES_128_GCM, shadowsocks.CipherType_BLAKE3_AES_256_GCM:
+		identified = len(v.Clients) > 0
+	case shadowsocks.CipherType_BLAKE3_CHACHA20_POLY1305:
+		if len(v.Clients) > 0 {
+			return nil, newError("Shadowsocks clients are not supported by 2022-blake3-chacha20-poly1305 method.")
+		}
+	}
+	if identified && v.Password == "" {
+		return nil, newError("Shadowsocks password is not specified.")
 	}
-	account.CipherType = shadowsocks.CipherFromString(v.Cipher)
-	if account.CipherType == shadowsocks.CipherType_UNKNOWN {
-		return nil, newError("unknown cipher method: ", v.Cipher)
+
+	if v.Password != "" {
+		config.User = &protocol.User{
+			Email: v.Email,
+			Level: uint32(v.Level),
+			Account: serial.ToTypedMessage(&shadowsocks.Account{
+				Password:   v.Password,
+				CipherType: cipherType,
+				IvCheck:    v.IVCheck,
+			}),
+		}
 	}
 
-	config.User = &protocol.User{
-		Email:   v.Email,
-		Level:   uint32(v.Level),
-		Account: serial.ToTypedMessage(account),
+	for _, client := range v.Clients {
+		if client.Password == "" {
+			return nil, newError("Shadowsocks password is not specified for client ", client.Email)
+		}
+		account := &shadowsocks.Account{
+			Password:   client.Password,
+			CipherType: cipherType,
+			IvCheck:    client.IVCheck,
+		}
+		if client.Cipher != "" {
+			account.CipherType = shadowsocks.CipherFromString(client.Cipher)
+		}
+		switch account.CipherType {
+		case shadowsocks.CipherType_UNKNOWN:
+			return nil, newError("unknown cipher method of client ", client.Email, ": ", client.Cipher)
+		case shadowsocks.CipherType_NONE:
+			return nil, newError("Shadowsocks clients must be of AEAD methods.")
+		case shadowsocks.CipherType_BLAKE3_AES_128_GCM, shadowsocks.CipherType_BLAKE3_AES_256_GCM, shadowsocks.CipherType_BLAKE3_CHACHA20_POLY1305:
+			if !identified {
+				return nil, newError("Shadowsocks 2022 clients must be of a server of 2022-blake3-aes-*-gcm method.")
+			}
+		}
+		if identified && account.CipherType != cipherType {
+			return nil, newError("Shadowsocks 2022 clients must be of the method of the server.")
+		}
+		config.Users = append(config.Users, &protocol.User{
+			Email:   client.Email,
+			Level:   uint32(client.Level),
+			Account: serial.ToTypedMessage(account),
+		})
+	}
+
+	for _, rawFallback := range v.Fallbacks {
+		fb, err := rawFallback.Build()
+		if err != nil {
+			return nil, newError("invalid Shadowsocks fallback").Base(err)
+		}
+		config.Fallbacks = append(config.Fallbacks, fb)
 	}
 
 	return config, nil
@@ -9,6 +9,7 @@ import (
 	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/common/protocol"
 	"github.com/v2fly/v2ray-core/v4/common/serial"
+	"github.com/v2fly/v2ray-core/v4/proxy/fallback"
 	"github.com/v2fly/v2ray-core/v4/proxy/shadowsocks"
 )
 
@@ -34,5 +35,106 @@ func TestShadowsocksServerConfigParsing(t *testing.T) {
 				Network: []net.Network{net.Network_TCP},
 			},
 		},
+		{
+			Input: `{
+				"method": "2022-blake3-aes-128-gcm",
+				"password": "MDEyMzQ1Njc4OWFiY2RlZg==",
+				"clients": [{
+					"password": "ZmVkY2JhOTg3NjU0MzIxMA==",
+					"email": "love@v2fly.org",
+					"level": 1
+				}]
+			}`,
+			Parser: testassist.LoadJSON(creator),
+			Output: &shadowsocks.ServerConfig{
+				User: &protocol.User{
+					Account: serial.ToTypedMessage(&shadowsocks.Account{
+						CipherType: shadowsocks.CipherType_BLAKE3_AES_128_GCM,
+						Password:   "MDEyMzQ1Njc4OWFiY2RlZg==",
+					}),
+				},
+				Users: []*protocol.User{
+					{
+						Email: "love@v2fly.org",
+						Level: 1,
+						Account: serial.ToTypedMessage(&shadowsocks.Account{
+							CipherType: shadowsocks.CipherType_BLAKE3_AES_128_GCM,
+							Password:   "ZmVkY2JhOTg3NjU0MzIxMA==",
+						}),
+					},
+				},
+				Network: []net.Network{net.Network_TCP},
+			},
+		},
+		{
+			Input: `{
+				"method": "aes-128-gcm",
+				"clients": [{
+					"password": "password-1",
+					"email": "love@v2fly.org"
+				}, {
+					"method": "chacha20-poly1305",
+					"password": "password-2",
+					"level": 1
+				}]
+			}`,
+			Parser: testassist.LoadJSON(creator),
+			Output: &shadowsocks.ServerConfig{
+				Users: []*protocol.User{
+					{
+						Email: "love@v2fly.org",
+						Account: serial.ToTypedMessage(&shadowsocks.Account{
+							CipherType: shadowsocks.CipherType_AES_128_GCM,
+							Password:   "password-1",
+						}),
+					},
+					{
+						Level: 1,
+						Account: serial.ToTypedMessage(&shadowsocks.Account{
+							CipherType: shadowsocks.CipherType_CHACHA20_POLY1305,
+							Password:   "password-2",
+						}),
+					},
+				},
+				Network: []net.Network{net.Network_TCP},
+			},
+		},
+		{
+			Input: `{
+				"method": "aes-256-gcm",
+				"password": "v2ray-password",
+				"fallbacks": [{
+					"dest": 80
+				}, {
+					"alpn": "h2",
+					"path": "/v2fly",
+					"dest": "/dev/shm/h2.sock",
+					"xver": 2
+				}]
+			}`,
+			Parser: testassist.LoadJSON(creator),
+			Output: &shadowsocks.ServerConfig{
+				User: &protocol.User{
+					Account: serial.ToTypedMessage(&shadowsocks.Account{
+						CipherType: shadowsocks.CipherType_AES_256_GCM,
+						Password:   "v2ray-password",
+					}),
+				},
+				Network: []net.Network{net.Network_TCP},
+				Fallbacks: []*fallback.Fallback{
+					{
+						Type: "tcp",
+						Dest: "127.0.0.1:80",
+					},
+					{
+						Alpn: "h2",
+						Path: "/v2fly",
+						Type: "unix",
+						Dest: "/dev/shm/h2.sock",
+						Xver: 2,
+					},
+				},
+			},
+		},
 	})
 }
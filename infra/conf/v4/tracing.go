@@ -0,0 +1,45 @@
+package v4
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"github.com/v2fly/v2ray-core/v4/app/tracing"
+)
+
+type TracingOTLPConfig struct {
+	Endpoint string            `json:"endpoint"`
+	Headers  map[string]string `json:"headers"`
+}
+
+type TracingConfig struct {
+	ServiceName   string             `json:"serviceName"`
+	SamplingRatio *float64           `json:"samplingRatio"`
+	OTLP          *TracingOTLPConfig `json:"otlp"`
+	File          string             `json:"file"`
+}
+
+func (c *TracingConfig) Build() (proto.Message, error) {
+	config := &tracing.Config{
+		ServiceName:   c.ServiceName,
+		SamplingRatio: 1,
+	}
+	if c.SamplingRatio != nil {
+		if *c.SamplingRatio <= 0 || *c.SamplingRatio > 1 {
+			return nil, newError("sampling ratio of tracing must be in (0, 1]: ", *c.SamplingRatio)
+		}
+		config.SamplingRatio = *c.SamplingRatio
+	}
+	if c.OTLP != nil {
+		config.Otlp = &tracing.OTLPExporter{
+			Endpoint: c.OTLP.Endpoint,
+			Headers:  c.OTLP.Headers,
+		}
+	}
+	if c.File != "" {
+		config.File = &tracing.FileExporter{Path: c.File}
+	}
+	if config.Otlp == nil && config.File == nil {
+		return nil, newError("tracing requires an OTLP endpoint or a file to export spans to")
+	}
+	return config, nil
+}
@@ -22,6 +22,7 @@ import (
 
 var (
 	inboundConfigLoader = loader.NewJSONConfigLoader(loader.ConfigCreatorCache{
+		"dns":           func() interface{} { return new(DNSInboundConfig) },
 		"dokodemo-door": func() interface{} { return new(DokodemoConfig) },
 		"http":          func() interface{} { return new(HTTPServerConfig) },
 		"shadowsocks":   func() interface{} { return new(ShadowsocksServerConfig) },
@@ -306,6 +307,9 @@ type Config struct {
 	Observatory      *ObservatoryConfig      `json:"observatory"`
 	BurstObservatory *BurstObservatoryConfig `json:"burstObservatory"`
 	MultiObservatory *MultiObservatoryConfig `json:"multiObservatory"`
+	Quota            *QuotaConfig            `json:"quota"`
+	Metrics          *MetricsConfig          `json:"metrics"`
+	Tracing          *TracingConfig          `json:"tracing"`
 
 	Services map[string]*json.RawMessage `json:"services"`
 }
@@ -462,6 +466,30 @@ func (c *Config) Build() (*core.Config, error) {
 		config.App = append(config.App, serial.ToTypedMessage(r))
 	}
 
+	if c.Quota != nil {
+		r, err := c.Quota.Build()
+		if err != nil {
+			return nil, newError("failed to parse quota config").Base(err)
+		}
+		config.App = append(config.App, serial.ToTypedMessage(r))
+	}
+
+	if c.Metrics != nil {
+		r, err := c.Metrics.Build()
+		if err != nil {
+			return nil, newError("failed to parse metrics config").Base(err)
+		}
+		config.App = append(config.App, serial.ToTypedMessage(r))
+	}
+
+	if c.Tracing != nil {
+		r, err := c.Tracing.Build()
+		if err != nil {
+			return nil, newError("failed to parse tracing config").Base(err)
+		}
+		config.App = append(config.App, serial.ToTypedMessage(r))
+	}
+
 	// Load Additional Services that do not have a json translator
 
 	if msg, err := c.BuildServices(c.Services); err != nil {
@@ -2,13 +2,9 @@ package v4
 
 import (
 	"encoding/json"
-	"runtime"
-	"strconv"
-	"syscall"
 
 	"github.com/golang/protobuf/proto"
 
-	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/common/protocol"
 	"github.com/v2fly/v2ray-core/v4/common/serial"
 	"github.com/v2fly/v2ray-core/v4/infra/conf/cfgcommon"
@@ -17,19 +13,11 @@ import (
 	"github.com/v2fly/v2ray-core/v4/proxy/vless/outbound"
 )
 
-type VLessInboundFallback struct {
-	Alpn string          `json:"alpn"`
-	Path string          `json:"path"`
-	Type string          `json:"type"`
-	Dest json.RawMessage `json:"dest"`
-	Xver uint64          `json:"xver"`
-}
-
 type VLessInboundConfig struct {
-	Clients    []json.RawMessage       `json:"clients"`
-	Decryption string                  `json:"decryption"`
-	Fallback   json.RawMessage         `json:"fallback"`
-	Fallbacks  []*VLessInboundFallback `json:"fallbacks"`
+	Clients    []json.RawMessage        `json:"clients"`
+	Decryption string                   `json:"decryption"`
+	Fallback   json.RawMessage          `json:"fallback"`
+	Fallbacks  []*InboundFallbackConfig `json:"fallbacks"`
 }
 
 // Build implements Buildable
@@ -62,60 +50,19 @@ func (c *VLessInboundConfig) Build() (proto.Message, error) {
 	if c.Fallback != nil {
 		return nil, newError(`VLESS settings: please use "fallbacks":[{}] instead of "fallback":{}`)
 	}
-	for _, fb := range c.Fallbacks {
-		var i uint16
-		var s string
-		if err := json.Unmarshal(fb.Dest, &i); err == nil {
-			s = strconv.Itoa(int(i))
-		} else {
-			_ = json.Unmarshal(fb.Dest, &s)
+	for _, rawFallback := range c.Fallbacks {
+		fb, err := rawFallback.Build()
+		if err != nil {
+			return nil, newError("invalid VLESS fallback").Base(err)
 		}
 		config.Fallbacks = append(config.Fallbacks, &inbound.Fallback{
 			Alpn: fb.Alpn,
 			Path: fb.Path,
 			Type: fb.Type,
-			Dest: s,
+			Dest: fb.Dest,
 			Xver: fb.Xver,
 		})
 	}
-	for _, fb := range config.Fallbacks {
-		/*
-			if fb.Alpn == "h2" && fb.Path != "" {
-				return nil, newError(`VLESS fallbacks: "alpn":"h2" doesn't support "path"`)
-			}
-		*/
-		if fb.Path != "" && fb.Path[0] != '/' {
-			return nil, newError(`VLESS fallbacks: "path" must be empty or start with "/"`)
-		}
-		if fb.Type == "" && fb.Dest != "" {
-			if fb.Dest == "serve-ws-none" {
-				fb.Type = "serve"
-			} else {
-				switch fb.Dest[0] {
-				case '@', '/':
-					fb.Type = "unix"
-					if fb.Dest[0] == '@' && len(fb.Dest) > 1 && fb.Dest[1] == '@' && (runtime.GOOS == "linux" || runtime.GOOS == "android") {
-						fullAddr := make([]byte, len(syscall.RawSockaddrUnix{}.Path)) // may need padding to work with haproxy
-						copy(fullAddr, fb.Dest[1:])
-						fb.Dest = string(fullAddr)
-					}
-				default:
-					if _, err := strconv.Atoi(fb.Dest); err == nil {
-						fb.Dest = "127.0.0.1:" + fb.Dest
-					}
-					if _, _, err := net.SplitHostPort(fb.Dest); err == nil {
-						fb.Type = "tcp"
-					}
-				}
-			}
-		}
-		if fb.Type == "" {
-			return nil, newError(`VLESS fallbacks: please fill in a valid value for every "dest"`)
-		}
-		if fb.Xver > 2 {
-			return nil, newError(`VLESS fallbacks: invalid PROXY protocol version, "xver" only accepts 0, 1, 2`)
-		}
-	}
 
 	return config, nil
 }
@@ -77,11 +77,12 @@ func (c *VMessDefaultConfig) Build() *inbound.DefaultConfig {
 }
 
 type VMessInboundConfig struct {
-	Users        []json.RawMessage   `json:"clients"`
-	Features     *FeaturesConfig     `json:"features"`
-	Defaults     *VMessDefaultConfig `json:"default"`
-	DetourConfig *VMessDetourConfig  `json:"detour"`
-	SecureOnly   bool                `json:"disableInsecureEncryption"`
+	Users        []json.RawMessage        `json:"clients"`
+	Features     *FeaturesConfig          `json:"features"`
+	Defaults     *VMessDefaultConfig      `json:"default"`
+	DetourConfig *VMessDetourConfig       `json:"detour"`
+	SecureOnly   bool                     `json:"disableInsecureEncryption"`
+	Fallbacks    []*InboundFallbackConfig `json:"fallbacks"`
 }
 
 // Build implements Buildable
@@ -114,6 +115,14 @@ func (c *VMessInboundConfig) Build() (proto.Message, error) {
 		config.User[idx] = user
 	}
 
+	for _, rawFallback := range c.Fallbacks {
+		fb, err := rawFallback.Build()
+		if err != nil {
+			return nil, newError("invalid VMess fallback").Base(err)
+		}
+		config.Fallbacks = append(config.Fallbacks, fb)
+	}
+
 	return config, nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/common/protocol"
 	"github.com/v2fly/v2ray-core/v4/common/serial"
+	"github.com/v2fly/v2ray-core/v4/proxy/fallback"
 	"github.com/v2fly/v2ray-core/v4/proxy/vmess"
 	"github.com/v2fly/v2ray-core/v4/proxy/vmess/inbound"
 	"github.com/v2fly/v2ray-core/v4/proxy/vmess/outbound"
@@ -88,7 +89,11 @@ func TestVMessInbound(t *testing.T) {
 				"detour": {
 					"to": "tag_to_detour"
 				},
-				"disableInsecureEncryption": true
+				"disableInsecureEncryption": true,
+				"fallbacks": [{
+					"dest": "127.0.0.1:8080",
+					"xver": 1
+				}]
 			}`,
 			Parser: testassist.LoadJSON(creator),
 			Output: &inbound.Config{
@@ -113,6 +118,13 @@ func TestVMessInbound(t *testing.T) {
 					To: "tag_to_detour",
 				},
 				SecureEncryptionOnly: true,
+				Fallbacks: []*fallback.Fallback{
+					{
+						Type: "tcp",
+						Dest: "127.0.0.1:8080",
+						Xver: 1,
+					},
+				},
 			},
 		},
 	})
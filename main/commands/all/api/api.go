@@ -15,5 +15,12 @@ var CmdAPI = &base.Command{
 		cmdStats,
 		cmdBalancerInfo,
 		cmdBalancerOverride,
+		cmdBandwidth,
+		cmdReverse,
+		cmdInstanceList,
+		cmdInstanceAdd,
+		cmdInstanceStart,
+		cmdInstanceStop,
+		cmdInstanceRemove,
 	},
 }
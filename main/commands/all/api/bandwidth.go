@@ -0,0 +1,141 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/v2fly/v2ray-core/v4/app/policy"
+	policyService "github.com/v2fly/v2ray-core/v4/app/policy/command"
+	"github.com/v2fly/v2ray-core/v4/main/commands/base"
+)
+
+var cmdBandwidth = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api bw [--server=127.0.0.1:8080] [-level <level> | -inbound <tag> | -outbound <tag>] [-up <bytes>] [-down <bytes>]",
+	Short:       "get or set bandwidth limits",
+	Long: `
+Get the bandwidth limits of user levels, inbounds and outbounds, or set
+one of them. Limits are in bytes per second, 0 for unlimited.
+
+> Make sure you have "PolicyService" set in "config.api.services"
+of server config.
+
+Arguments:
+
+	-level <level>
+		Set the limit of each user of the level.
+
+	-inbound <tag>
+		Set the limit of the inbound.
+
+	-outbound <tag>
+		Set the limit of the outbound.
+
+	-up <bytes>
+		The limit of uplink traffic. Default 0
+
+	-down <bytes>
+		The limit of downlink traffic. Default 0
+
+	-json
+		Use json output.
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout seconds to call API. Default 3
+
+Example:
+
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080
+	{{.Exec}} {{.LongName}} -level 0 -up 1048576 -down 1048576
+	{{.Exec}} {{.LongName}} -outbound proxy -down 0
+`,
+	Run: executeBandwidth,
+}
+
+func executeBandwidth(cmd *base.Command, args []string) {
+	var (
+		level    int
+		inbound  string
+		outbound string
+		up       uint64
+		down     uint64
+	)
+	cmd.Flag.IntVar(&level, "level", -1, "")
+	cmd.Flag.StringVar(&inbound, "inbound", "", "")
+	cmd.Flag.StringVar(&outbound, "outbound", "", "")
+	cmd.Flag.Uint64Var(&up, "up", 0, "")
+	cmd.Flag.Uint64Var(&down, "down", 0, "")
+	setSharedFlags(cmd)
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := policyService.NewPolicyServiceClient(conn)
+	req := &policyService.SetBandwidthRequest{
+		Bandwidth: &policy.Policy_Bandwidth{
+			Uplink:   up,
+			Downlink: down,
+		},
+	}
+	switch {
+	case level >= 0:
+		req.Target = &policyService.SetBandwidthRequest_Level{Level: uint32(level)}
+	case inbound != "":
+		req.Target = &policyService.SetBandwidthRequest_InboundTag{InboundTag: inbound}
+	case outbound != "":
+		req.Target = &policyService.SetBandwidthRequest_OutboundTag{OutboundTag: outbound}
+	}
+	if req.Target != nil {
+		if _, err := client.SetBandwidth(ctx, req); err != nil {
+			base.Fatalf("failed to set bandwidth: %s", err)
+		}
+		return
+	}
+
+	resp, err := client.GetBandwidth(ctx, &policyService.GetBandwidthRequest{})
+	if err != nil {
+		base.Fatalf("failed to get bandwidth: %s", err)
+	}
+	if apiJSON {
+		showJSONResponse(resp)
+		return
+	}
+
+	type row struct {
+		target string
+		limit  *policy.Policy_Bandwidth
+	}
+	var rows []row
+	for l, b := range resp.Level {
+		rows = append(rows, row{fmt.Sprint("level ", l), b})
+	}
+	for tag, b := range resp.Inbound {
+		rows = append(rows, row{"inbound " + tag, b})
+	}
+	for tag, b := range resp.Outbound {
+		rows = append(rows, row{"outbound " + tag, b})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].target < rows[j].target
+	})
+
+	const tableIndent = 0
+	sb := new(strings.Builder)
+	titles := []string{"Target", "Uplink", "Downlink"}
+	formats := []string{"%-30s ", "%-12s ", "%s"}
+	writeRow(sb, tableIndent, 0, titles, formats)
+	for i, r := range rows {
+		writeRow(sb, tableIndent, i+1, []string{
+			r.target,
+			fmt.Sprint(r.limit.GetUplink()),
+			fmt.Sprint(r.limit.GetDownlink()),
+		}, formats)
+	}
+	os.Stdout.WriteString(sb.String())
+}
@@ -0,0 +1,252 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	instmanService "github.com/v2fly/v2ray-core/v4/app/instman/command"
+	"github.com/v2fly/v2ray-core/v4/main/commands/base"
+)
+
+var cmdInstanceList = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api lsinst [--server=127.0.0.1:8080]",
+	Short:       "list managed instances",
+	Long: `
+List the instances managed by the instance manager, and their states.
+
+> Make sure you have "InstanceManagementService" set in "config.api.services"
+of server config, and the "instman" app enabled.
+
+Arguments:
+
+	-json
+		Use json output.
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout seconds to call API. Default 3
+
+Example:
+
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080
+`,
+	Run: executeInstanceList,
+}
+
+var cmdInstanceAdd = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api adinst [--server=127.0.0.1:8080] [-format=json] [-start] <name> <config file>",
+	Short:       "add managed instance",
+	Long: `
+Add an instance to the instance manager.
+
+> Make sure you have "InstanceManagementService" set in "config.api.services"
+of server config, and the "instman" app enabled.
+
+Arguments:
+
+	-format <format>
+		Format of the config. Default "json"
+
+	-start
+		Start the instance after it is added.
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout seconds to call API. Default 3
+
+Example:
+
+	{{.Exec}} {{.LongName}} -start node1 node1.json
+`,
+	Run: executeInstanceAdd,
+}
+
+var cmdInstanceStart = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api startinst [--server=127.0.0.1:8080] <name>...",
+	Short:       "start managed instances",
+	Long: `
+Start instances of the instance manager.
+
+Arguments:
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout seconds to call API. Default 3
+
+Example:
+
+	{{.Exec}} {{.LongName}} node1 node2
+`,
+	Run: executeInstanceStart,
+}
+
+var cmdInstanceStop = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api stopinst [--server=127.0.0.1:8080] <name>...",
+	Short:       "stop managed instances",
+	Long: `
+Stop instances of the instance manager. Stopped instances are kept,
+and can be started again.
+
+Arguments:
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout seconds to call API. Default 3
+
+Example:
+
+	{{.Exec}} {{.LongName}} node1 node2
+`,
+	Run: executeInstanceStop,
+}
+
+var cmdInstanceRemove = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api rminst [--server=127.0.0.1:8080] <name>...",
+	Short:       "remove managed instances",
+	Long: `
+Stop instances of the instance manager, and remove them.
+
+Arguments:
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout seconds to call API. Default 3
+
+Example:
+
+	{{.Exec}} {{.LongName}} node1 node2
+`,
+	Run: executeInstanceRemove,
+}
+
+func executeInstanceList(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := instmanService.NewInstanceManagementServiceClient(conn)
+	resp, err := client.ListInstance(ctx, &instmanService.ListInstanceReq{})
+	if err != nil {
+		base.Fatalf("failed to list instances: %s", err)
+	}
+	if apiJSON {
+		showJSONResponse(resp)
+		return
+	}
+
+	const tableIndent = 0
+	sb := new(strings.Builder)
+	titles := []string{"Name", "State", "Restarts", "Last Error"}
+	formats := []string{"%-20s ", "%-10s ", "%-10s ", "%s"}
+	writeRow(sb, tableIndent, 0, titles, formats)
+	for i, status := range resp.Status {
+		writeRow(sb, tableIndent, i+1, []string{
+			status.Name,
+			strings.ToLower(status.State.String()),
+			fmt.Sprint(status.RestartCount),
+			status.LastError,
+		}, formats)
+	}
+	os.Stdout.WriteString(sb.String())
+}
+
+func executeInstanceAdd(cmd *base.Command, args []string) {
+	var (
+		format string
+		start  bool
+	)
+	setSharedFlags(cmd)
+	cmd.Flag.StringVar(&format, "format", "json", "")
+	cmd.Flag.BoolVar(&start, "start", false, "")
+	cmd.Flag.Parse(args)
+	if cmd.Flag.NArg() != 2 {
+		base.Fatalf("instance name and config file are required")
+	}
+	name, file := cmd.Flag.Arg(0), cmd.Flag.Arg(1)
+	content, err := os.ReadFile(file)
+	if err != nil {
+		base.Fatalf("failed to read config %s: %s", file, err)
+	}
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := instmanService.NewInstanceManagementServiceClient(conn)
+	_, err = client.AddInstance(ctx, &instmanService.AddInstanceReq{
+		Name:             name,
+		ConfigType:       format,
+		ConfigContentB64: base64.StdEncoding.EncodeToString(content),
+	})
+	if err != nil {
+		base.Fatalf("failed to add instance %s: %s", name, err)
+	}
+	if start {
+		if _, err := client.StartInstance(ctx, &instmanService.StartInstanceReq{Name: name}); err != nil {
+			base.Fatalf("failed to start instance %s: %s", name, err)
+		}
+	}
+}
+
+func executeInstanceStart(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := instmanService.NewInstanceManagementServiceClient(conn)
+	for _, name := range cmd.Flag.Args() {
+		if _, err := client.StartInstance(ctx, &instmanService.StartInstanceReq{Name: name}); err != nil {
+			base.Fatalf("failed to start instance %s: %s", name, err)
+		}
+	}
+}
+
+func executeInstanceStop(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := instmanService.NewInstanceManagementServiceClient(conn)
+	for _, name := range cmd.Flag.Args() {
+		if _, err := client.StopInstance(ctx, &instmanService.StopInstanceReq{Name: name}); err != nil {
+			base.Fatalf("failed to stop instance %s: %s", name, err)
+		}
+	}
+}
+
+func executeInstanceRemove(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := instmanService.NewInstanceManagementServiceClient(conn)
+	for _, name := range cmd.Flag.Args() {
+		if _, err := client.UntrackInstance(ctx, &instmanService.UntrackInstanceReq{Name: name}); err != nil {
+			base.Fatalf("failed to remove instance %s: %s", name, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	reverseService "github.com/v2fly/v2ray-core/v4/app/reverse/command"
+	"github.com/v2fly/v2ray-core/v4/common/units"
+	"github.com/v2fly/v2ray-core/v4/main/commands/base"
+)
+
+var cmdReverse = &base.Command{
+	CustomFlags: true,
+	UsageLine:   "{{.Exec}} api rvs [--server=127.0.0.1:8080] [portal]",
+	Short:       "status of reverse portals",
+	Long: `
+Get the bridges connected to a reverse portal, with their connections,
+traffic and the last time they replied to heartbeats. If no portal tag
+specified, get those of all portals.
+
+> Make sure you have "ReverseService" set in "config.api.services"
+of server config.
+
+Arguments:
+
+	-json
+		Use json output.
+
+	-s, -server <server:port>
+		The API server address. Default 127.0.0.1:8080
+
+	-t, -timeout <seconds>
+		Timeout seconds to call API. Default 3
+
+Example:
+
+	{{.Exec}} {{.LongName}} --server=127.0.0.1:8080 portal
+`,
+	Run: executeReverse,
+}
+
+func executeReverse(cmd *base.Command, args []string) {
+	setSharedFlags(cmd)
+	cmd.Flag.Parse(args)
+
+	conn, ctx, close := dialAPIServer()
+	defer close()
+
+	client := reverseService.NewReverseServiceClient(conn)
+	resp, err := client.GetPortalStatus(ctx, &reverseService.GetPortalStatusRequest{Tag: cmd.Flag.Arg(0)})
+	if err != nil {
+		base.Fatalf("failed to get portal status: %s", err)
+	}
+	if apiJSON {
+		showJSONResponse(resp)
+		return
+	}
+
+	const tableIndent = 4
+	sb := new(strings.Builder)
+	titles := []string{"Bridge", "Workers", "Connections", "Uplink", "Downlink", "Last Seen"}
+	formats := []string{"%-38s ", "%-8s ", "%-12s ", "%-10s ", "%-10s ", "%s"}
+	for _, p := range resp.Portals {
+		sb.WriteString(fmt.Sprintf("%s (%s):\n", p.Tag, p.Domain))
+		writeRow(sb, tableIndent, 0, titles, formats)
+		for i, b := range p.Bridges {
+			id := b.Id
+			if id == "" {
+				id = "(unidentified)"
+			}
+			lastSeen := "-"
+			if b.LastSeen > 0 {
+				lastSeen = time.Unix(b.LastSeen, 0).Format(time.RFC3339)
+			}
+			writeRow(sb, tableIndent, i+1, []string{
+				id,
+				fmt.Sprint(b.Workers),
+				fmt.Sprint(b.ActiveConnections),
+				units.ByteSize(b.Uplink).String(),
+				units.ByteSize(b.Downlink).String(),
+				lastSeen,
+			}, formats)
+		}
+	}
+	os.Stdout.WriteString(sb.String())
+}
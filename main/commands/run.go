@@ -10,6 +10,7 @@ import (
 	"runtime"
 	"strings"
 	"syscall"
+	"time"
 
 	core "github.com/v2fly/v2ray-core/v4"
 	"github.com/v2fly/v2ray-core/v4/common/cmdarg"
@@ -20,7 +21,7 @@ import (
 // CmdRun runs V2Ray with config
 var CmdRun = &base.Command{
 	CustomFlags: true,
-	UsageLine:   "{{.Exec}} run [-c config.json] [-d dir]",
+	UsageLine:   "{{.Exec}} run [-c config.json] [-d dir] [-watch]",
 	Short:       "run V2Ray with config",
 	Long: `
 Run V2Ray with config.
@@ -47,10 +48,24 @@ Arguments:
 	-format <format>
 		Format of config input. (default "auto")
 
+	-watch
+		Reload config when the config files are modified, or when config
+		files are added to or removed from the config directories.
+
+The config files are looked up and loaded again, then applied without
+restarting when V2Ray receives SIGHUP, or on modification if -watch is
+set. Inbounds and
+outbounds are matched by tag, only the changed ones are recreated, so
+connections of unchanged handlers are kept. Routing and DNS settings are
+replaced at once. Changes that cannot be applied this way, like adding
+an app or changing the first outbound, are rejected and logged, leaving
+the running config intact.
+
 Examples:
 
 	{{.Exec}} {{.LongName}} -c config.json
 	{{.Exec}} {{.LongName}} -d path/to/dir
+	{{.Exec}} {{.LongName}} -c config.json -watch
 
 Use "{{.Exec}} help format-loader" for more information about format.
 	`,
@@ -62,11 +77,15 @@ var (
 	configDirs           cmdarg.Arg
 	configFormat         *string
 	configDirRecursively *bool
+	configWatch          *bool
 )
 
+const configWatchInterval = 5 * time.Second
+
 func setConfigFlags(cmd *base.Command) {
 	configFormat = cmd.Flag.String("format", core.FormatAuto, "")
 	configDirRecursively = cmd.Flag.Bool("r", false, "")
+	configWatch = cmd.Flag.Bool("watch", false, "")
 
 	cmd.Flag.Var(&configFiles, "config", "")
 	cmd.Flag.Var(&configFiles, "c", "")
@@ -78,6 +97,7 @@ func executeRun(cmd *base.Command, args []string) {
 	setConfigFlags(cmd)
 	cmd.Flag.Parse(args)
 	printVersion()
+	configFileArgs := append(cmdarg.Arg(nil), configFiles...)
 	configFiles = getConfigFilePath()
 	server, err := startV2Ray()
 	if err != nil {
@@ -92,11 +112,127 @@ func executeRun(cmd *base.Command, args []string) {
 	// Explicitly triggering GC to remove garbage from config loading.
 	runtime.GC()
 
-	{
-		osSignals := make(chan os.Signal, 1)
-		signal.Notify(osSignals, os.Interrupt, syscall.SIGTERM)
-		<-osSignals
+	var configChanged <-chan struct{}
+	if *configWatch {
+		configChanged = watchConfigFiles(configFiles, configDirectories())
+	}
+
+	osSignals := make(chan os.Signal, 1)
+	signal.Notify(osSignals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	for {
+		select {
+		case sig := <-osSignals:
+			if sig != syscall.SIGHUP {
+				return
+			}
+		case <-configChanged:
+		}
+		if err := reloadV2Ray(server, configFileArgs); err != nil {
+			log.Println("Failed to reload config:", err)
+		}
+	}
+}
+
+// reloadV2Ray looks up the config files again from the given arguments and the config directories, so that files
+// added to or removed from the directories are taken into account, and applies the config loaded from them.
+func reloadV2Ray(server core.Server, args cmdarg.Arg) error {
+	instance, ok := server.(*core.Instance)
+	if !ok {
+		return newError("server does not support reloading")
+	}
+	if len(configFiles) == 0 {
+		return newError("config from stdin cannot be reloaded")
+	}
+	files, err := findConfigFiles(args)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return newError("no config file found")
+	}
+	config, err := core.LoadConfig(*configFormat, files)
+	if err != nil {
+		return newError(fmt.Sprintf("failed to load config: %s", files)).Base(err)
+	}
+	if err := instance.Reload(config); err != nil {
+		return err
+	}
+	configFiles = files
+	return nil
+}
+
+// configDirectories returns the directories config files are read from.
+func configDirectories() cmdarg.Arg {
+	if len(configDirs) > 0 {
+		return configDirs
+	}
+	if envConfDir := platform.GetConfDirPath(); dirExists(envConfDir) {
+		return cmdarg.Arg{envConfDir}
+	}
+	return nil
+}
+
+// watchConfigFiles polls the given files and the config files in the given directories, and notifies when any of
+// them is modified, added or removed.
+func watchConfigFiles(files, dirs cmdarg.Arg) <-chan struct{} {
+	changed := make(chan struct{})
+	if len(files) == 0 && len(dirs) == 0 {
+		return changed
+	}
+	files = append(cmdarg.Arg(nil), files...)
+	go func() {
+		last := configModTimes(files, dirs)
+		for range time.Tick(configWatchInterval) {
+			current := configModTimes(files, dirs)
+			if !equalModTimes(last, current) {
+				changed <- struct{}{}
+			}
+			last = current
+		}
+	}()
+	return changed
+}
+
+// configModTimes returns the modification times of the given files and the config files in the given directories.
+// Files that do not exist have the zero time.
+func configModTimes(files, dirs cmdarg.Arg) map[string]time.Time {
+	times := make(map[string]time.Time)
+	for _, file := range files {
+		times[file] = time.Time{}
+	}
+	if extension, err := core.GetLoaderExtensions(*configFormat); err == nil {
+		dirReader := listConfDir
+		if *configDirRecursively {
+			dirReader = listConfDirRecursively
+		}
+		for _, dir := range dirs {
+			dirFiles, err := dirReader(dir, extension)
+			if err != nil {
+				continue
+			}
+			for _, file := range dirFiles {
+				times[file] = time.Time{}
+			}
+		}
+	}
+	for file := range times {
+		if info, err := os.Stat(file); err == nil {
+			times[file] = info.ModTime()
+		}
+	}
+	return times
+}
+
+func equalModTimes(a, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for file, t := range a {
+		if u, found := b[file]; !found || !u.Equal(t) {
+			return false
+		}
 	}
+	return true
 }
 
 func fileExists(file string) bool {
@@ -113,9 +249,17 @@ func dirExists(file string) bool {
 }
 
 func readConfDir(dirPath string, extension []string) cmdarg.Arg {
+	files, err := listConfDir(dirPath, extension)
+	if err != nil {
+		base.Fatalf("%s", err)
+	}
+	return files
+}
+
+func listConfDir(dirPath string, extension []string) (cmdarg.Arg, error) {
 	confs, err := ioutil.ReadDir(dirPath)
 	if err != nil {
-		base.Fatalf("failed to read dir %s: %s", dirPath, err)
+		return nil, newError(fmt.Sprintf("failed to read dir %s", dirPath)).Base(err)
 	}
 	files := make(cmdarg.Arg, 0)
 	for _, f := range confs {
@@ -127,11 +271,19 @@ func readConfDir(dirPath string, extension []string) cmdarg.Arg {
 			}
 		}
 	}
-	return files
+	return files, nil
 }
 
 // getFolderFiles get files in the folder and it's children
 func readConfDirRecursively(dirPath string, extension []string) cmdarg.Arg {
+	files, err := listConfDirRecursively(dirPath, extension)
+	if err != nil {
+		base.Fatalf("%s", err)
+	}
+	return files
+}
+
+func listConfDirRecursively(dirPath string, extension []string) (cmdarg.Arg, error) {
 	files := make(cmdarg.Arg, 0)
 	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
 		ext := filepath.Ext(path)
@@ -144,51 +296,70 @@ func readConfDirRecursively(dirPath string, extension []string) cmdarg.Arg {
 		return nil
 	})
 	if err != nil {
-		base.Fatalf("failed to read dir %s: %s", dirPath, err)
+		return nil, newError(fmt.Sprintf("failed to read dir %s", dirPath)).Base(err)
 	}
-	return files
+	return files, nil
 }
 
 func getConfigFilePath() cmdarg.Arg {
+	files, err := findConfigFiles(configFiles)
+	if err != nil {
+		base.Fatalf("%s", err)
+	}
+	return files
+}
+
+// findConfigFiles returns the given config files and those in the config directories. If there are none, it
+// falls back to the default config file.
+func findConfigFiles(args cmdarg.Arg) (cmdarg.Arg, error) {
 	extension, err := core.GetLoaderExtensions(*configFormat)
 	if err != nil {
-		base.Fatalf(err.Error())
+		return nil, err
 	}
-	dirReader := readConfDir
+	dirReader := listConfDir
 	if *configDirRecursively {
-		dirReader = readConfDirRecursively
+		dirReader = listConfDirRecursively
 	}
+	files := append(cmdarg.Arg(nil), args...)
 	if len(configDirs) > 0 {
 		for _, d := range configDirs {
 			log.Println("Using confdir from arg:", d)
-			configFiles = append(configFiles, dirReader(d, extension)...)
+			dirFiles, err := dirReader(d, extension)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, dirFiles...)
 		}
 	} else if envConfDir := platform.GetConfDirPath(); dirExists(envConfDir) {
 		log.Println("Using confdir from env:", envConfDir)
-		configFiles = append(configFiles, dirReader(envConfDir, extension)...)
+		dirFiles, err := dirReader(envConfDir, extension)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, dirFiles...)
 	}
-	if len(configFiles) > 0 {
-		return configFiles
+	if len(files) > 0 {
+		return files, nil
 	}
 
-	if len(configFiles) == 0 && len(configDirs) > 0 {
-		base.Fatalf("no config file found with extension: %s", extension)
+	if len(configDirs) > 0 {
+		return nil, newError(fmt.Sprintf("no config file found with extension: %s", extension))
 	}
 
 	if workingDir, err := os.Getwd(); err == nil {
 		configFile := filepath.Join(workingDir, "config.json")
 		if fileExists(configFile) {
 			log.Println("Using default config: ", configFile)
-			return cmdarg.Arg{configFile}
+			return cmdarg.Arg{configFile}, nil
 		}
 	}
 
 	if configFile := platform.GetConfigurationPath(); fileExists(configFile) {
 		log.Println("Using config from env: ", configFile)
-		return cmdarg.Arg{configFile}
+		return cmdarg.Arg{configFile}, nil
 	}
 
-	return nil
+	return nil, nil
 }
 
 func startV2Ray() (core.Server, error) {
@@ -11,21 +11,26 @@ import (
 	// Default commander and all its services. This is an optional feature.
 	_ "github.com/v2fly/v2ray-core/v4/app/commander"
 	_ "github.com/v2fly/v2ray-core/v4/app/log/command"
+	_ "github.com/v2fly/v2ray-core/v4/app/policy/command"
 	_ "github.com/v2fly/v2ray-core/v4/app/proxyman/command"
 	_ "github.com/v2fly/v2ray-core/v4/app/stats/command"
 
 	// Developer preview services
 	_ "github.com/v2fly/v2ray-core/v4/app/instman/command"
 	_ "github.com/v2fly/v2ray-core/v4/app/observatory/command"
+	_ "github.com/v2fly/v2ray-core/v4/app/reverse/command"
 
 	// Other optional features.
 	_ "github.com/v2fly/v2ray-core/v4/app/dns"
 	_ "github.com/v2fly/v2ray-core/v4/app/dns/fakedns"
 	_ "github.com/v2fly/v2ray-core/v4/app/log"
+	_ "github.com/v2fly/v2ray-core/v4/app/metrics"
 	_ "github.com/v2fly/v2ray-core/v4/app/policy"
+	_ "github.com/v2fly/v2ray-core/v4/app/quota"
 	_ "github.com/v2fly/v2ray-core/v4/app/reverse"
 	_ "github.com/v2fly/v2ray-core/v4/app/router"
 	_ "github.com/v2fly/v2ray-core/v4/app/stats"
+	_ "github.com/v2fly/v2ray-core/v4/app/tracing"
 
 	// Fix dependency cycle caused by core import in internet package
 	_ "github.com/v2fly/v2ray-core/v4/transport/internet/tagged/taggedimpl"
@@ -33,6 +38,7 @@ import (
 	// Developer preview features
 	_ "github.com/v2fly/v2ray-core/v4/app/instman"
 	_ "github.com/v2fly/v2ray-core/v4/app/observatory"
+	_ "github.com/v2fly/v2ray-core/v4/app/persistentstorage/filesystemstorage"
 	_ "github.com/v2fly/v2ray-core/v4/app/restful-api"
 
 	// Inbound and outbound proxies.
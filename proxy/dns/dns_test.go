@@ -1,6 +1,7 @@
 package dns_test
 
 import (
+	"fmt"
 	"google.golang.org/protobuf/types/known/anypb"
 	"strconv"
 	"testing"
@@ -29,7 +30,7 @@ type staticHandler struct{}
 
 func (*staticHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 	ans := new(dns.Msg)
-	ans.Id = r.Id
+	ans.SetReply(r)
 
 	var clientIP net.IP
 
@@ -68,6 +69,18 @@ func (*staticHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 			common.Must(err)
 			ans.Answer = append(ans.Answer, rr)
 
+		case q.Name == "google.com." && q.Qtype == dns.TypeTXT:
+			rr, err := dns.NewRR("google.com. IN TXT \"v=spf1 -all\"")
+			common.Must(err)
+			ans.Answer = append(ans.Answer, rr)
+
+		case q.Name == "google.com." && q.Qtype == dns.TypeMX:
+			for i := 0; i < 40; i++ {
+				rr, err := dns.NewRR(fmt.Sprintf("google.com. IN MX %d mail%d.google.com.", i, i))
+				common.Must(err)
+				ans.Answer = append(ans.Answer, rr)
+			}
+
 		case q.Name == "notexist.google.com." && q.Qtype == dns.TypeAAAA:
 			ans.MsgHdr.Rcode = dns.RcodeNameError
 		}
@@ -0,0 +1,760 @@
+package dns
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+	"golang.org/x/net/http2"
+
+	core "github.com/v2fly/v2ray-core/v4"
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/protocol"
+	dns_proto "github.com/v2fly/v2ray-core/v4/common/protocol/dns"
+	"github.com/v2fly/v2ray-core/v4/common/session"
+	"github.com/v2fly/v2ray-core/v4/common/signal"
+	"github.com/v2fly/v2ray-core/v4/common/task"
+	"github.com/v2fly/v2ray-core/v4/features/dns"
+	"github.com/v2fly/v2ray-core/v4/features/policy"
+	"github.com/v2fly/v2ray-core/v4/features/routing"
+	"github.com/v2fly/v2ray-core/v4/transport/internet"
+)
+
+const (
+	// defaultTTL is the TTL of answers if not configured, as IPs are resolved without their TTLs.
+	defaultTTL = 600
+	// maxUDPSize is the UDP payload size advertised in EDNS0 (RFC6891), following the DNS flag day 2020.
+	maxUDPSize = 1232
+	// maxCNAMEChain is the maximum length of CNAME chains followed in answers.
+	maxCNAMEChain = 8
+	// upstreamTimeout is the timeout of forwarded queries.
+	upstreamTimeout = time.Second * 5
+	// maxStreamSize is the maximum size of messages on streams, which are prefixed by their 2 byte lengths (RFC7766).
+	maxStreamSize = 65535
+	// maxTXTStringSize is the maximum size of each string of TXT records.
+	maxTXTStringSize = 255
+	// maxPendingQueries is the maximum number of queries answered at the same time on each connection. Reading
+	// further queries waits for one of them to finish.
+	maxPendingQueries = 16
+)
+
+// Types of service binding records (RFC9460), which are not defined in dnsmessage.
+const (
+	typeSVCB  dnsmessage.Type = 64
+	typeHTTPS dnsmessage.Type = 65
+)
+
+func init() {
+	common.Must(common.RegisterConfig((*ServerConfig)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		return NewServer(ctx, config.(*ServerConfig))
+	}))
+
+	common.Must(common.RegisterConfig((*SimplifiedServerConfig)(nil), func(ctx context.Context, config interface{}) (interface{}, error) {
+		simplifiedServer := config.(*SimplifiedServerConfig)
+		fullConfig := &ServerConfig{
+			Networks: net.ParseNetworks(simplifiedServer.Network),
+			Upstream: simplifiedServer.Upstream,
+			DohPath:  simplifiedServer.DohPath,
+		}
+		return common.CreateObject(ctx, fullConfig)
+	}))
+}
+
+// Server is an inbound handler that answers DNS queries with the DNS client of V2Ray, so that it can serve as the
+// resolver of other hosts. Queries are accepted on UDP and TCP, and on TLS or as DNS over HTTPS with the stream
+// settings of the inbound.
+type Server struct {
+	config        *ServerConfig
+	client        dns.Client
+	policyManager policy.Manager
+	upstream      net.Destination
+	ttl           uint32
+}
+
+// NewServer creates a new DNS server inbound handler.
+func NewServer(ctx context.Context, config *ServerConfig) (*Server, error) {
+	s := &Server{
+		config: config,
+		ttl:    config.Ttl,
+	}
+	if s.ttl == 0 {
+		s.ttl = defaultTTL
+	}
+	if config.Upstream != nil {
+		s.upstream = config.Upstream.AsDestination()
+		if s.upstream.Network == net.Network_Unknown {
+			s.upstream.Network = net.Network_UDP
+		}
+	}
+	if err := core.RequireFeatures(ctx, func(dnsClient dns.Client, pm policy.Manager) error {
+		if _, ok := dnsClient.(dns.IPv4Lookup); !ok {
+			return newError("dns.Client doesn't implement IPv4Lookup")
+		}
+		if _, ok := dnsClient.(dns.IPv6Lookup); !ok {
+			return newError("dns.Client doesn't implement IPv6Lookup")
+		}
+		s.client = dnsClient
+		s.policyManager = pm
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Network implements proxy.Inbound.
+func (s *Server) Network() []net.Network {
+	if len(s.config.Networks) == 0 {
+		return []net.Network{net.Network_TCP, net.Network_UDP}
+	}
+	return s.config.Networks
+}
+
+// Process implements proxy.Inbound.
+func (s *Server) Process(ctx context.Context, network net.Network, conn internet.Connection, dispatcher routing.Dispatcher) error {
+	if inbound := session.InboundFromContext(ctx); inbound != nil {
+		inbound.User = &protocol.MemoryUser{
+			Level: s.config.UserLevel,
+		}
+	}
+	sessionPolicy := s.policyManager.ForLevel(s.config.UserLevel)
+
+	if network != net.Network_UDP && s.config.DohPath != "" {
+		return s.serveDoH(ctx, conn, dispatcher)
+	}
+
+	var reader dns_proto.MessageReader
+	var writer answerWriter
+	if network == net.Network_UDP {
+		reader = &dns_proto.UDPReader{
+			Reader: buf.NewPacketReader(conn),
+		}
+		writer = &udpAnswerWriter{
+			Writer: buf.NewWriter(conn),
+		}
+	} else {
+		reader = dns_proto.NewTCPReader(buf.NewReader(conn))
+		writer = &tcpAnswerWriter{
+			Writer: buf.NewWriter(conn),
+		}
+	}
+	var writeAccess sync.Mutex
+	pending := make(chan struct{}, maxPendingQueries)
+
+	ctx, cancel := context.WithCancel(ctx)
+	timer := signal.CancelAfterInactivity(ctx, cancel, sessionPolicy.Timeouts.ConnectionIdle)
+
+	request := func() error {
+		for {
+			b, err := reader.ReadMessage()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			timer.Update()
+
+			select {
+			case pending <- struct{}{}:
+			case <-ctx.Done():
+				b.Release()
+				return ctx.Err()
+			}
+			go func(b *buf.Buffer) {
+				defer func() { <-pending }()
+				defer b.Release()
+
+				resp := s.answer(ctx, b.Bytes(), network == net.Network_UDP, dispatcher)
+				if resp == nil {
+					return
+				}
+				writeAccess.Lock()
+				defer writeAccess.Unlock()
+				if err := writer.WriteMessage(resp); err != nil {
+					newError("failed to write answer").Base(err).WriteToLog(session.ExportIDToError(ctx))
+					return
+				}
+				timer.Update()
+			}(b)
+		}
+	}
+
+	if err := task.Run(ctx, request); err != nil {
+		return newError("connection ends").Base(err)
+	}
+	return nil
+}
+
+// answer returns the packed response of the query, or nil if the query should be dropped.
+func (s *Server) answer(ctx context.Context, query []byte, isUDP bool, dispatcher routing.Dispatcher) []byte {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(query)
+	if err != nil {
+		newError("failed to parse query").Base(err).AtDebug().WriteToLog(session.ExportIDToError(ctx))
+		return nil
+	}
+	if header.Response {
+		return nil
+	}
+
+	resp := &dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:                 header.ID,
+			Response:           true,
+			OpCode:             header.OpCode,
+			RecursionDesired:   header.RecursionDesired,
+			RecursionAvailable: true,
+		},
+	}
+	questions, err := parser.AllQuestions()
+	if err != nil || len(questions) != 1 {
+		resp.RCode = dnsmessage.RCodeFormatError
+		return s.pack(resp, nil, isUDP)
+	}
+	resp.Questions = questions
+
+	// Find the EDNS0 OPT record of the query, if any.
+	var opt *dnsmessage.ResourceHeader
+	if err := parser.SkipAllAnswers(); err == nil {
+		if err := parser.SkipAllAuthorities(); err == nil {
+			for {
+				h, err := parser.AdditionalHeader()
+				if err != nil {
+					break
+				}
+				if h.Type == dnsmessage.TypeOPT {
+					opt = &h
+				}
+				if err := parser.SkipAdditional(); err != nil {
+					break
+				}
+			}
+		}
+	}
+
+	q := questions[0]
+	switch {
+	case header.OpCode != 0:
+		resp.RCode = dnsmessage.RCodeNotImplemented
+	case q.Class != dnsmessage.ClassINET:
+		resp.RCode = dnsmessage.RCodeNotImplemented
+	case q.Type == dnsmessage.TypeA || q.Type == dnsmessage.TypeAAAA:
+		s.answerIP(ctx, resp, q)
+	case q.Type == dnsmessage.TypeCNAME:
+		if !s.answerCNAME(resp, q) {
+			return s.forward(ctx, resp, query, opt, isUDP, dispatcher)
+		}
+	case q.Type == dnsmessage.TypePTR:
+		if !s.answerPTR(resp, q) {
+			return s.forward(ctx, resp, query, opt, isUDP, dispatcher)
+		}
+	case q.Type == dnsmessage.TypeTXT:
+		if !s.answerTXT(ctx, resp, q) {
+			return s.forward(ctx, resp, query, opt, isUDP, dispatcher)
+		}
+	default:
+		return s.forward(ctx, resp, query, opt, isUDP, dispatcher)
+	}
+	return s.pack(resp, opt, isUDP)
+}
+
+func (s *Server) resourceHeader(name dnsmessage.Name, t dnsmessage.Type) dnsmessage.ResourceHeader {
+	return dnsmessage.ResourceHeader{
+		Name:  name,
+		Type:  t,
+		Class: dnsmessage.ClassINET,
+		TTL:   s.ttl,
+	}
+}
+
+// cname returns the name that the name is an alias of in the DNS client, or false if it is not an alias.
+func (s *Server) cname(name dnsmessage.Name) (dnsmessage.Name, bool) {
+	lookup, ok := s.client.(dns.CNAMELookup)
+	if !ok {
+		return dnsmessage.Name{}, false
+	}
+	target := lookup.LookupCNAME(name.String())
+	if target == "" {
+		return dnsmessage.Name{}, false
+	}
+	targetName, err := dnsmessage.NewName(fqdn(target))
+	if err != nil {
+		return dnsmessage.Name{}, false
+	}
+	return targetName, true
+}
+
+func (s *Server) answerIP(ctx context.Context, resp *dnsmessage.Message, q dnsmessage.Question) {
+	name := q.Name
+	for i := 0; i < maxCNAMEChain; i++ {
+		target, ok := s.cname(name)
+		if !ok {
+			break
+		}
+		resp.Answers = append(resp.Answers, dnsmessage.Resource{
+			Header: s.resourceHeader(name, dnsmessage.TypeCNAME),
+			Body:   &dnsmessage.CNAMEResource{CNAME: target},
+		})
+		name = target
+	}
+
+	domain := strings.TrimSuffix(name.String(), ".")
+	ips, err := s.lookupIP(ctx, domain, q.Type)
+	if rcode := dns.RCodeFromError(err); rcode != 0 {
+		resp.RCode = dnsmessage.RCode(rcode)
+		return
+	}
+	if len(ips) == 0 && err != nil && err != dns.ErrEmptyResponse {
+		newError("failed to lookup IP of ", domain).Base(err).WriteToLog()
+		resp.RCode = dnsmessage.RCodeServerFailure
+		return
+	}
+
+	for _, ip := range ips {
+		ip4 := ip.To4()
+		switch {
+		case ip4 != nil && q.Type == dnsmessage.TypeA:
+			var r dnsmessage.AResource
+			copy(r.A[:], ip4)
+			resp.Answers = append(resp.Answers, dnsmessage.Resource{
+				Header: s.resourceHeader(name, dnsmessage.TypeA),
+				Body:   &r,
+			})
+		case ip4 == nil && len(ip) == net.IPv6len && q.Type == dnsmessage.TypeAAAA:
+			var r dnsmessage.AAAAResource
+			copy(r.AAAA[:], ip)
+			resp.Answers = append(resp.Answers, dnsmessage.Resource{
+				Header: s.resourceHeader(name, dnsmessage.TypeAAAA),
+				Body:   &r,
+			})
+		}
+	}
+}
+
+// lookupIP looks up the IPs of the type of the domain. FakeDNS is not skipped, as the IPs are answered to clients
+// which connect to them through V2Ray. It is enabled for this lookup only, leaving other users of the client alone.
+func (s *Server) lookupIP(ctx context.Context, domain string, t dnsmessage.Type) ([]net.IP, error) {
+	lookup, ok := s.client.(dns.ContextIPLookup)
+	if !ok {
+		if t == dnsmessage.TypeA {
+			return s.client.(dns.IPv4Lookup).LookupIPv4(domain)
+		}
+		return s.client.(dns.IPv6Lookup).LookupIPv6(domain)
+	}
+	option := dns.IPOption{
+		IPv4Enable: t == dnsmessage.TypeA,
+		IPv6Enable: t == dnsmessage.TypeAAAA,
+		FakeEnable: true,
+	}
+	// The query strategy of the client still applies.
+	if c, ok := s.client.(dns.ClientWithIPOption); ok {
+		if current := c.GetIPOption(); current != nil {
+			option.IPv4Enable = option.IPv4Enable && current.IPv4Enable
+			option.IPv6Enable = option.IPv6Enable && current.IPv6Enable
+		}
+	}
+	if !option.IPv4Enable && !option.IPv6Enable {
+		return nil, dns.ErrEmptyResponse
+	}
+	return lookup.LookupIPContext(ctx, domain, option)
+}
+
+// answerCNAME answers the CNAME query with static hosts. It returns false if the domain is not an alias.
+func (s *Server) answerCNAME(resp *dnsmessage.Message, q dnsmessage.Question) bool {
+	target, ok := s.cname(q.Name)
+	if !ok {
+		return false
+	}
+	resp.Answers = append(resp.Answers, dnsmessage.Resource{
+		Header: s.resourceHeader(q.Name, dnsmessage.TypeCNAME),
+		Body:   &dnsmessage.CNAMEResource{CNAME: target},
+	})
+	return true
+}
+
+// answerPTR answers the PTR query with static hosts and FakeDNS. It returns false if no domains are known.
+func (s *Server) answerPTR(resp *dnsmessage.Message, q dnsmessage.Question) bool {
+	lookup, ok := s.client.(dns.PTRLookup)
+	if !ok {
+		return false
+	}
+	ip := parseReverseName(q.Name.String())
+	if ip == nil {
+		return false
+	}
+	for _, domain := range lookup.LookupPTR(ip) {
+		name, err := dnsmessage.NewName(fqdn(domain))
+		if err != nil {
+			continue
+		}
+		resp.Answers = append(resp.Answers, dnsmessage.Resource{
+			Header: s.resourceHeader(q.Name, dnsmessage.TypePTR),
+			Body:   &dnsmessage.PTRResource{PTR: name},
+		})
+	}
+	return len(resp.Answers) > 0
+}
+
+// answerTXT answers the TXT query with the DNS client. It returns false if the DNS client cannot look up text records,
+// or fails to, while there is an upstream to forward the query to.
+func (s *Server) answerTXT(ctx context.Context, resp *dnsmessage.Message, q dnsmessage.Question) bool {
+	lookup, ok := s.client.(dns.TXTLookup)
+	if !ok {
+		return false
+	}
+	name := q.Name
+	for i := 0; i < maxCNAMEChain; i++ {
+		target, ok := s.cname(name)
+		if !ok {
+			break
+		}
+		resp.Answers = append(resp.Answers, dnsmessage.Resource{
+			Header: s.resourceHeader(name, dnsmessage.TypeCNAME),
+			Body:   &dnsmessage.CNAMEResource{CNAME: target},
+		})
+		name = target
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, upstreamTimeout)
+	defer cancel()
+	domain := strings.TrimSuffix(name.String(), ".")
+	txts, err := lookup.LookupTXT(ctx, domain)
+	if rcode := dns.RCodeFromError(err); rcode != 0 {
+		resp.RCode = dnsmessage.RCode(rcode)
+		return true
+	}
+	if len(txts) == 0 && err != nil && err != dns.ErrEmptyResponse {
+		if s.upstream.Address != nil {
+			resp.Answers = nil
+			return false
+		}
+		newError("failed to lookup TXT of ", domain).Base(err).WriteToLog(session.ExportIDToError(ctx))
+		resp.RCode = dnsmessage.RCodeServerFailure
+		return true
+	}
+	for _, txt := range txts {
+		resp.Answers = append(resp.Answers, dnsmessage.Resource{
+			Header: s.resourceHeader(name, dnsmessage.TypeTXT),
+			Body:   &dnsmessage.TXTResource{TXT: splitTXT(txt)},
+		})
+	}
+	return true
+}
+
+// splitTXT splits the text into the strings of a TXT record, which are limited to 255 bytes each.
+func splitTXT(txt string) []string {
+	strs := make([]string, 0, len(txt)/maxTXTStringSize+1)
+	for len(txt) > maxTXTStringSize {
+		strs = append(strs, txt[:maxTXTStringSize])
+		txt = txt[maxTXTStringSize:]
+	}
+	return append(strs, txt)
+}
+
+func fqdn(domain string) string {
+	if strings.HasSuffix(domain, ".") {
+		return domain
+	}
+	return domain + "."
+}
+
+// parseReverseName returns the IP of a name in the in-addr.arpa or ip6.arpa domain, or nil if the name is invalid.
+func parseReverseName(name string) net.IP {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	switch {
+	case strings.HasSuffix(name, ".in-addr.arpa"):
+		labels := strings.Split(strings.TrimSuffix(name, ".in-addr.arpa"), ".")
+		if len(labels) != net.IPv4len {
+			return nil
+		}
+		for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+			labels[i], labels[j] = labels[j], labels[i]
+		}
+		return net.ParseIP(strings.Join(labels, ".")).To4()
+	case strings.HasSuffix(name, ".ip6.arpa"):
+		nibbles := strings.Split(strings.TrimSuffix(name, ".ip6.arpa"), ".")
+		if len(nibbles) != net.IPv6len*2 {
+			return nil
+		}
+		ip := make(net.IP, net.IPv6len)
+		for i, nibble := range nibbles {
+			if len(nibble) != 1 {
+				return nil
+			}
+			v := strings.Index("0123456789abcdef", nibble)
+			if v < 0 {
+				return nil
+			}
+			// Nibbles are in reverse order, the lowest first.
+			k := len(nibbles) - 1 - i
+			ip[k/2] |= byte(v) << (4 * uint(1-k%2))
+		}
+		return ip
+	default:
+		return nil
+	}
+}
+
+// forward sends the query to the upstream server, and returns its response. Without an upstream, HTTPS and SVCB
+// queries are answered with no records so that clients fall back to A and AAAA queries.
+func (s *Server) forward(ctx context.Context, resp *dnsmessage.Message, query []byte, opt *dnsmessage.ResourceHeader, isUDP bool, dispatcher routing.Dispatcher) []byte {
+	if s.upstream.Address == nil {
+		switch resp.Questions[0].Type {
+		case typeHTTPS, typeSVCB, dnsmessage.TypeCNAME:
+		case dnsmessage.TypePTR:
+			resp.RCode = dnsmessage.RCodeNameError
+		default:
+			resp.RCode = dnsmessage.RCodeNotImplemented
+		}
+		return s.pack(resp, opt, isUDP)
+	}
+
+	reply, err := s.exchange(ctx, query, dispatcher)
+	if err != nil {
+		newError("failed to forward query to ", s.upstream).Base(err).WriteToLog(session.ExportIDToError(ctx))
+		resp.RCode = dnsmessage.RCodeServerFailure
+		return s.pack(resp, opt, isUDP)
+	}
+	if len(reply) > maxSize(opt, isUDP) {
+		// Tell the client to retry on TCP.
+		resp.Truncated = true
+		return s.pack(resp, opt, isUDP)
+	}
+	return reply
+}
+
+func (s *Server) exchange(ctx context.Context, query []byte, dispatcher routing.Dispatcher) ([]byte, error) {
+	ctx = session.ContextWithContent(ctx, &session.Content{
+		Protocol:       "dns",
+		SkipDNSResolve: true,
+	})
+	ctx, cancel := context.WithTimeout(ctx, upstreamTimeout)
+	defer cancel()
+
+	link, err := dispatcher.Dispatch(ctx, s.upstream)
+	if err != nil {
+		return nil, err
+	}
+	defer common.Close(link.Writer)
+	defer common.Interrupt(link.Reader)
+
+	var reader dns_proto.MessageReader
+	var writer dns_proto.MessageWriter
+	if s.upstream.Network == net.Network_TCP {
+		reader = dns_proto.NewTCPReader(link.Reader)
+		writer = &dns_proto.TCPWriter{Writer: link.Writer}
+	} else {
+		reader = &dns_proto.UDPReader{Reader: link.Reader}
+		writer = &dns_proto.UDPWriter{Writer: link.Writer}
+	}
+
+	b := buf.New()
+	if _, err := b.Write(query); err != nil {
+		b.Release()
+		return nil, err
+	}
+	if err := writer.WriteMessage(b); err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		reply []byte
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		b, err := reader.ReadMessage()
+		if err != nil {
+			done <- result{err: err}
+			return
+		}
+		reply := append([]byte(nil), b.Bytes()...)
+		b.Release()
+		done <- result{reply: reply}
+	}()
+	select {
+	case r := <-done:
+		return r.reply, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// maxSize returns the maximum size of responses. UDP responses are limited by the payload size of EDNS0 (RFC6891),
+// and responses on streams by their length prefixes.
+func maxSize(opt *dnsmessage.ResourceHeader, isUDP bool) int {
+	if !isUDP {
+		return maxStreamSize
+	}
+	size := 512
+	if opt != nil && int(opt.Class) > size {
+		size = int(opt.Class)
+	}
+	if size > maxUDPSize {
+		size = maxUDPSize
+	}
+	return size
+}
+
+// pack packs the response, with an OPT record if the query has one. Responses that are too large are truncated.
+func (s *Server) pack(resp *dnsmessage.Message, opt *dnsmessage.ResourceHeader, isUDP bool) []byte {
+	if opt != nil {
+		var h dnsmessage.ResourceHeader
+		common.Must(h.SetEDNS0(maxUDPSize, resp.RCode, false))
+		resp.Additionals = append(resp.Additionals, dnsmessage.Resource{
+			Header: h,
+			Body:   &dnsmessage.OPTResource{},
+		})
+	}
+	b, err := resp.Pack()
+	if err == nil && len(b) > maxSize(opt, isUDP) {
+		resp.Truncated = true
+		resp.Answers = nil
+		resp.Authorities = nil
+		b, err = resp.Pack()
+	}
+	if err != nil {
+		newError("failed to pack response").Base(err).WriteToLog()
+		return nil
+	}
+	return b
+}
+
+func (s *Server) serveDoH(ctx context.Context, conn internet.Connection, dispatcher routing.Dispatcher) error {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.handleDoH(ctx, w, r, dispatcher)
+	})
+
+	reader := bufio.NewReader(conn)
+	bufferedConn := &bufferedConn{Connection: conn, reader: reader}
+	if preface, err := reader.Peek(len(http2.ClientPreface)); err == nil && string(preface) == http2.ClientPreface {
+		(&http2.Server{}).ServeConn(bufferedConn, &http2.ServeConnOpts{
+			Context: ctx,
+			Handler: handler,
+		})
+		return nil
+	}
+
+	closed := make(chan struct{})
+	server := &http.Server{
+		Handler: handler,
+		ConnState: func(_ net.Conn, state http.ConnState) {
+			if state == http.StateClosed || state == http.StateHijacked {
+				close(closed)
+			}
+		},
+	}
+	go server.Serve(&singleConnListener{conn: bufferedConn})
+	select {
+	case <-closed:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+func (s *Server) handleDoH(ctx context.Context, w http.ResponseWriter, r *http.Request, dispatcher routing.Dispatcher) {
+	if r.URL.Path != s.config.DohPath {
+		http.NotFound(w, r)
+		return
+	}
+
+	var query []byte
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+		query, err = base64.RawURLEncoding.DecodeString(r.URL.Query().Get("dns"))
+	case http.MethodPost:
+		if r.Header.Get("Content-Type") != "application/dns-message" {
+			http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+			return
+		}
+		query, err = io.ReadAll(io.LimitReader(r.Body, 65535))
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil || len(query) == 0 {
+		http.Error(w, "invalid query", http.StatusBadRequest)
+		return
+	}
+
+	resp := s.answer(ctx, query, false, dispatcher)
+	if resp == nil {
+		http.Error(w, "invalid query", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/dns-message")
+	w.Write(resp)
+}
+
+// answerWriter writes packed answers. Unlike dns_proto.MessageWriter, answers on streams may be larger than a buffer.
+type answerWriter interface {
+	WriteMessage(b []byte) error
+}
+
+type udpAnswerWriter struct {
+	buf.Writer
+}
+
+func (w *udpAnswerWriter) WriteMessage(b []byte) error {
+	packet := buf.New()
+	if _, err := packet.Write(b); err != nil {
+		packet.Release()
+		return newError("answer too large").Base(err)
+	}
+	return w.WriteMultiBuffer(buf.MultiBuffer{packet})
+}
+
+type tcpAnswerWriter struct {
+	buf.Writer
+}
+
+func (w *tcpAnswerWriter) WriteMessage(b []byte) error {
+	mb := buf.MergeBytes(nil, []byte{byte(len(b) >> 8), byte(len(b))})
+	mb = buf.MergeBytes(mb, b)
+	return w.WriteMultiBuffer(mb)
+}
+
+type bufferedConn struct {
+	internet.Connection
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// singleConnListener is a net.Listener that accepts the connection only.
+type singleConnListener struct {
+	access sync.Mutex
+	conn   net.Conn
+}
+
+func (l *singleConnListener) Accept() (net.Conn, error) {
+	l.access.Lock()
+	defer l.access.Unlock()
+
+	if l.conn == nil {
+		return nil, io.EOF
+	}
+	conn := l.conn
+	l.conn = nil
+	return conn, nil
+}
+
+func (l *singleConnListener) Close() error {
+	return nil
+}
+
+func (l *singleConnListener) Addr() net.Addr {
+	return &net.TCPAddr{}
+}
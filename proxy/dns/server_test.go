@@ -0,0 +1,235 @@
+package dns_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/miekg/dns"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	core "github.com/v2fly/v2ray-core/v4"
+	"github.com/v2fly/v2ray-core/v4/app/dispatcher"
+	dnsapp "github.com/v2fly/v2ray-core/v4/app/dns"
+	"github.com/v2fly/v2ray-core/v4/app/policy"
+	"github.com/v2fly/v2ray-core/v4/app/proxyman"
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/serial"
+	feature_dns "github.com/v2fly/v2ray-core/v4/features/dns"
+	dns_proxy "github.com/v2fly/v2ray-core/v4/proxy/dns"
+	"github.com/v2fly/v2ray-core/v4/proxy/freedom"
+	"github.com/v2fly/v2ray-core/v4/testing/servers/tcp"
+	"github.com/v2fly/v2ray-core/v4/testing/servers/udp"
+)
+
+func TestDNSServer(t *testing.T) {
+	port := udp.PickPort()
+
+	dnsServer := dns.Server{
+		Addr:    "127.0.0.1:" + port.String(),
+		Net:     "udp",
+		Handler: &staticHandler{},
+	}
+	defer dnsServer.Shutdown()
+
+	go dnsServer.ListenAndServe()
+	time.Sleep(time.Second)
+
+	upstream := &net.Endpoint{
+		Network: net.Network_UDP,
+		Address: net.NewIPOrDomain(net.LocalHostIP),
+		Port:    uint32(port),
+	}
+	many := make([][]byte, 0, 100)
+	for i := 0; i < 100; i++ {
+		many = append(many, []byte{10, 0, 0, byte(i)})
+	}
+	more := make([][]byte, 0, 300)
+	for i := 0; i < 300; i++ {
+		more = append(more, []byte{10, 0, byte(i >> 8), byte(i)})
+	}
+
+	serverPort := tcp.PickPort()
+	localPort := tcp.PickPort()
+	config := &core.Config{
+		App: []*anypb.Any{
+			serial.ToTypedMessage(&dnsapp.Config{
+				NameServer: []*dnsapp.NameServer{
+					{Address: upstream},
+				},
+				StaticHosts: []*dnsapp.HostMapping{
+					{
+						Type:   dnsapp.DomainMatchingType_Full,
+						Domain: "v2fly.org",
+						Ip:     [][]byte{{1, 2, 3, 4}},
+					},
+					{
+						Type:          dnsapp.DomainMatchingType_Full,
+						Domain:        "www.v2fly.org",
+						ProxiedDomain: "v2fly.org",
+					},
+					{
+						Type:   dnsapp.DomainMatchingType_Full,
+						Domain: "many.v2fly.org",
+						Ip:     many,
+					},
+					{
+						Type:   dnsapp.DomainMatchingType_Full,
+						Domain: "more.v2fly.org",
+						Ip:     more,
+					},
+				},
+			}),
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&policy.Config{}),
+		},
+		Inbound: []*core.InboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&dns_proxy.ServerConfig{
+					Upstream: upstream,
+				}),
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: net.SinglePortRange(serverPort),
+					Listen:    net.NewIPOrDomain(net.LocalHostIP),
+				}),
+			},
+			{
+				ProxySettings: serial.ToTypedMessage(&dns_proxy.ServerConfig{}),
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: net.SinglePortRange(localPort),
+					Listen:    net.NewIPOrDomain(net.LocalHostIP),
+				}),
+			},
+		},
+		Outbound: []*core.OutboundHandlerConfig{
+			{
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+
+	v, err := core.New(config)
+	common.Must(err)
+	common.Must(v.Start())
+	defer v.Close()
+
+	server := "127.0.0.1:" + serverPort.String()
+	udpClient := &dns.Client{Net: "udp"}
+	tcpClient := &dns.Client{Net: "tcp"}
+
+	{
+		m := new(dns.Msg).SetQuestion("www.v2fly.org.", dns.TypeA)
+		in, _, err := udpClient.Exchange(m, server)
+		common.Must(err)
+		if len(in.Answer) != 2 {
+			t.Fatal("unexpected answers: ", in.Answer)
+		}
+		if rr, ok := in.Answer[0].(*dns.CNAME); !ok || rr.Target != "v2fly.org." {
+			t.Error("unexpected CNAME record: ", in.Answer[0])
+		}
+		if rr, ok := in.Answer[1].(*dns.A); !ok || rr.Hdr.Name != "v2fly.org." || !rr.A.Equal(net.IP{1, 2, 3, 4}) {
+			t.Error("unexpected A record: ", in.Answer[1])
+		}
+	}
+
+	{
+		m := new(dns.Msg).SetQuestion("google.com.", dns.TypeA)
+		m.SetEdns0(4096, false)
+		in, _, err := tcpClient.Exchange(m, server)
+		common.Must(err)
+		if len(in.Answer) != 1 {
+			t.Fatal("unexpected answers: ", in.Answer)
+		}
+		if r := cmp.Diff(in.Answer[0].(*dns.A).A[:], net.IP{8, 8, 8, 8}); r != "" {
+			t.Error(r)
+		}
+		if opt := in.IsEdns0(); opt == nil || opt.UDPSize() != 1232 {
+			t.Error("unexpected OPT record: ", opt)
+		}
+	}
+
+	{
+		m := new(dns.Msg).SetQuestion("4.3.2.1.in-addr.arpa.", dns.TypePTR)
+		in, _, err := udpClient.Exchange(m, server)
+		common.Must(err)
+		if len(in.Answer) != 1 || in.Answer[0].(*dns.PTR).Ptr != "v2fly.org." {
+			t.Error("unexpected PTR answers: ", in.Answer)
+		}
+	}
+
+	{
+		m := new(dns.Msg).SetQuestion("google.com.", dns.TypeTXT)
+		in, _, err := udpClient.Exchange(m, server)
+		common.Must(err)
+		if len(in.Answer) != 1 || in.Answer[0].(*dns.TXT).Txt[0] != "v=spf1 -all" {
+			t.Error("unexpected TXT answers: ", in.Answer)
+		}
+	}
+
+	{
+		m := new(dns.Msg).SetQuestion("many.v2fly.org.", dns.TypeA)
+		in, _, err := udpClient.Exchange(m, server)
+		common.Must(err)
+		if !in.Truncated || len(in.Answer) != 0 {
+			t.Error("expected truncated answer, but got ", len(in.Answer), " answers")
+		}
+
+		in, _, err = tcpClient.Exchange(m, server)
+		common.Must(err)
+		if in.Truncated || len(in.Answer) != 100 {
+			t.Error("expected 100 answers, but got ", len(in.Answer))
+		}
+	}
+
+	{
+		m := new(dns.Msg).SetQuestion("more.v2fly.org.", dns.TypeA)
+		in, _, err := tcpClient.Exchange(m, server)
+		common.Must(err)
+		if in.Truncated || len(in.Answer) != 300 {
+			t.Error("expected 300 answers over TCP, but got ", len(in.Answer))
+		}
+	}
+
+	{
+		m := new(dns.Msg).SetQuestion("google.com.", dns.TypeMX)
+		in, _, err := udpClient.Exchange(m, server)
+		common.Must(err)
+		if !in.Truncated || len(in.Answer) != 0 {
+			t.Error("expected forwarded answer to be truncated, but got ", len(in.Answer), " answers")
+		}
+
+		in, _, err = tcpClient.Exchange(m, server)
+		common.Must(err)
+		if in.Truncated || len(in.Answer) != 40 {
+			t.Error("expected 40 forwarded answers, but got ", len(in.Answer))
+		}
+	}
+
+	// Answering queries does not change the options of the DNS client shared with other users.
+	if option := v.GetFeature(feature_dns.ClientType()).(feature_dns.ClientWithIPOption).GetIPOption(); option.FakeEnable {
+		t.Error("FakeDNS is enabled on the DNS client by answering queries")
+	}
+
+	// Without an upstream, TXT queries are answered by the DNS app.
+	local := "127.0.0.1:" + localPort.String()
+	{
+		m := new(dns.Msg).SetQuestion("google.com.", dns.TypeTXT)
+		in, _, err := udpClient.Exchange(m, local)
+		common.Must(err)
+		if in.Rcode != dns.RcodeSuccess || len(in.Answer) != 1 || in.Answer[0].(*dns.TXT).Txt[0] != "v=spf1 -all" {
+			t.Error("unexpected TXT answers: ", in)
+		}
+	}
+
+	{
+		m := new(dns.Msg).SetQuestion("v2fly.org.", dns.TypeTXT)
+		in, _, err := udpClient.Exchange(m, local)
+		common.Must(err)
+		if in.Rcode != dns.RcodeSuccess || len(in.Answer) != 0 {
+			t.Error("expected no TXT answers of static hosts, but got ", in)
+		}
+	}
+}
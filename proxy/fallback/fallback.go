@@ -0,0 +1,209 @@
+// Package fallback forwards the connections an inbound fails to authenticate to other servers, such as web servers,
+// so that the inbound looks like them to active probes.
+package fallback
+
+//go:generate go run github.com/v2fly/v2ray-core/v4/common/errors/errorgen
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/retry"
+	"github.com/v2fly/v2ray-core/v4/common/session"
+	"github.com/v2fly/v2ray-core/v4/common/signal"
+	"github.com/v2fly/v2ray-core/v4/common/task"
+	"github.com/v2fly/v2ray-core/v4/features/policy"
+	"github.com/v2fly/v2ray-core/v4/transport/internet"
+	"github.com/v2fly/v2ray-core/v4/transport/internet/tls"
+)
+
+// Fallbacks are the fallbacks of an inbound by ALPN and path.
+type Fallbacks map[string]map[string]*Fallback
+
+// New creates Fallbacks of the configs, or returns nil if there is none. The paths of the fallbacks of any ALPN are
+// also of the other ALPNs, unless they have their own.
+func New(configs []*Fallback) Fallbacks {
+	if len(configs) == 0 {
+		return nil
+	}
+	f := make(Fallbacks)
+	for _, fb := range configs {
+		if f[fb.Alpn] == nil {
+			f[fb.Alpn] = make(map[string]*Fallback)
+		}
+		f[fb.Alpn][fb.Path] = fb
+	}
+	for alpn, paths := range f {
+		if alpn == "" {
+			continue
+		}
+		for path, fb := range f[""] {
+			if paths[path] == nil {
+				paths[path] = fb
+			}
+		}
+	}
+	return f
+}
+
+// find returns the fallback of the ALPN, or of any ALPN if there is none of it, and then of the path within them, or
+// of any path if there is none of it.
+func (f Fallbacks) find(alpn, path string) *Fallback {
+	paths := f[alpn]
+	if paths == nil {
+		paths = f[""]
+	}
+	if fb := paths[path]; fb != nil {
+		return fb
+	}
+	return paths[""]
+}
+
+// requestPath returns the path of the HTTP/1 request the bytes start with, or empty if they are not of one.
+func requestPath(b []byte) string {
+	if len(b) < 18 || b[4] == '*' { // not h2c
+		return ""
+	}
+	for i := 4; i <= 8; i++ { // after the method
+		if b[i] == '/' && b[i-1] == ' ' {
+			end := len(b)
+			if end > 64 {
+				end = 64
+			}
+			for j := i + 1; j < end; j++ {
+				switch b[j] {
+				case '\r', '\n':
+					return ""
+				case ' ':
+					return string(b[i:j])
+				}
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// proxyHeader returns the header of the PROXY protocol of the version, for the connection from remote to local.
+func proxyHeader(version uint64, remote, local net.Addr) ([]byte, error) {
+	remoteHost, remotePort, err := net.SplitHostPort(remote.String())
+	if err != nil {
+		return nil, err
+	}
+	localHost, localPort, err := net.SplitHostPort(local.String())
+	if err != nil {
+		return nil, err
+	}
+	remoteIP := net.ParseIP(remoteHost)
+	localIP := net.ParseIP(localHost)
+	if remoteIP == nil || localIP == nil {
+		return nil, newError("not a connection between IP addresses: ", remote, " -> ", local)
+	}
+	ipv4 := remoteIP.To4() != nil && localIP.To4() != nil
+	if ipv4 {
+		remoteIP = remoteIP.To4()
+		localIP = localIP.To4()
+	} else {
+		remoteIP = remoteIP.To16()
+		localIP = localIP.To16()
+	}
+
+	switch version {
+	case 1:
+		family := "TCP6"
+		if ipv4 {
+			family = "TCP4"
+		}
+		return []byte("PROXY " + family + " " + remoteIP.String() + " " + localIP.String() + " " + remotePort + " " + localPort + "\r\n"), nil
+	case 2:
+		header := []byte("\x0D\x0A\x0D\x0A\x00\x0D\x0A\x51\x55\x49\x54\x0A\x21") // signature + v2 + PROXY
+		if ipv4 {
+			header = append(header, 0x11, 0x00, 0x0C) // AF_INET + STREAM + 12 bytes
+		} else {
+			header = append(header, 0x21, 0x00, 0x24) // AF_INET6 + STREAM + 36 bytes
+		}
+		header = append(header, remoteIP...)
+		header = append(header, localIP...)
+		p1, _ := strconv.ParseUint(remotePort, 10, 16)
+		p2, _ := strconv.ParseUint(localPort, 10, 16)
+		return append(header, byte(p1>>8), byte(p1), byte(p2>>8), byte(p2)), nil
+	default:
+		return nil, newError("unknown PROXY protocol version: ", version)
+	}
+}
+
+// Serve forwards the connection to its fallback, along with what the reader has read from it.
+func (f Fallbacks) Serve(ctx context.Context, sessionPolicy policy.Session, connection internet.Connection, reader *Reader) error {
+	sid := session.ExportIDToError(ctx)
+	if err := connection.SetReadDeadline(time.Time{}); err != nil {
+		newError("unable to set back read deadline").Base(err).AtWarning().WriteToLog(sid)
+	}
+
+	requestReader, head := reader.replay()
+	alpn := tls.NegotiatedProtocol(connection)
+	path := requestPath(head)
+	fb := f.find(alpn, path)
+	if fb == nil {
+		buf.ReleaseMulti(requestReader.Buffer)
+		return newError("no fallback of alpn ", alpn, " and path ", path).AtWarning()
+	}
+	newError("fallback to ", fb.Dest, " for alpn ", alpn, " and path ", path).AtInfo().WriteToLog(sid)
+
+	ctx, cancel := context.WithCancel(ctx)
+	timer := signal.CancelAfterInactivity(ctx, cancel, sessionPolicy.Timeouts.ConnectionIdle)
+	ctx = policy.ContextWithBufferPolicy(ctx, sessionPolicy.Buffer)
+
+	var conn net.Conn
+	if err := retry.ExponentialBackoff(5, 100).On(func() error {
+		var dialer net.Dialer
+		var err error
+		conn, err = dialer.DialContext(ctx, fb.Type, fb.Dest)
+		return err
+	}); err != nil {
+		buf.ReleaseMulti(requestReader.Buffer)
+		return newError("failed to dial to ", fb.Dest).Base(err).AtWarning()
+	}
+	defer conn.Close()
+
+	serverReader := buf.NewReader(conn)
+	serverWriter := buf.NewWriter(conn)
+
+	postRequest := func() error {
+		defer timer.SetTimeout(sessionPolicy.Timeouts.DownlinkOnly)
+		if fb.Xver != 0 {
+			header, err := proxyHeader(fb.Xver, connection.RemoteAddr(), connection.LocalAddr())
+			if err != nil {
+				return newError("failed to set PROXY protocol v", fb.Xver).Base(err).AtWarning()
+			}
+			if err := serverWriter.WriteMultiBuffer(buf.MergeBytes(nil, header)); err != nil {
+				return newError("failed to set PROXY protocol v", fb.Xver).Base(err).AtWarning()
+			}
+		}
+		if err := buf.Copy(requestReader, serverWriter, buf.UpdateActivity(timer)); err != nil {
+			return newError("failed to fallback request payload").Base(err).AtInfo()
+		}
+		return nil
+	}
+
+	writer := buf.NewWriter(connection)
+
+	getResponse := func() error {
+		defer timer.SetTimeout(sessionPolicy.Timeouts.UplinkOnly)
+		if err := buf.Copy(serverReader, writer, buf.UpdateActivity(timer)); err != nil {
+			return newError("failed to deliver response payload").Base(err).AtInfo()
+		}
+		return nil
+	}
+
+	if err := task.Run(ctx, task.OnSuccess(postRequest, task.Close(serverWriter)), task.OnSuccess(getResponse, task.Close(writer))); err != nil {
+		common.Must(common.Interrupt(serverReader))
+		common.Must(common.Interrupt(serverWriter))
+		return newError("fallback ends").Base(err).AtInfo()
+	}
+
+	return nil
+}
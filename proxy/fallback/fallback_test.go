@@ -0,0 +1,158 @@
+package fallback
+
+import (
+	"bytes"
+	"context"
+	"io"
+	gonet "net"
+	"testing"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/features/policy"
+)
+
+func TestFind(t *testing.T) {
+	f := New([]*Fallback{
+		{Dest: "default"},
+		{Path: "/ws", Dest: "ws"},
+		{Alpn: "h2", Dest: "h2"},
+		{Alpn: "http/1.1", Path: "/h1", Dest: "h1"},
+	})
+	cases := []struct {
+		alpn string
+		path string
+		dest string
+	}{
+		{"", "", "default"},
+		{"", "/ws", "ws"},
+		{"", "/unknown", "default"},
+		{"h2", "", "h2"},
+		{"h2", "/ws", "ws"},
+		{"http/1.1", "/h1", "h1"},
+		{"http/1.1", "/ws", "ws"},
+		{"http/1.1", "", "default"},
+		{"unknown", "", "default"},
+	}
+	for _, c := range cases {
+		if fb := f.find(c.alpn, c.path); fb == nil || fb.Dest != c.dest {
+			t.Error("unexpected fallback of alpn ", c.alpn, " and path ", c.path, ": ", fb)
+		}
+	}
+
+	if New(nil) != nil {
+		t.Error("expected no fallbacks")
+	}
+	if fb := New([]*Fallback{{Path: "/ws", Dest: "ws"}}).find("", "/"); fb != nil {
+		t.Error("unexpected fallback: ", fb)
+	}
+}
+
+func TestRequestPath(t *testing.T) {
+	cases := []struct {
+		request string
+		path    string
+	}{
+		{"GET /v2fly HTTP/1.1\r\nHost: v2fly.org\r\n\r\n", "/v2fly"},
+		{"OPTIONS /v2fly/ws?ed=2048 HTTP/1.1\r\n\r\n", "/v2fly/ws?ed=2048"},
+		{"PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n", ""},
+		{"GET /v2fly\r\nHost: v2fly.org\r\n\r\n", ""},
+		{"GET /", ""},
+		{"\x16\x03\x01\x02\x00\x01\x00\x01\xfc\x03\x03\x00\x01\x02\x03\x04\x05", ""},
+	}
+	for _, c := range cases {
+		if path := requestPath([]byte(c.request)); path != c.path {
+			t.Error("unexpected path of ", c.request, ": ", path)
+		}
+	}
+}
+
+func TestProxyHeader(t *testing.T) {
+	remote := &net.TCPAddr{IP: net.IP{10, 0, 0, 1}, Port: 12345}
+	local := &net.TCPAddr{IP: net.IP{10, 0, 0, 2}, Port: 443}
+
+	header, err := proxyHeader(1, remote, local)
+	common.Must(err)
+	if string(header) != "PROXY TCP4 10.0.0.1 10.0.0.2 12345 443\r\n" {
+		t.Error("unexpected PROXY protocol v1 header: ", string(header))
+	}
+
+	header, err = proxyHeader(2, remote, local)
+	common.Must(err)
+	expected := []byte("\x0D\x0A\x0D\x0A\x00\x0D\x0A\x51\x55\x49\x54\x0A\x21\x11\x00\x0C\x0A\x00\x00\x01\x0A\x00\x00\x02\x30\x39\x01\xBB")
+	if !bytes.Equal(header, expected) {
+		t.Error("unexpected PROXY protocol v2 header: ", header)
+	}
+
+	if _, err := proxyHeader(1, &net.UnixAddr{Name: "@v2fly", Net: "unix"}, local); err == nil {
+		t.Error("expected the PROXY protocol header of a unix address to be rejected")
+	}
+}
+
+func TestServe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.Must(err)
+	defer listener.Close()
+
+	request := "GET /v2fly HTTP/1.1\r\nHost: v2fly.org\r\n\r\n"
+	response := "HTTP/1.1 200 OK\r\nContent-Length: 0\r\n\r\n"
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		b := make([]byte, len(request))
+		if _, err := io.ReadFull(conn, b); err != nil || string(b) != request {
+			return
+		}
+		conn.Write([]byte(response))
+	}()
+
+	f := New([]*Fallback{
+		{Type: "tcp", Dest: "127.0.0.1:1"},
+		{Path: "/v2fly", Type: "tcp", Dest: listener.Addr().String()},
+	})
+
+	client, server := gonet.Pipe()
+	defer client.Close()
+	done := make(chan error, 1)
+	go func() {
+		reader := NewReader(buf.NewReader(server))
+		// The failed handshake reads the request.
+		mb, err := reader.ReadMultiBuffer()
+		if err != nil {
+			done <- err
+			return
+		}
+		buf.ReleaseMulti(mb)
+		done <- f.Serve(context.Background(), policy.SessionDefault(), server, reader)
+	}()
+
+	common.Must2(client.Write([]byte(request)))
+	b := make([]byte, len(response))
+	common.Must2(io.ReadFull(client, b))
+	if string(b) != response {
+		t.Error("unexpected response: ", string(b))
+	}
+	client.Close()
+	if err := <-done; err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReaderWithHead(t *testing.T) {
+	rest := buf.NewReader(bytes.NewReader([]byte("Host: v2fly.org\r\n\r\n")))
+	reader := NewReaderWithHead([]byte("GET /v2fly HTTP/1.1\r\n"), rest)
+
+	requestReader, head := reader.replay()
+	if path := requestPath(head); path != "/v2fly" {
+		t.Error("unexpected path of head ", string(head), ": ", path)
+	}
+	var b bytes.Buffer
+	common.Must(buf.Copy(requestReader, buf.NewWriter(&b)))
+	if b.String() != "GET /v2fly HTTP/1.1\r\nHost: v2fly.org\r\n\r\n" {
+		t.Error("unexpected replay: ", b.String())
+	}
+}
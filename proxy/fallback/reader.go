@@ -0,0 +1,61 @@
+package fallback
+
+import (
+	"github.com/v2fly/v2ray-core/v4/common/buf"
+)
+
+// Reader reads the connection of an inbound, and records what is read until the handshake succeeds, so that it can
+// be forwarded to the fallback if the handshake fails.
+type Reader struct {
+	reader    buf.Reader
+	record    buf.MultiBuffer
+	recording bool
+}
+
+// NewReader creates a Reader of the reader of a connection.
+func NewReader(reader buf.Reader) *Reader {
+	return &Reader{
+		reader:    reader,
+		recording: true,
+	}
+}
+
+// NewReaderWithHead creates a Reader of the reader of a connection, whose head is already read by the inbound.
+func NewReaderWithHead(head []byte, reader buf.Reader) *Reader {
+	return &Reader{
+		reader:    reader,
+		record:    buf.MergeBytes(nil, head),
+		recording: true,
+	}
+}
+
+// ReadMultiBuffer implements buf.Reader.
+func (r *Reader) ReadMultiBuffer() (buf.MultiBuffer, error) {
+	mb, err := r.reader.ReadMultiBuffer()
+	if r.recording {
+		for _, b := range mb {
+			r.record = buf.MergeBytes(r.record, b.Bytes())
+		}
+	}
+	return mb, err
+}
+
+// Commit stops recording, as the handshake succeeds.
+func (r *Reader) Commit() {
+	r.recording = false
+	r.record = buf.ReleaseMulti(r.record)
+}
+
+// replay returns a reader of what is recorded followed by the rest of the connection, and the first bytes recorded.
+func (r *Reader) replay() (*buf.BufferedReader, []byte) {
+	r.recording = false
+	record := r.record
+	r.record = nil
+
+	head := make([]byte, 64)
+	head = head[:record.Copy(head)]
+	return &buf.BufferedReader{
+		Reader: r.reader,
+		Buffer: record,
+	}, head
+}
@@ -177,6 +177,8 @@ func setUpHTTPTunnel(ctx context.Context, dest net.Destination, target string, u
 		return rawConn, nil
 	}
 
+	// connectHTTP2 opens a tunnel as a stream of the HTTP/2 connection, which is shared by the tunnels to the proxy, so
+	// it is left open if the tunnel fails.
 	connectHTTP2 := func(rawConn net.Conn, h2clientConn *http2.ClientConn) (net.Conn, error) {
 		pr, pw := io.Pipe()
 		req.Body = pr
@@ -192,18 +194,20 @@ func setUpHTTPTunnel(ctx context.Context, dest net.Destination, target string, u
 
 		resp, err := h2clientConn.RoundTrip(req) // nolint: bodyclose
 		if err != nil {
-			rawConn.Close()
+			pw.CloseWithError(err)
+			wg.Wait()
 			return nil, err
 		}
 
 		wg.Wait()
 		if pErr != nil {
-			rawConn.Close()
+			resp.Body.Close()
 			return nil, pErr
 		}
 
 		if resp.StatusCode != http.StatusOK {
-			rawConn.Close()
+			pw.Close()
+			resp.Body.Close()
 			return nil, newError("Proxy responded with non 200 code: " + resp.Status)
 		}
 		return newHTTP2Conn(rawConn, pw, resp.Body), nil
@@ -218,6 +222,14 @@ func setUpHTTPTunnel(ctx context.Context, dest net.Destination, target string, u
 		if cc.CanTakeNewRequest() {
 			proxyConn, err := connectHTTP2(rc, cc)
 			if err != nil {
+				if !cc.CanTakeNewRequest() {
+					cachedH2Mutex.Lock()
+					if cachedH2Conns[dest].h2Conn == cc {
+						delete(cachedH2Conns, dest)
+					}
+					cachedH2Mutex.Unlock()
+					rc.Close()
+				}
 				return nil, err
 			}
 
@@ -3,6 +3,7 @@ package http
 import (
 	"bufio"
 	"context"
+	gotls "crypto/tls"
 	"encoding/base64"
 	"io"
 	"net/http"
@@ -23,12 +24,15 @@ import (
 	"github.com/v2fly/v2ray-core/v4/features/policy"
 	"github.com/v2fly/v2ray-core/v4/features/routing"
 	"github.com/v2fly/v2ray-core/v4/transport/internet"
+	"github.com/v2fly/v2ray-core/v4/transport/internet/tls"
 )
 
 // Server is an HTTP proxy server.
 type Server struct {
 	config        *ServerConfig
 	policyManager policy.Manager
+	// http3TLS is the TLS config of HTTP/3, or nil if HTTP/3 is not served.
+	http3TLS *gotls.Config
 }
 
 // NewServer creates a new HTTP inbound handler.
@@ -39,6 +43,14 @@ func NewServer(ctx context.Context, config *ServerConfig) (*Server, error) {
 		policyManager: v.GetFeature(policy.ManagerType()).(policy.Manager),
 	}
 
+	if http3 := config.GetHttp3(); http3 != nil {
+		tlsSettings := http3.GetTlsSettings()
+		if len(tlsSettings.GetCertificate()) == 0 {
+			return nil, newError("HTTP/3 requires a TLS certificate")
+		}
+		s.http3TLS = tlsSettings.GetTLSConfig()
+	}
+
 	return s, nil
 }
 
@@ -51,8 +63,11 @@ func (s *Server) policy() policy.Session {
 	return p
 }
 
-// Network implements proxy.Inbound.
-func (*Server) Network() []net.Network {
+// Network implements proxy.Inbound. UDP is for HTTP/3, if it is served.
+func (s *Server) Network() []net.Network {
+	if s.http3TLS != nil {
+		return []net.Network{net.Network_TCP, net.Network_UNIX, net.Network_UDP}
+	}
 	return []net.Network{net.Network_TCP, net.Network_UNIX}
 }
 
@@ -90,6 +105,20 @@ func (s *Server) Process(ctx context.Context, network net.Network, conn internet
 		}
 	}
 
+	if network == net.Network_UDP {
+		return s.serveHTTP3(ctx, conn, dispatcher)
+	}
+	// The TLS server shakes hands on the first read, so the negotiated protocol is bounded by the handshake timeout.
+	if err := conn.SetReadDeadline(time.Now().Add(s.policy().Timeouts.Handshake)); err != nil {
+		newError("failed to set read deadline").Base(err).WriteToLog(session.ExportIDToError(ctx))
+	}
+	if tls.NegotiatedProtocol(conn) == "h2" {
+		if err := conn.SetReadDeadline(time.Time{}); err != nil {
+			newError("failed to clear read deadline").Base(err).WriteToLog(session.ExportIDToError(ctx))
+		}
+		return s.serveHTTP2(ctx, conn, dispatcher)
+	}
+
 	reader := bufio.NewReaderSize(readerOnly{conn}, buf.Size)
 
 Start:
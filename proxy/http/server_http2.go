@@ -0,0 +1,226 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	gotls "crypto/tls"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/http2"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/log"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/protocol"
+	http_proto "github.com/v2fly/v2ray-core/v4/common/protocol/http"
+	"github.com/v2fly/v2ray-core/v4/common/session"
+	"github.com/v2fly/v2ray-core/v4/common/signal"
+	"github.com/v2fly/v2ray-core/v4/common/task"
+	"github.com/v2fly/v2ray-core/v4/features/policy"
+	"github.com/v2fly/v2ray-core/v4/features/routing"
+	"github.com/v2fly/v2ray-core/v4/transport/internet"
+	"github.com/v2fly/v2ray-core/v4/transport/internet/tls"
+)
+
+// tlsStateConn is a TLS connection counted by stats, with its TLS state exposed to the HTTP/2 server.
+type tlsStateConn struct {
+	internet.Connection
+	state gotls.ConnectionState
+}
+
+func (c *tlsStateConn) ConnectionState() gotls.ConnectionState {
+	return c.state
+}
+
+// serveHTTP2 serves the requests of an HTTP/2 connection, each of its streams being a proxy request of its own.
+func (s *Server) serveHTTP2(ctx context.Context, conn internet.Connection, dispatcher routing.Dispatcher) error {
+	// The HTTP/2 server tells the streams of :scheme https by the TLS state of the connection.
+	if state, ok := tls.ConnectionState(conn); ok {
+		conn = &tlsStateConn{Connection: conn, state: state}
+	}
+	server := &http2.Server{
+		IdleTimeout: s.policy().Timeouts.ConnectionIdle,
+	}
+	server.ServeConn(conn, &http2.ServeConnOpts{
+		Context: ctx,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+			s.handleStream(ctx, w, request, dispatcher)
+		}),
+	})
+	return nil
+}
+
+// handleStream handles a request of an HTTP/2 or HTTP/3 stream.
+func (s *Server) handleStream(ctx context.Context, w http.ResponseWriter, request *http.Request, dispatcher routing.Dispatcher) {
+	// Streams are sessions of their own, sharing the inbound of the connection.
+	ctx = session.ContextWithID(ctx, session.NewID())
+	if inbound := session.InboundFromContext(ctx); inbound != nil {
+		streamInbound := *inbound
+		streamInbound.User = &protocol.MemoryUser{
+			Level: s.config.UserLevel,
+		}
+		ctx = session.ContextWithInbound(ctx, &streamInbound)
+	}
+	sid := session.ExportIDToError(ctx)
+
+	if len(s.config.Accounts) > 0 {
+		user, pass, ok := parseBasicAuth(request.Header.Get("Proxy-Authorization"))
+		if !ok || !s.config.HasAccount(user, pass) {
+			w.Header().Set("Proxy-Authenticate", "Basic realm=\"proxy\"")
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		if inbound := session.InboundFromContext(ctx); inbound != nil {
+			inbound.User.Email = user
+		}
+	}
+
+	newError(request.Proto, " request to Method [", request.Method, "] Host [", request.Host, "] with URL [", request.URL, "]").WriteToLog(sid)
+
+	defaultPort := net.Port(80)
+	if request.Method == http.MethodConnect || request.TLS != nil || strings.EqualFold(request.URL.Scheme, "https") {
+		defaultPort = net.Port(443)
+	}
+	dest, err := http_proto.ParseHost(request.Host, defaultPort)
+	if err != nil {
+		newError("malformed proxy host: ", request.Host).AtWarning().Base(err).WriteToLog(sid)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	ctx = log.ContextWithAccessMessage(ctx, &log.AccessMessage{
+		From:   request.RemoteAddr,
+		To:     request.Host,
+		Status: log.AccessAccepted,
+		Reason: "",
+	})
+
+	if request.Method == http.MethodConnect {
+		err = s.handleStreamConnect(ctx, w, request, dest, dispatcher)
+	} else {
+		err = s.handleStreamPlain(ctx, w, request, dest, dispatcher)
+	}
+	if err != nil {
+		newError("failed to handle ", request.Proto, " request").Base(err).WriteToLog(sid)
+	}
+}
+
+// flushWriter flushes each write to an HTTP/2 or HTTP/3 stream, so that a tunnel is not delayed by buffering.
+type flushWriter struct {
+	w http.ResponseWriter
+}
+
+func (w flushWriter) Write(b []byte) (int, error) {
+	n, err := w.w.Write(b)
+	w.w.(http.Flusher).Flush()
+	return n, err
+}
+
+func (s *Server) handleStreamConnect(ctx context.Context, w http.ResponseWriter, request *http.Request, dest net.Destination, dispatcher routing.Dispatcher) error {
+	plcy := s.policy()
+	ctx, cancel := context.WithCancel(ctx)
+	timer := signal.CancelAfterInactivity(ctx, cancel, plcy.Timeouts.ConnectionIdle)
+
+	ctx = policy.ContextWithBufferPolicy(ctx, plcy.Buffer)
+	link, err := dispatcher.Dispatch(ctx, dest)
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return err
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.(http.Flusher).Flush()
+
+	requestDone := func() error {
+		defer timer.SetTimeout(plcy.Timeouts.DownlinkOnly)
+
+		return buf.Copy(buf.NewReader(request.Body), link.Writer, buf.UpdateActivity(timer))
+	}
+
+	responseDone := func() error {
+		defer timer.SetTimeout(plcy.Timeouts.UplinkOnly)
+
+		return buf.Copy(link.Reader, buf.NewWriter(flushWriter{w}), buf.UpdateActivity(timer))
+	}
+
+	closeWriter := task.OnSuccess(requestDone, task.Close(link.Writer))
+	if err := task.Run(ctx, closeWriter, responseDone); err != nil {
+		common.Interrupt(link.Reader)
+		common.Interrupt(link.Writer)
+		return newError("connection ends").Base(err)
+	}
+
+	return nil
+}
+
+// handleStreamPlain forwards a request other than CONNECT to its host in HTTP/1.1.
+func (s *Server) handleStreamPlain(ctx context.Context, w http.ResponseWriter, request *http.Request, dest net.Destination, dispatcher routing.Dispatcher) error {
+	http_proto.RemoveHopByHopHeaders(request.Header)
+
+	// Prevent UA from being set to golang's default ones
+	if request.Header.Get("User-Agent") == "" {
+		request.Header.Set("User-Agent", "")
+	}
+
+	content := &session.Content{
+		Protocol: "http/1.1",
+	}
+
+	content.SetAttribute(":method", strings.ToUpper(request.Method))
+	content.SetAttribute(":path", request.URL.Path)
+	for key := range request.Header {
+		value := request.Header.Get(key)
+		content.SetAttribute(strings.ToLower(key), value)
+	}
+
+	ctx = session.ContextWithContent(ctx, content)
+
+	link, err := dispatcher.Dispatch(ctx, dest)
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return err
+	}
+	defer common.Close(link.Writer)
+
+	requestDone := func() error {
+		request.Header.Set("Connection", "close")
+
+		requestWriter := buf.NewBufferedWriter(link.Writer)
+		common.Must(requestWriter.SetBuffered(false))
+		if err := request.Write(requestWriter); err != nil {
+			return newError("failed to write whole request").Base(err).AtWarning()
+		}
+		return nil
+	}
+
+	responseDone := func() error {
+		responseReader := bufio.NewReaderSize(&buf.BufferedReader{Reader: link.Reader}, buf.Size)
+		response, err := http.ReadResponse(responseReader, request)
+		if err != nil {
+			newError("failed to read response from ", request.Host).Base(err).AtWarning().WriteToLog(session.ExportIDToError(ctx))
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return nil
+		}
+		defer response.Body.Close()
+
+		http_proto.RemoveHopByHopHeaders(response.Header)
+		for key, values := range response.Header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(response.StatusCode)
+		if _, err := io.Copy(flushWriter{w}, response.Body); err != nil {
+			return newError("failed to write response").Base(err).AtWarning()
+		}
+		return nil
+	}
+
+	if err := task.Run(ctx, requestDone, responseDone); err != nil {
+		common.Interrupt(link.Reader)
+		common.Interrupt(link.Writer)
+		return newError("connection ends").Base(err)
+	}
+
+	return nil
+}
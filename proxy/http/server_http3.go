@@ -0,0 +1,101 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/lucas-clemente/quic-go"
+	"github.com/lucas-clemente/quic-go/http3"
+
+	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/features/routing"
+	"github.com/v2fly/v2ray-core/v4/transport/internet"
+)
+
+// http3IdleTimeout is the idle timeout of QUIC connections, which are kept alive by pings every half of it, as the UDP
+// inbound closes the connections of clients sending nothing for 8 seconds.
+const http3IdleTimeout = 10 * time.Second
+
+// clientAddr is the local address of the packet connection of a client. Its network names the client, as QUIC shares
+// the packet connections of the same local address between servers.
+type clientAddr struct {
+	net.Addr
+	client net.Addr
+}
+
+func (a *clientAddr) Network() string {
+	return a.Addr.Network() + " " + a.client.String()
+}
+
+// packetConn is the UDP connection of a client to the inbound, as the packet connection of an HTTP/3 server.
+type packetConn struct {
+	conn   internet.Connection
+	reader buf.Reader
+	cache  buf.MultiBuffer
+}
+
+func (c *packetConn) ReadFrom(p []byte) (int, net.Addr, error) {
+	for c.cache.IsEmpty() {
+		mb, err := c.reader.ReadMultiBuffer()
+		if err != nil {
+			return 0, nil, err
+		}
+		c.cache = mb
+	}
+	var b *buf.Buffer
+	c.cache, b = buf.SplitFirst(c.cache)
+	n := copy(p, b.Bytes())
+	b.Release()
+	return n, c.conn.RemoteAddr(), nil
+}
+
+func (c *packetConn) WriteTo(p []byte, addr net.Addr) (int, error) {
+	return c.conn.Write(p)
+}
+
+func (c *packetConn) Close() error {
+	buf.ReleaseMulti(c.cache)
+	c.cache = nil
+	return c.conn.Close()
+}
+
+func (c *packetConn) LocalAddr() net.Addr {
+	return &clientAddr{Addr: c.conn.LocalAddr(), client: c.conn.RemoteAddr()}
+}
+
+func (c *packetConn) SetDeadline(t time.Time) error {
+	return c.conn.SetDeadline(t)
+}
+
+func (c *packetConn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+func (c *packetConn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
+// serveHTTP3 serves the requests of HTTP/3 connections from a client, each of their streams being a proxy request of
+// its own.
+func (s *Server) serveHTTP3(ctx context.Context, conn internet.Connection, dispatcher routing.Dispatcher) error {
+	server := &http3.Server{
+		Server: &http.Server{
+			TLSConfig: s.http3TLS,
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, request *http.Request) {
+				s.handleStream(ctx, w, request, dispatcher)
+			}),
+		},
+		QuicConfig: &quic.Config{
+			MaxIdleTimeout: http3IdleTimeout,
+			KeepAlive:      true,
+		},
+	}
+	defer server.Close()
+
+	if err := server.Serve(&packetConn{conn: conn, reader: buf.NewReader(conn)}); err != nil {
+		return newError("failed to serve HTTP/3").Base(err)
+	}
+	return nil
+}
@@ -89,29 +89,43 @@ func (c *Client) Process(ctx context.Context, link *transport.Link, dialer inter
 	}
 
 	user := server.PickUser()
-	_, ok := user.Account.(*MemoryAccount)
+	account, ok := user.Account.(*MemoryAccount)
 	if !ok {
 		return newError("user account is not valid")
 	}
 	request.User = user
+	_, is2022 := account.Cipher.(*Cipher2022)
 
 	sessionPolicy := c.policyManager.ForLevel(user.Level)
 	ctx, cancel := context.WithCancel(ctx)
 	timer := signal.CancelAfterInactivity(ctx, cancel, sessionPolicy.Timeouts.ConnectionIdle)
 
 	if request.Command == protocol.RequestCommandTCP {
+		bufferedWriter := buf.NewBufferedWriter(buf.NewWriter(conn))
+		var bodyWriter buf.Writer
+		var requestSalt []byte
+		if is2022 {
+			requestSalt, bodyWriter, err = WriteTCPRequest2022(request, bufferedWriter)
+		} else {
+			bodyWriter, err = WriteTCPRequest(request, bufferedWriter)
+		}
+		if err != nil {
+			return newError("failed to write request").Base(err)
+		}
+
 		requestDone := func() error {
 			defer timer.SetTimeout(sessionPolicy.Timeouts.DownlinkOnly)
-			bufferedWriter := buf.NewBufferedWriter(buf.NewWriter(conn))
-			bodyWriter, err := WriteTCPRequest(request, bufferedWriter)
-			if err != nil {
-				return newError("failed to write request").Base(err)
-			}
 
-			if err = buf.CopyOnceTimeout(link.Reader, bodyWriter, time.Millisecond*100); err != nil && err != buf.ErrNotTimeoutReader && err != buf.ErrReadTimeout {
+			if err := buf.CopyOnceTimeout(link.Reader, bodyWriter, time.Millisecond*100); err != nil && err != buf.ErrNotTimeoutReader && err != buf.ErrReadTimeout {
 				return newError("failed to write A request payload").Base(err).AtWarning()
 			}
 
+			if w, ok := bodyWriter.(*chunkWriter2022); ok {
+				if err := w.flushHeader(); err != nil {
+					return newError("failed to write request header").Base(err)
+				}
+			}
+
 			if err := bufferedWriter.SetBuffered(false); err != nil {
 				return err
 			}
@@ -122,7 +136,13 @@ func (c *Client) Process(ctx context.Context, link *transport.Link, dialer inter
 		responseDone := func() error {
 			defer timer.SetTimeout(sessionPolicy.Timeouts.UplinkOnly)
 
-			responseReader, err := ReadTCPResponse(user, conn)
+			var responseReader buf.Reader
+			var err error
+			if is2022 {
+				responseReader, err = ReadTCPResponse2022(user, requestSalt, conn)
+			} else {
+				responseReader, err = ReadTCPResponse(user, conn)
+			}
 			if err != nil {
 				return err
 			}
@@ -139,9 +159,14 @@ func (c *Client) Process(ctx context.Context, link *transport.Link, dialer inter
 	}
 
 	if request.Command == protocol.RequestCommandUDP {
+		var session2022 *udpSession2022
+		if is2022 {
+			session2022 = newUDPSession2022(user)
+		}
 		writer := &buf.SequentialWriter{Writer: &UDPWriter{
 			Writer:  conn,
 			Request: request,
+			session: session2022,
 		}}
 
 		requestDone := func() error {
@@ -157,8 +182,9 @@ func (c *Client) Process(ctx context.Context, link *transport.Link, dialer inter
 			defer timer.SetTimeout(sessionPolicy.Timeouts.UplinkOnly)
 
 			reader := &UDPReader{
-				Reader: conn,
-				User:   user,
+				Reader:  conn,
+				User:    user,
+				session: session2022,
 			}
 
 			if err := buf.Copy(reader, link.Writer, buf.UpdateActivity(timer)); err != nil {
@@ -6,11 +6,13 @@ import (
 	"crypto/cipher"
 	"crypto/md5"
 	"crypto/sha1"
+	"encoding/base64"
 	"io"
 	"strings"
 
 	"golang.org/x/crypto/chacha20poly1305"
 	"golang.org/x/crypto/hkdf"
+	"lukechampine.com/blake3"
 
 	"github.com/v2fly/v2ray-core/v4/common"
 	"github.com/v2fly/v2ray-core/v4/common/antireplay"
@@ -23,6 +25,9 @@ import (
 type MemoryAccount struct {
 	Cipher Cipher
 	Key    []byte
+	// IdentityKeys are the keys of the identity headers of Shadowsocks 2022, sent to the servers in order before the
+	// one of Key.
+	IdentityKeys [][]byte
 
 	replayFilter antireplay.GeneralizedReplayFilter
 }
@@ -81,6 +86,23 @@ func (a *Account) getCipher() (Cipher, error) {
 		}, nil
 	case CipherType_NONE:
 		return NoneCipher{}, nil
+	case CipherType_BLAKE3_AES_128_GCM:
+		return &Cipher2022{
+			KeyBytes:        16,
+			AEADAuthCreator: createAesGcm,
+			BlockCipher:     true,
+		}, nil
+	case CipherType_BLAKE3_AES_256_GCM:
+		return &Cipher2022{
+			KeyBytes:        32,
+			AEADAuthCreator: createAesGcm,
+			BlockCipher:     true,
+		}, nil
+	case CipherType_BLAKE3_CHACHA20_POLY1305:
+		return &Cipher2022{
+			KeyBytes:        32,
+			AEADAuthCreator: createChaCha20Poly1305,
+		}, nil
 	default:
 		return nil, newError("Unsupported cipher.")
 	}
@@ -92,6 +114,21 @@ func (a *Account) AsAccount() (protocol.Account, error) {
 	if err != nil {
 		return nil, newError("failed to get cipher").Base(err)
 	}
+	if _, ok := Cipher.(*Cipher2022); ok {
+		keys, err := passwordToKeys2022(a.Password, Cipher.KeySize())
+		if err != nil {
+			return nil, err
+		}
+		if len(keys) > 1 && !Cipher.(*Cipher2022).BlockCipher {
+			return nil, newError("identity keys are only supported by AES ciphers of Shadowsocks 2022")
+		}
+		return &MemoryAccount{
+			Cipher:       Cipher,
+			Key:          keys[len(keys)-1],
+			IdentityKeys: keys[:len(keys)-1],
+			replayFilter: antireplay.NewReplayFilter(saltReplayInterval2022),
+		}, nil
+	}
 	return &MemoryAccount{
 		Cipher: Cipher,
 		Key:    passwordToCipherKey([]byte(a.Password), Cipher.KeySize()),
@@ -183,6 +220,52 @@ func (c *AEADCipher) DecodePacket(key []byte, b *buf.Buffer) error {
 	return nil
 }
 
+// Cipher2022 is a cipher of Shadowsocks 2022, whose session keys are derived with BLAKE3. Its sessions have their
+// own formats, so it is not used as other ciphers.
+type Cipher2022 struct {
+	KeyBytes        int32
+	AEADAuthCreator func(key []byte) cipher.AEAD
+	// BlockCipher is whether identity headers and the separate headers of UDP packets are encrypted with AES.
+	// Otherwise UDP packets are sealed with XChaCha20-Poly1305 as a whole.
+	BlockCipher bool
+}
+
+func (*Cipher2022) IsAEAD() bool {
+	return true
+}
+
+func (c *Cipher2022) KeySize() int32 {
+	return c.KeyBytes
+}
+
+// IVSize returns the size of the salt, which is the same as the key.
+func (c *Cipher2022) IVSize() int32 {
+	return c.KeyBytes
+}
+
+func (*Cipher2022) NewEncryptionWriter(key []byte, iv []byte, writer io.Writer) (buf.Writer, error) {
+	return nil, newError("Shadowsocks 2022 stream is not supported as a cipher")
+}
+
+func (*Cipher2022) NewDecryptionReader(key []byte, iv []byte, reader io.Reader) (buf.Reader, error) {
+	return nil, newError("Shadowsocks 2022 stream is not supported as a cipher")
+}
+
+func (*Cipher2022) EncodePacket(key []byte, b *buf.Buffer) error {
+	return newError("Shadowsocks 2022 packet is not supported as a cipher")
+}
+
+func (*Cipher2022) DecodePacket(key []byte, b *buf.Buffer) error {
+	return newError("Shadowsocks 2022 packet is not supported as a cipher")
+}
+
+// sessionKey derives the key of the session of the salt, or the ID of a UDP session.
+func (c *Cipher2022) sessionKey(key []byte, salt []byte) []byte {
+	subkey := make([]byte, c.KeyBytes)
+	blake3.DeriveKey(subkey, "shadowsocks 2022 session subkey", append(append([]byte(nil), key...), salt...))
+	return subkey
+}
+
 type NoneCipher struct{}
 
 func (NoneCipher) KeySize() int32 { return 0 }
@@ -217,6 +300,12 @@ func CipherFromString(c string) CipherType {
 		return CipherType_CHACHA20_POLY1305
 	case "none", "plain":
 		return CipherType_NONE
+	case "2022-blake3-aes-128-gcm":
+		return CipherType_BLAKE3_AES_128_GCM
+	case "2022-blake3-aes-256-gcm":
+		return CipherType_BLAKE3_AES_256_GCM
+	case "2022-blake3-chacha20-poly1305":
+		return CipherType_BLAKE3_CHACHA20_POLY1305
 	default:
 		return CipherType_UNKNOWN
 	}
@@ -239,6 +328,23 @@ func passwordToCipherKey(password []byte, keySize int32) []byte {
 	return key
 }
 
+// passwordToKeys2022 decodes the keys of Shadowsocks 2022 from the password, which are base64 encoded and separated
+// by colons, with the identity keys before the key of the user.
+func passwordToKeys2022(password string, keySize int32) ([][]byte, error) {
+	var keys [][]byte
+	for _, s := range strings.Split(password, ":") {
+		key, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, newError("failed to decode Shadowsocks 2022 key").Base(err)
+		}
+		if int32(len(key)) != keySize {
+			return nil, newError("Shadowsocks 2022 key must be ", keySize, " bytes, but got ", len(key))
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
 func hkdfSHA1(secret, salt, outKey []byte) {
 	r := hkdf.New(sha1.New, secret, salt, []byte("ss-subkey"))
 	common.Must2(io.ReadFull(r, outKey))
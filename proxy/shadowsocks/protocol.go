@@ -29,17 +29,19 @@ var addrParser = protocol.NewAddressParser(
 
 // ReadTCPSession reads a Shadowsocks TCP session from the given reader, returns its header and remaining parts.
 func ReadTCPSession(user *protocol.MemoryUser, reader io.Reader) (*protocol.RequestHeader, buf.Reader, error) {
-	account := user.Account.(*MemoryAccount)
-
-	hashkdf := hmac.New(sha256.New, []byte("SSBSKDF"))
-	hashkdf.Write(account.Key)
-
-	behaviorSeed := crc32.ChecksumIEEE(hashkdf.Sum(nil))
+	return readTCPSession(user, reader, nil)
+}
 
-	drainer, err := drain.NewBehaviorSeedLimitedDrainer(int64(behaviorSeed), 16+38, 3266, 64)
+// readTCPSession reads a TCP session like ReadTCPSession, but drains the reader on failure with the drainer if any.
+func readTCPSession(user *protocol.MemoryUser, reader io.Reader, drainer drain.Drainer) (*protocol.RequestHeader, buf.Reader, error) {
+	account := user.Account.(*MemoryAccount)
 
-	if err != nil {
-		return nil, nil, newError("failed to initialize drainer").Base(err)
+	if drainer == nil {
+		var err error
+		drainer, err = newDrainer(account)
+		if err != nil {
+			return nil, nil, newError("failed to initialize drainer").Base(err)
+		}
 	}
 
 	buffer := buf.New()
@@ -238,6 +240,8 @@ func DecodeUDPPacket(user *protocol.MemoryUser, payload *buf.Buffer) (*protocol.
 type UDPReader struct {
 	Reader io.Reader
 	User   *protocol.MemoryUser
+
+	session *udpSession2022
 }
 
 func (v *UDPReader) ReadMultiBuffer() (buf.MultiBuffer, error) {
@@ -247,6 +251,13 @@ func (v *UDPReader) ReadMultiBuffer() (buf.MultiBuffer, error) {
 		buffer.Release()
 		return nil, err
 	}
+	if v.session != nil {
+		if err := v.session.decode(buffer); err != nil {
+			buffer.Release()
+			return nil, err
+		}
+		return buf.MultiBuffer{buffer}, nil
+	}
 	_, payload, err := DecodeUDPPacket(v.User, buffer)
 	if err != nil {
 		buffer.Release()
@@ -258,10 +269,20 @@ func (v *UDPReader) ReadMultiBuffer() (buf.MultiBuffer, error) {
 type UDPWriter struct {
 	Writer  io.Writer
 	Request *protocol.RequestHeader
+
+	session *udpSession2022
 }
 
 // Write implements io.Writer.
 func (w *UDPWriter) Write(payload []byte) (int, error) {
+	if w.session != nil {
+		packet, err := w.session.encode(w.Request, payload, false)
+		if err != nil {
+			return 0, err
+		}
+		_, err = w.Writer.Write(packet)
+		return len(payload), err
+	}
 	packet, err := EncodeUDPPacket(w.Request, payload)
 	if err != nil {
 		return 0, err
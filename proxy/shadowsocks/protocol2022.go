@@ -0,0 +1,442 @@
+package shadowsocks
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"time"
+
+	"lukechampine.com/blake3"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/dice"
+	"github.com/v2fly/v2ray-core/v4/common/drain"
+	"github.com/v2fly/v2ray-core/v4/common/protocol"
+)
+
+const (
+	headerTypeClient2022 = 0
+	headerTypeServer2022 = 1
+
+	// maxTimeDiff2022 is how far the timestamps of requests and responses may be from now.
+	maxTimeDiff2022 = 30 * time.Second
+	// saltReplayInterval2022 is how long the salts are remembered in seconds, longer than the timestamps are valid.
+	saltReplayInterval2022 = 60
+
+	maxChunkSize2022     = 0xFFFF
+	maxPaddingLength2022 = 900
+	identityHeaderSize   = 16
+	// requestFixedHeaderSize is the size of the type, timestamp and the length of the variable header.
+	requestFixedHeaderSize = 1 + 8 + 2
+)
+
+// IdentityUsers are the users of a Shadowsocks 2022 server, identified by the hashes of their keys in the identity
+// headers.
+type IdentityUsers map[[identityHeaderSize]byte]*protocol.MemoryUser
+
+// Add adds the user, whose account must be of Shadowsocks 2022.
+func (u IdentityUsers) Add(user *protocol.MemoryUser) {
+	u[identityHash(user.Account.(*MemoryAccount).Key)] = user
+}
+
+func identityHash(key []byte) (hash [identityHeaderSize]byte) {
+	sum := blake3.Sum256(key)
+	copy(hash[:], sum[:identityHeaderSize])
+	return
+}
+
+func newBlockCipher(key []byte) cipher.Block {
+	block, err := aes.NewCipher(key)
+	common.Must(err)
+	return block
+}
+
+// newIdentityCipher creates the cipher of the identity header of the TCP session of the salt.
+func newIdentityCipher(identityKey []byte, salt []byte) cipher.Block {
+	subkey := make([]byte, len(identityKey))
+	blake3.DeriveKey(subkey, "shadowsocks 2022 identity subkey", append(append([]byte(nil), identityKey...), salt...))
+	return newBlockCipher(subkey)
+}
+
+func newDrainer(account *MemoryAccount) (drain.Drainer, error) {
+	hashkdf := hmac.New(sha256.New, []byte("SSBSKDF"))
+	hashkdf.Write(account.Key)
+
+	behaviorSeed := crc32.ChecksumIEEE(hashkdf.Sum(nil))
+	return drain.NewBehaviorSeedLimitedDrainer(int64(behaviorSeed), 16+38, 3266, 64)
+}
+
+func putTimestamp(b []byte) {
+	binary.BigEndian.PutUint64(b, uint64(time.Now().Unix()))
+}
+
+func checkTimestamp(b []byte) error {
+	t := time.Unix(int64(binary.BigEndian.Uint64(b)), 0)
+	if d := time.Since(t); d > maxTimeDiff2022 || d < -maxTimeDiff2022 {
+		return newError("timestamp is too far from now: ", t)
+	}
+	return nil
+}
+
+// sessionAEAD seals and opens the chunks of a TCP session of Shadowsocks 2022, with the nonce counting from zero.
+type sessionAEAD struct {
+	aead  cipher.AEAD
+	nonce []byte
+}
+
+func newSessionAEAD(account *MemoryAccount, key []byte, salt []byte) *sessionAEAD {
+	c := account.Cipher.(*Cipher2022)
+	aead := c.AEADAuthCreator(c.sessionKey(key, salt))
+	return &sessionAEAD{
+		aead:  aead,
+		nonce: make([]byte, aead.NonceSize()),
+	}
+}
+
+func (a *sessionAEAD) increaseNonce() {
+	for i := range a.nonce {
+		a.nonce[i]++
+		if a.nonce[i] != 0 {
+			return
+		}
+	}
+}
+
+func (a *sessionAEAD) seal(dst []byte, plaintext []byte) []byte {
+	dst = a.aead.Seal(dst, a.nonce, plaintext, nil)
+	a.increaseNonce()
+	return dst
+}
+
+// readSealed reads a chunk of size bytes of plaintext, and returns it opened.
+func (a *sessionAEAD) readSealed(reader io.Reader, size int) ([]byte, error) {
+	b := make([]byte, size+a.aead.Overhead())
+	if _, err := io.ReadFull(reader, b); err != nil {
+		return nil, err
+	}
+	plaintext, err := a.aead.Open(b[:0], a.nonce, b, nil)
+	if err != nil {
+		return nil, newError("failed to open chunk").Base(err)
+	}
+	a.increaseNonce()
+	return plaintext, nil
+}
+
+// chunkReader2022 reads the chunks of a TCP session of Shadowsocks 2022, each sealed with its length.
+type chunkReader2022 struct {
+	reader  io.Reader
+	aead    *sessionAEAD
+	pending buf.MultiBuffer
+}
+
+// ReadMultiBuffer implements buf.Reader.
+func (r *chunkReader2022) ReadMultiBuffer() (buf.MultiBuffer, error) {
+	if !r.pending.IsEmpty() {
+		mb := r.pending
+		r.pending = nil
+		return mb, nil
+	}
+
+	size, err := r.aead.readSealed(r.reader, 2)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := r.aead.readSealed(r.reader, int(binary.BigEndian.Uint16(size)))
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return buf.MergeBytes(nil, payload), nil
+}
+
+// chunkWriter2022 writes the chunks of a TCP session of Shadowsocks 2022. Its header is written with the first
+// payload, which the header may carry a part of.
+type chunkWriter2022 struct {
+	writer io.Writer
+	aead   *sessionAEAD
+	header func(mb buf.MultiBuffer) ([]byte, buf.MultiBuffer)
+}
+
+// flushHeader writes the header if it is not written yet.
+func (w *chunkWriter2022) flushHeader() error {
+	if w.header == nil {
+		return nil
+	}
+	out, _ := w.header(nil)
+	w.header = nil
+	return buf.WriteAllBytes(w.writer, out)
+}
+
+// WriteMultiBuffer implements buf.Writer.
+func (w *chunkWriter2022) WriteMultiBuffer(mb buf.MultiBuffer) error {
+	defer buf.ReleaseMulti(mb)
+
+	if mb.IsEmpty() {
+		return nil
+	}
+
+	var out []byte
+	if w.header != nil {
+		out, mb = w.header(mb)
+		w.header = nil
+	}
+	for !mb.IsEmpty() {
+		size := mb.Len()
+		if size > maxChunkSize2022 {
+			size = maxChunkSize2022
+		}
+		payload := make([]byte, size)
+		mb, _ = buf.SplitBytes(mb, payload)
+
+		var length [2]byte
+		binary.BigEndian.PutUint16(length[:], uint16(size))
+		out = w.aead.seal(out, length[:])
+		out = w.aead.seal(out, payload)
+	}
+	return buf.WriteAllBytes(w.writer, out)
+}
+
+// ReadTCPSession2022 reads a Shadowsocks 2022 TCP session from the given reader, returns its header, salt and
+// remaining parts. With users, the session is of the user its identity header refers to.
+func ReadTCPSession2022(user *protocol.MemoryUser, users IdentityUsers, reader io.Reader) (*protocol.RequestHeader, []byte, buf.Reader, error) {
+	return readTCPSession2022(user, users, reader, nil)
+}
+
+// readTCPSession2022 reads a TCP session like ReadTCPSession2022, but drains the reader on failure with the drainer
+// if any.
+func readTCPSession2022(user *protocol.MemoryUser, users IdentityUsers, reader io.Reader, drainer drain.Drainer) (*protocol.RequestHeader, []byte, buf.Reader, error) {
+	account := user.Account.(*MemoryAccount)
+
+	if drainer == nil {
+		var err error
+		drainer, err = newDrainer(account)
+		if err != nil {
+			return nil, nil, nil, newError("failed to initialize drainer").Base(err)
+		}
+	}
+
+	received := 0
+	fail := func(err error) error {
+		drainer.AcknowledgeReceive(received)
+		return drain.WithError(drainer, reader, err)
+	}
+
+	salt := make([]byte, account.Cipher.IVSize())
+	if _, err := io.ReadFull(reader, salt); err != nil {
+		return nil, nil, nil, fail(newError("failed to read salt").Base(err))
+	}
+	received += len(salt)
+
+	key := account.Key
+	if len(users) > 0 {
+		identity := make([]byte, identityHeaderSize)
+		if _, err := io.ReadFull(reader, identity); err != nil {
+			return nil, nil, nil, fail(newError("failed to read identity header").Base(err))
+		}
+		received += len(identity)
+
+		var hash [identityHeaderSize]byte
+		newIdentityCipher(account.Key, salt).Decrypt(hash[:], identity)
+		if user = users[hash]; user == nil {
+			return nil, nil, nil, fail(newError("unknown user identity"))
+		}
+		key = user.Account.(*MemoryAccount).Key
+	}
+
+	aead := newSessionAEAD(account, key, salt)
+	fixedHeader, err := aead.readSealed(reader, requestFixedHeaderSize)
+	if err != nil {
+		return nil, nil, nil, fail(newError("failed to read request header").Base(err))
+	}
+	received += requestFixedHeaderSize + aead.aead.Overhead()
+
+	if fixedHeader[0] != headerTypeClient2022 {
+		return nil, nil, nil, fail(newError("invalid request header type: ", fixedHeader[0]))
+	}
+	if err := checkTimestamp(fixedHeader[1:9]); err != nil {
+		return nil, nil, nil, fail(newError("invalid request").Base(err))
+	}
+	if err := account.CheckIV(salt); err != nil {
+		return nil, nil, nil, fail(newError("failed salt check").Base(err))
+	}
+
+	variableHeader, err := aead.readSealed(reader, int(binary.BigEndian.Uint16(fixedHeader[9:])))
+	if err != nil {
+		return nil, nil, nil, fail(newError("failed to read request header").Base(err))
+	}
+	received += len(variableHeader) + aead.aead.Overhead()
+
+	header := bytes.NewReader(variableHeader)
+	addr, port, err := addrParser.ReadAddressPort(nil, header)
+	if err != nil {
+		return nil, nil, nil, fail(newError("failed to read address").Base(err))
+	}
+	var paddingLength uint16
+	if err := binary.Read(header, binary.BigEndian, &paddingLength); err != nil {
+		return nil, nil, nil, fail(newError("failed to read padding").Base(err))
+	}
+	if int(paddingLength) > header.Len() {
+		return nil, nil, nil, fail(newError("invalid padding length: ", paddingLength))
+	}
+	initialPayload := variableHeader[len(variableHeader)-header.Len()+int(paddingLength):]
+
+	request := &protocol.RequestHeader{
+		Version: Version,
+		User:    user,
+		Command: protocol.RequestCommandTCP,
+		Address: addr,
+		Port:    port,
+	}
+	return request, salt, &chunkReader2022{
+		reader:  reader,
+		aead:    aead,
+		pending: buf.MergeBytes(nil, initialPayload),
+	}, nil
+}
+
+// WriteTCPRequest2022 writes a Shadowsocks 2022 request into the given writer, and returns its salt and a writer for
+// body. The header is written with the first payload, or when the writer is flushed by the client.
+func WriteTCPRequest2022(request *protocol.RequestHeader, writer io.Writer) ([]byte, buf.Writer, error) {
+	account := request.User.Account.(*MemoryAccount)
+
+	salt := make([]byte, account.Cipher.IVSize())
+	common.Must2(rand.Read(salt))
+	if err := account.CheckIV(salt); err != nil {
+		return nil, nil, newError("failed to mark outgoing salt").Base(err)
+	}
+
+	address := buf.New()
+	defer address.Release()
+	if err := addrParser.WriteAddressPort(address, request.Address, request.Port); err != nil {
+		return nil, nil, newError("failed to write address").Base(err)
+	}
+	addressBytes := append([]byte(nil), address.Bytes()...)
+
+	aead := newSessionAEAD(account, account.Key, salt)
+	w := &chunkWriter2022{
+		writer: writer,
+		aead:   aead,
+	}
+	w.header = func(mb buf.MultiBuffer) ([]byte, buf.MultiBuffer) {
+		initialLength := int(mb.Len())
+		if maxLength := maxChunkSize2022 - len(addressBytes) - 2; initialLength > maxLength {
+			initialLength = maxLength
+		}
+		paddingLength := 0
+		if initialLength == 0 {
+			paddingLength = 1 + dice.Roll(maxPaddingLength2022)
+		}
+
+		variableHeader := make([]byte, len(addressBytes)+2+paddingLength+initialLength)
+		copy(variableHeader, addressBytes)
+		binary.BigEndian.PutUint16(variableHeader[len(addressBytes):], uint16(paddingLength))
+		mb, _ = buf.SplitBytes(mb, variableHeader[len(addressBytes)+2+paddingLength:])
+
+		var fixedHeader [requestFixedHeaderSize]byte
+		fixedHeader[0] = headerTypeClient2022
+		putTimestamp(fixedHeader[1:9])
+		binary.BigEndian.PutUint16(fixedHeader[9:], uint16(len(variableHeader)))
+
+		out := append([]byte(nil), salt...)
+		for i, identityKey := range account.IdentityKeys {
+			nextKey := account.Key
+			if i+1 < len(account.IdentityKeys) {
+				nextKey = account.IdentityKeys[i+1]
+			}
+			hash := identityHash(nextKey)
+			identity := make([]byte, identityHeaderSize)
+			newIdentityCipher(identityKey, salt).Encrypt(identity, hash[:])
+			out = append(out, identity...)
+		}
+		out = aead.seal(out, fixedHeader[:])
+		out = aead.seal(out, variableHeader)
+		return out, mb
+	}
+	return salt, w, nil
+}
+
+// ReadTCPResponse2022 reads a Shadowsocks 2022 response to the request of the salt from the given reader, and returns
+// a reader for body.
+func ReadTCPResponse2022(user *protocol.MemoryUser, requestSalt []byte, reader io.Reader) (buf.Reader, error) {
+	account := user.Account.(*MemoryAccount)
+
+	salt := make([]byte, account.Cipher.IVSize())
+	if _, err := io.ReadFull(reader, salt); err != nil {
+		return nil, newError("failed to read salt").Base(err)
+	}
+
+	aead := newSessionAEAD(account, account.Key, salt)
+	fixedHeader, err := aead.readSealed(reader, 1+8+len(salt)+2)
+	if err != nil {
+		return nil, newError("failed to read response header").Base(err)
+	}
+	if fixedHeader[0] != headerTypeServer2022 {
+		return nil, newError("invalid response header type: ", fixedHeader[0])
+	}
+	if err := checkTimestamp(fixedHeader[1:9]); err != nil {
+		return nil, newError("invalid response").Base(err)
+	}
+	if !bytes.Equal(fixedHeader[9:9+len(salt)], requestSalt) {
+		return nil, newError("response is not to the request")
+	}
+	if err := account.CheckIV(salt); err != nil {
+		return nil, newError("failed salt check").Base(err)
+	}
+
+	payload, err := aead.readSealed(reader, int(binary.BigEndian.Uint16(fixedHeader[9+len(salt):])))
+	if err != nil {
+		return nil, newError("failed to read response payload").Base(err)
+	}
+	return &chunkReader2022{
+		reader:  reader,
+		aead:    aead,
+		pending: buf.MergeBytes(nil, payload),
+	}, nil
+}
+
+// WriteTCPResponse2022 writes a Shadowsocks 2022 response to the request of the salt into the given writer, and
+// returns a writer for body. The header is written with the first payload.
+func WriteTCPResponse2022(request *protocol.RequestHeader, requestSalt []byte, writer io.Writer) (buf.Writer, error) {
+	account := request.User.Account.(*MemoryAccount)
+
+	salt := make([]byte, account.Cipher.IVSize())
+	common.Must2(rand.Read(salt))
+	if err := account.CheckIV(salt); err != nil {
+		return nil, newError("failed to mark outgoing salt").Base(err)
+	}
+
+	aead := newSessionAEAD(account, account.Key, salt)
+	w := &chunkWriter2022{
+		writer: writer,
+		aead:   aead,
+	}
+	w.header = func(mb buf.MultiBuffer) ([]byte, buf.MultiBuffer) {
+		size := mb.Len()
+		if size > maxChunkSize2022 {
+			size = maxChunkSize2022
+		}
+		payload := make([]byte, size)
+		mb, _ = buf.SplitBytes(mb, payload)
+
+		fixedHeader := make([]byte, 1+8+len(requestSalt)+2)
+		fixedHeader[0] = headerTypeServer2022
+		putTimestamp(fixedHeader[1:9])
+		copy(fixedHeader[9:], requestSalt)
+		binary.BigEndian.PutUint16(fixedHeader[9+len(requestSalt):], uint16(size))
+
+		out := append([]byte(nil), salt...)
+		out = aead.seal(out, fixedHeader)
+		out = aead.seal(out, payload)
+		return out, mb
+	}
+	return w, nil
+}
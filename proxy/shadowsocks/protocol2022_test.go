@@ -0,0 +1,275 @@
+package shadowsocks
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/protocol"
+)
+
+func newKey2022(size int) string {
+	key := make([]byte, size)
+	common.Must2(rand.Read(key))
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func newTestUser(email string, cipherType CipherType, password string) *protocol.MemoryUser {
+	account, err := (&Account{
+		Password:   password,
+		CipherType: cipherType,
+	}).AsAccount()
+	common.Must(err)
+	return &protocol.MemoryUser{
+		Email:   email,
+		Account: account,
+	}
+}
+
+func newPayload(size int) []byte {
+	payload := make([]byte, size)
+	common.Must2(rand.Read(payload))
+	return payload
+}
+
+// roundTripTCP2022 sends a request with the payload from the client to the server, and the payload back, and returns
+// the request the server reads.
+func roundTripTCP2022(t *testing.T, client, server *protocol.MemoryUser, users IdentityUsers, payload []byte) *protocol.RequestHeader {
+	request := &protocol.RequestHeader{
+		Version: Version,
+		Command: protocol.RequestCommandTCP,
+		Address: net.DomainAddress("v2fly.org"),
+		Port:    443,
+		User:    client,
+	}
+
+	stream := new(bytes.Buffer)
+	requestSalt, requestWriter, err := WriteTCPRequest2022(request, stream)
+	common.Must(err)
+	common.Must(requestWriter.WriteMultiBuffer(buf.MergeBytes(nil, payload)))
+	common.Must(requestWriter.(*chunkWriter2022).flushHeader())
+
+	decodedRequest, salt, requestReader, err := ReadTCPSession2022(server, users, stream)
+	common.Must(err)
+	if decodedRequest.Destination() != net.TCPDestination(net.DomainAddress("v2fly.org"), 443) {
+		t.Error("unexpected destination: ", decodedRequest.Destination())
+	}
+	if !bytes.Equal(salt, requestSalt) {
+		t.Error("unexpected salt")
+	}
+	if b := common.Must2(buf.ReadAllToBytes(&buf.BufferedReader{Reader: requestReader})).([]byte); !bytes.Equal(b, payload) {
+		t.Error("unexpected request payload of ", len(b), " bytes")
+	}
+	if len(payload) == 0 {
+		return decodedRequest
+	}
+
+	responseWriter, err := WriteTCPResponse2022(decodedRequest, salt, stream)
+	common.Must(err)
+	common.Must(responseWriter.WriteMultiBuffer(buf.MergeBytes(nil, payload)))
+
+	responseReader, err := ReadTCPResponse2022(client, requestSalt, stream)
+	common.Must(err)
+	if b := common.Must2(buf.ReadAllToBytes(&buf.BufferedReader{Reader: responseReader})).([]byte); !bytes.Equal(b, payload) {
+		t.Error("unexpected response payload of ", len(b), " bytes")
+	}
+	return decodedRequest
+}
+
+func TestTCPSession2022(t *testing.T) {
+	cases := []struct {
+		cipherType CipherType
+		keySize    int
+	}{
+		{CipherType_BLAKE3_AES_128_GCM, 16},
+		{CipherType_BLAKE3_AES_256_GCM, 32},
+		{CipherType_BLAKE3_CHACHA20_POLY1305, 32},
+	}
+	for _, c := range cases {
+		password := newKey2022(c.keySize)
+		for _, size := range []int{0, 1024, 200000} {
+			roundTripTCP2022(t, newTestUser("", c.cipherType, password), newTestUser("", c.cipherType, password), nil, newPayload(size))
+		}
+	}
+}
+
+func TestTCPSession2022Identity(t *testing.T) {
+	serverKey := newKey2022(32)
+	userKey := newKey2022(32)
+
+	server := newTestUser("", CipherType_BLAKE3_AES_256_GCM, serverKey)
+	user := newTestUser("love@v2fly.org", CipherType_BLAKE3_AES_256_GCM, userKey)
+	users := make(IdentityUsers)
+	users.Add(newTestUser("another@v2fly.org", CipherType_BLAKE3_AES_256_GCM, newKey2022(32)))
+	users.Add(user)
+
+	client := newTestUser("", CipherType_BLAKE3_AES_256_GCM, serverKey+":"+userKey)
+	if request := roundTripTCP2022(t, client, server, users, []byte("test string")); request.User != user {
+		t.Error("expected the request of ", user.Email, ", but got ", request.User.Email)
+	}
+
+	stranger := newTestUser("", CipherType_BLAKE3_AES_256_GCM, serverKey+":"+newKey2022(32))
+	stream := new(bytes.Buffer)
+	_, writer, err := WriteTCPRequest2022(&protocol.RequestHeader{
+		Version: Version,
+		Command: protocol.RequestCommandTCP,
+		Address: net.LocalHostIP,
+		Port:    1234,
+		User:    stranger,
+	}, stream)
+	common.Must(err)
+	common.Must(writer.(*chunkWriter2022).flushHeader())
+	if _, _, _, err := ReadTCPSession2022(server, users, stream); err == nil {
+		t.Error("expected the request of an unknown user to be rejected")
+	}
+}
+
+func TestTCPSession2022Replay(t *testing.T) {
+	password := newKey2022(16)
+	client := newTestUser("", CipherType_BLAKE3_AES_128_GCM, password)
+	server := newTestUser("", CipherType_BLAKE3_AES_128_GCM, password)
+
+	stream := new(bytes.Buffer)
+	_, writer, err := WriteTCPRequest2022(&protocol.RequestHeader{
+		Version: Version,
+		Command: protocol.RequestCommandTCP,
+		Address: net.LocalHostIP,
+		Port:    1234,
+		User:    client,
+	}, stream)
+	common.Must(err)
+	common.Must(writer.WriteMultiBuffer(buf.MergeBytes(nil, []byte("test string"))))
+	replayed := append([]byte(nil), stream.Bytes()...)
+
+	if _, _, _, err := ReadTCPSession2022(server, nil, stream); err != nil {
+		t.Error("failed to read request: ", err)
+	}
+	if _, _, _, err := ReadTCPSession2022(server, nil, bytes.NewReader(replayed)); err == nil {
+		t.Error("expected the replayed request to be rejected")
+	}
+}
+
+func TestTimestamp2022(t *testing.T) {
+	var timestamp [8]byte
+	putTimestamp(timestamp[:])
+	if err := checkTimestamp(timestamp[:]); err != nil {
+		t.Error(err)
+	}
+	binary.BigEndian.PutUint64(timestamp[:], uint64(time.Now().Add(-time.Minute).Unix()))
+	if err := checkTimestamp(timestamp[:]); err == nil {
+		t.Error("expected the timestamp a minute ago to be rejected")
+	}
+}
+
+func TestUDPSession2022(t *testing.T) {
+	serverKey := newKey2022(16)
+	userKey := newKey2022(16)
+	chachaKey := newKey2022(32)
+
+	user := newTestUser("love@v2fly.org", CipherType_BLAKE3_AES_128_GCM, userKey)
+	users := make(IdentityUsers)
+	users.Add(user)
+
+	cases := []struct {
+		client *protocol.MemoryUser
+		server *udpServer2022
+		user   *protocol.MemoryUser
+	}{
+		{
+			client: newTestUser("", CipherType_BLAKE3_AES_128_GCM, serverKey+":"+userKey),
+			server: newUDPServer2022(newTestUser("", CipherType_BLAKE3_AES_128_GCM, serverKey), users),
+			user:   user,
+		},
+		{
+			client: newTestUser("", CipherType_BLAKE3_CHACHA20_POLY1305, chachaKey),
+			server: newUDPServer2022(newTestUser("", CipherType_BLAKE3_CHACHA20_POLY1305, chachaKey), nil),
+		},
+	}
+	for _, c := range cases {
+		if c.user == nil {
+			c.user = c.server.user
+		}
+		request := &protocol.RequestHeader{
+			Version: Version,
+			Command: protocol.RequestCommandUDP,
+			Address: net.LocalHostIP,
+			Port:    53,
+			User:    c.client,
+		}
+		clientSession := newUDPSession2022(c.client)
+		payload := []byte("test string")
+
+		packet, err := clientSession.encode(request, payload, false)
+		common.Must(err)
+		b := buf.New()
+		common.Must2(b.Write(packet))
+		decodedRequest, serverSession, err := c.server.decode(b)
+		common.Must(err)
+		if decodedRequest.User != c.user {
+			t.Error("unexpected user ", decodedRequest.User.Email)
+		}
+		if decodedRequest.Destination() != net.UDPDestination(net.LocalHostIP, 53) {
+			t.Error("unexpected destination: ", decodedRequest.Destination())
+		}
+		if r := cmp.Diff(b.Bytes(), payload); r != "" {
+			t.Error("request payload: ", r)
+		}
+
+		b.Clear()
+		common.Must2(b.Write(packet))
+		if _, _, err := c.server.decode(b); err == nil {
+			t.Error("expected the replayed packet to be rejected")
+		}
+
+		response, err := serverSession.encode(decodedRequest, payload, true)
+		common.Must(err)
+		b.Clear()
+		common.Must2(b.Write(response))
+		common.Must(clientSession.decode(b))
+		if r := cmp.Diff(b.Bytes(), payload); r != "" {
+			t.Error("response payload: ", r)
+		}
+	}
+}
+
+func TestUDPServer2022Expire(t *testing.T) {
+	key := newKey2022(32)
+	server := newUDPServer2022(newTestUser("", CipherType_BLAKE3_CHACHA20_POLY1305, key), nil)
+	request := &protocol.RequestHeader{
+		Version: Version,
+		Command: protocol.RequestCommandUDP,
+		Address: net.LocalHostIP,
+		Port:    53,
+	}
+
+	decode := func(client *udpSession2022) {
+		packet, err := client.encode(request, []byte("test string"), false)
+		common.Must(err)
+		b := buf.New()
+		defer b.Release()
+		common.Must2(b.Write(packet))
+		_, _, err = server.decode(b)
+		common.Must(err)
+	}
+	idle := newUDPSession2022(newTestUser("", CipherType_BLAKE3_CHACHA20_POLY1305, key))
+	decode(idle)
+	server.lastSeen[idle.id] = time.Now().Add(-udpSessionTimeout2022)
+	server.nextSweep = time.Time{}
+	active := newUDPSession2022(newTestUser("", CipherType_BLAKE3_CHACHA20_POLY1305, key))
+	decode(active)
+
+	if _, found := server.sessions[idle.id]; found {
+		t.Error("expected idle session to be removed")
+	}
+	if _, found := server.sessions[active.id]; !found {
+		t.Error("expected active session to be kept")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	core "github.com/v2fly/v2ray-core/v4"
 	"github.com/v2fly/v2ray-core/v4/common"
 	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/drain"
 	"github.com/v2fly/v2ray-core/v4/common/log"
 	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/common/protocol"
@@ -16,34 +17,82 @@ import (
 	"github.com/v2fly/v2ray-core/v4/common/task"
 	"github.com/v2fly/v2ray-core/v4/features/policy"
 	"github.com/v2fly/v2ray-core/v4/features/routing"
+	"github.com/v2fly/v2ray-core/v4/proxy/fallback"
 	"github.com/v2fly/v2ray-core/v4/transport/internet"
 	"github.com/v2fly/v2ray-core/v4/transport/internet/udp"
 )
 
 type Server struct {
-	config        *ServerConfig
-	user          *protocol.MemoryUser
+	config *ServerConfig
+	// user is the only user of the server, or the server itself if users are identified by identity headers.
+	user *protocol.MemoryUser
+	// users are identified by trying their keys on the sessions.
+	users         []*protocol.MemoryUser
+	identityUsers IdentityUsers
+	fallbacks     fallback.Fallbacks
 	policyManager policy.Manager
 }
 
 // NewServer create a new Shadowsocks server.
 func NewServer(ctx context.Context, config *ServerConfig) (*Server, error) {
-	if config.GetUser() == nil {
+	if config.GetUser() == nil && len(config.Users) == 0 {
 		return nil, newError("user is not specified")
 	}
 
-	mUser, err := config.User.ToMemoryUser()
-	if err != nil {
-		return nil, newError("failed to parse user account").Base(err)
-	}
-
 	v := core.MustFromContext(ctx)
 	s := &Server{
 		config:        config,
-		user:          mUser,
+		fallbacks:     fallback.New(config.Fallbacks),
 		policyManager: v.GetFeature(policy.ManagerType()).(policy.Manager),
 	}
 
+	var users []*protocol.MemoryUser
+	if config.User != nil {
+		mUser, err := config.User.ToMemoryUser()
+		if err != nil {
+			return nil, newError("failed to parse user account").Base(err)
+		}
+		users = append(users, mUser)
+	}
+	for _, user := range config.Users {
+		mUser, err := user.ToMemoryUser()
+		if err != nil {
+			return nil, newError("failed to parse user account").Base(err)
+		}
+		users = append(users, mUser)
+	}
+
+	if config.User != nil {
+		if c, ok := users[0].Account.(*MemoryAccount).Cipher.(*Cipher2022); ok {
+			s.user = users[0]
+			if len(users) == 1 {
+				return s, nil
+			}
+			if !c.BlockCipher {
+				return nil, newError("users are only supported by AES ciphers of Shadowsocks 2022")
+			}
+			s.identityUsers = make(IdentityUsers)
+			for _, u := range users[1:] {
+				if uc, ok := u.Account.(*MemoryAccount).Cipher.(*Cipher2022); !ok || uc.KeyBytes != c.KeyBytes || !uc.BlockCipher {
+					return nil, newError("user ", u.Email, " is not of the cipher of the server")
+				}
+				s.identityUsers.Add(u)
+			}
+			return s, nil
+		}
+	}
+
+	if len(users) == 1 {
+		s.user = users[0]
+		return s, nil
+	}
+	for _, u := range users {
+		if _, ok := u.Account.(*MemoryAccount).Cipher.(*AEADCipher); !ok {
+			return nil, newError("user ", u.Email, " is not of an AEAD cipher, which multiple users must be of")
+		}
+	}
+	s.users = users
+
 	return s, nil
 }
 
@@ -69,6 +118,14 @@ func (s *Server) Process(ctx context.Context, network net.Network, conn internet
 	}
 }
 
+func (s *Server) is2022() bool {
+	if s.user == nil {
+		return false
+	}
+	_, ok := s.user.Account.(*MemoryAccount).Cipher.(*Cipher2022)
+	return ok
+}
+
 func (s *Server) handlerUDPPayload(ctx context.Context, conn internet.Connection, dispatcher routing.Dispatcher) error {
 	udpServer := udp.NewDispatcher(dispatcher, func(ctx context.Context, packet *udp_proto.Packet) {
 		request := protocol.RequestHeaderFromContext(ctx)
@@ -77,6 +134,16 @@ func (s *Server) handlerUDPPayload(ctx context.Context, conn internet.Connection
 		}
 
 		payload := packet.Payload
+		if udpSession := udpSession2022FromContext(ctx); udpSession != nil {
+			data, err := udpSession.encode(request, payload.Bytes(), true)
+			payload.Release()
+			if err != nil {
+				newError("failed to encode UDP packet").Base(err).AtWarning().WriteToLog(session.ExportIDToError(ctx))
+				return
+			}
+			conn.Write(data)
+			return
+		}
 		data, err := EncodeUDPPacket(request, payload.Bytes())
 		payload.Release()
 		if err != nil {
@@ -94,6 +161,11 @@ func (s *Server) handlerUDPPayload(ctx context.Context, conn internet.Connection
 	}
 	inbound.User = s.user
 
+	var server2022 *udpServer2022
+	if s.is2022() {
+		server2022 = newUDPServer2022(s.user, s.identityUsers)
+	}
+
 	reader := buf.NewPacketReader(conn)
 	for {
 		mpayload, err := reader.ReadMultiBuffer()
@@ -102,7 +174,18 @@ func (s *Server) handlerUDPPayload(ctx context.Context, conn internet.Connection
 		}
 
 		for _, payload := range mpayload {
-			request, data, err := DecodeUDPPacket(s.user, payload)
+			var request *protocol.RequestHeader
+			var data *buf.Buffer
+			var session2022 *udpSession2022
+			switch {
+			case server2022 != nil:
+				request, session2022, err = server2022.decode(payload)
+				data = payload
+			case len(s.users) > 0:
+				request, data, err = s.decodeUDPPacket(payload)
+			default:
+				request, data, err = DecodeUDPPacket(s.user, payload)
+			}
 			if err != nil {
 				if inbound := session.InboundFromContext(ctx); inbound != nil && inbound.Source.IsValid() {
 					newError("dropping invalid UDP packet from: ", inbound.Source).Base(err).WriteToLog(session.ExportIDToError(ctx))
@@ -131,6 +214,13 @@ func (s *Server) handlerUDPPayload(ctx context.Context, conn internet.Connection
 			newError("tunnelling request to ", dest).WriteToLog(session.ExportIDToError(currentPacketCtx))
 
 			currentPacketCtx = protocol.ContextWithRequestHeader(currentPacketCtx, request)
+			// The inbound is shared by the packets of the connection, which may be of different users.
+			packetInbound := *inbound
+			packetInbound.User = request.User
+			currentPacketCtx = session.ContextWithInbound(currentPacketCtx, &packetInbound)
+			if session2022 != nil {
+				currentPacketCtx = contextWithUDPSession2022(currentPacketCtx, session2022)
+			}
 			udpServer.Dispatch(currentPacketCtx, dest, data)
 		}
 	}
@@ -139,11 +229,36 @@ func (s *Server) handlerUDPPayload(ctx context.Context, conn internet.Connection
 }
 
 func (s *Server) handleConnection(ctx context.Context, conn internet.Connection, dispatcher routing.Dispatcher) error {
-	sessionPolicy := s.policyManager.ForLevel(s.user.Level)
+	var sessionPolicy policy.Session
+	if s.user != nil {
+		sessionPolicy = s.policyManager.ForLevel(s.user.Level)
+	} else {
+		sessionPolicy = s.policyManager.ForLevel(0)
+	}
 	conn.SetReadDeadline(time.Now().Add(sessionPolicy.Timeouts.Handshake))
 
-	bufferedReader := buf.BufferedReader{Reader: buf.NewReader(conn)}
-	request, bodyReader, err := ReadTCPSession(s.user, &bufferedReader)
+	var connReader buf.Reader = buf.NewReader(conn)
+	var fallbackReader *fallback.Reader
+	var drainer drain.Drainer
+	if s.fallbacks != nil {
+		// The sessions failing the handshake are forwarded with what is read from them, so they are not drained.
+		fallbackReader = fallback.NewReader(connReader)
+		connReader = fallbackReader
+		drainer = drain.NewNopDrainer()
+	}
+	bufferedReader := buf.BufferedReader{Reader: connReader}
+	var request *protocol.RequestHeader
+	var requestSalt []byte
+	var bodyReader buf.Reader
+	var err error
+	switch {
+	case s.is2022():
+		request, requestSalt, bodyReader, err = readTCPSession2022(s.user, s.identityUsers, &bufferedReader, drainer)
+	case len(s.users) > 0:
+		request, bodyReader, err = s.readTCPSession(&bufferedReader, drainer)
+	default:
+		request, bodyReader, err = readTCPSession(s.user, &bufferedReader, drainer)
+	}
 	if err != nil {
 		log.Record(&log.AccessMessage{
 			From:   conn.RemoteAddr(),
@@ -151,15 +266,23 @@ func (s *Server) handleConnection(ctx context.Context, conn internet.Connection,
 			Status: log.AccessRejected,
 			Reason: err,
 		})
+		if fallbackReader != nil {
+			newError("failed to create request from: ", conn.RemoteAddr()).Base(err).AtInfo().WriteToLog(session.ExportIDToError(ctx))
+			return s.fallbacks.Serve(ctx, sessionPolicy, conn, fallbackReader)
+		}
 		return newError("failed to create request from: ", conn.RemoteAddr()).Base(err)
 	}
+	if fallbackReader != nil {
+		fallbackReader.Commit()
+	}
 	conn.SetReadDeadline(time.Time{})
 
 	inbound := session.InboundFromContext(ctx)
 	if inbound == nil {
 		panic("no inbound metadata")
 	}
-	inbound.User = s.user
+	inbound.User = request.User
+	sessionPolicy = s.policyManager.ForLevel(request.User.Level)
 
 	dest := request.Destination()
 	ctx = log.ContextWithAccessMessage(ctx, &log.AccessMessage{
@@ -184,7 +307,13 @@ func (s *Server) handleConnection(ctx context.Context, conn internet.Connection,
 		defer timer.SetTimeout(sessionPolicy.Timeouts.UplinkOnly)
 
 		bufferedWriter := buf.NewBufferedWriter(buf.NewWriter(conn))
-		responseWriter, err := WriteTCPResponse(request, bufferedWriter)
+		var responseWriter buf.Writer
+		var err error
+		if requestSalt != nil {
+			responseWriter, err = WriteTCPResponse2022(request, requestSalt, bufferedWriter)
+		} else {
+			responseWriter, err = WriteTCPResponse(request, bufferedWriter)
+		}
 		if err != nil {
 			return newError("failed to write response").Base(err)
 		}
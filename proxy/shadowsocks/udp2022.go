@@ -0,0 +1,335 @@
+package shadowsocks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/protocol"
+)
+
+const (
+	// separateHeaderSize is the size of the session ID and packet ID of a UDP packet of Shadowsocks 2022.
+	separateHeaderSize = 8 + 8
+	// udpSessionTimeout2022 is how long the session of a client is kept without packets from it.
+	udpSessionTimeout2022 = 5 * time.Minute
+)
+
+// packetWindow is a sliding window of the packet IDs seen in a UDP session, rejecting replayed and too old packets.
+type packetWindow struct {
+	last uint64
+	seen uint64
+	used bool
+}
+
+func (w *packetWindow) check(id uint64) bool {
+	switch {
+	case !w.used:
+		w.used = true
+		w.last = id
+		w.seen = 1
+	case id > w.last:
+		if shift := id - w.last; shift < 64 {
+			w.seen = w.seen<<shift | 1
+		} else {
+			w.seen = 1
+		}
+		w.last = id
+	default:
+		diff := w.last - id
+		if diff >= 64 || w.seen&(1<<diff) != 0 {
+			return false
+		}
+		w.seen |= 1 << diff
+	}
+	return true
+}
+
+// udpSession2022 is a UDP session of Shadowsocks 2022 with its own session ID and packet IDs, and those of the other
+// side.
+type udpSession2022 struct {
+	id       uint64
+	packetID uint64
+	// user is whose key the packets are sealed with.
+	user *protocol.MemoryUser
+
+	access   sync.Mutex
+	remoteID uint64
+	window   packetWindow
+}
+
+func newUDPSession2022(user *protocol.MemoryUser) *udpSession2022 {
+	var id [8]byte
+	common.Must2(rand.Read(id[:]))
+	return &udpSession2022{
+		id:   binary.BigEndian.Uint64(id[:]),
+		user: user,
+	}
+}
+
+// checkPacket checks the packet ID of the session of the other side, which is started over when the session changes.
+func (s *udpSession2022) checkPacket(remoteID uint64, packetID uint64) error {
+	s.access.Lock()
+	defer s.access.Unlock()
+
+	if remoteID != s.remoteID {
+		s.remoteID = remoteID
+		s.window = packetWindow{}
+	}
+	if !s.window.check(packetID) {
+		return newError("packet ", packetID, " is replayed or too old")
+	}
+	return nil
+}
+
+// encode seals the payload to or from the destination of the request in a packet of the session. Servers send packets
+// with the session IDs of the clients, and clients send them with their identity headers.
+func (s *udpSession2022) encode(request *protocol.RequestHeader, payload []byte, fromServer bool) ([]byte, error) {
+	account := s.user.Account.(*MemoryAccount)
+	c := account.Cipher.(*Cipher2022)
+
+	body := make([]byte, separateHeaderSize, separateHeaderSize+1+8+8+2+len(payload)+64)
+	binary.BigEndian.PutUint64(body, s.id)
+	binary.BigEndian.PutUint64(body[8:], atomic.AddUint64(&s.packetID, 1)-1)
+	var timestamp [8]byte
+	putTimestamp(timestamp[:])
+	if fromServer {
+		body = append(body, headerTypeServer2022)
+		body = append(body, timestamp[:]...)
+		var clientID [8]byte
+		s.access.Lock()
+		binary.BigEndian.PutUint64(clientID[:], s.remoteID)
+		s.access.Unlock()
+		body = append(body, clientID[:]...)
+	} else {
+		body = append(body, headerTypeClient2022)
+		body = append(body, timestamp[:]...)
+	}
+	body = append(body, 0, 0)
+	address := buf.New()
+	defer address.Release()
+	if err := addrParser.WriteAddressPort(address, request.Address, request.Port); err != nil {
+		return nil, newError("failed to write address").Base(err)
+	}
+	body = append(body, address.Bytes()...)
+	body = append(body, payload...)
+
+	if !c.BlockCipher {
+		aead, err := chacha20poly1305.NewX(account.Key)
+		common.Must(err)
+		nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(body)+aead.Overhead())
+		common.Must2(rand.Read(nonce))
+		return aead.Seal(nonce, nonce, body, nil), nil
+	}
+
+	header := body[:separateHeaderSize]
+	aead := c.AEADAuthCreator(c.sessionKey(account.Key, header[:8]))
+	keys := [][]byte{account.Key}
+	if !fromServer && len(account.IdentityKeys) > 0 {
+		keys = append(append([][]byte(nil), account.IdentityKeys...), account.Key)
+	}
+	packet := make([]byte, separateHeaderSize*len(keys), separateHeaderSize*len(keys)+len(body)+aead.Overhead())
+	newBlockCipher(keys[0]).Encrypt(packet, header)
+	for i, identityKey := range keys[:len(keys)-1] {
+		hash := identityHash(keys[i+1])
+		for j := range hash {
+			hash[j] ^= header[j]
+		}
+		newBlockCipher(identityKey).Encrypt(packet[identityHeaderSize*(i+1):], hash[:])
+	}
+	return aead.Seal(packet, header[4:], body[separateHeaderSize:], nil), nil
+}
+
+// openUDPPacket2022 opens the packet with the key of the user, leaving its body in the buffer, and returns the separate
+// header. With users, the packet has an identity header, and is sealed with the key of the user it refers to.
+func openUDPPacket2022(user *protocol.MemoryUser, users IdentityUsers, packet *buf.Buffer) (*protocol.MemoryUser, []byte, error) {
+	account := user.Account.(*MemoryAccount)
+	c := account.Cipher.(*Cipher2022)
+
+	if !c.BlockCipher {
+		aead, err := chacha20poly1305.NewX(account.Key)
+		common.Must(err)
+		nonceSize := int32(aead.NonceSize())
+		if packet.Len() < nonceSize+separateHeaderSize+int32(aead.Overhead()) {
+			return nil, nil, newError("insufficient data: ", packet.Len())
+		}
+		b := packet.BytesFrom(nonceSize)
+		body, err := aead.Open(b[:0], packet.BytesTo(nonceSize), b, nil)
+		if err != nil {
+			return nil, nil, newError("failed to open packet").Base(err)
+		}
+		header := append([]byte(nil), body[:separateHeaderSize]...)
+		packet.Resize(nonceSize+separateHeaderSize, nonceSize+int32(len(body)))
+		return user, header, nil
+	}
+
+	offset := int32(separateHeaderSize)
+	if len(users) > 0 {
+		offset += identityHeaderSize
+	}
+	if packet.Len() < offset+16 {
+		return nil, nil, newError("insufficient data: ", packet.Len())
+	}
+	block := newBlockCipher(account.Key)
+	header := make([]byte, separateHeaderSize)
+	block.Decrypt(header, packet.BytesTo(separateHeaderSize))
+	if len(users) > 0 {
+		var hash [identityHeaderSize]byte
+		block.Decrypt(hash[:], packet.BytesRange(separateHeaderSize, offset))
+		for i := range hash {
+			hash[i] ^= header[i]
+		}
+		if user = users[hash]; user == nil {
+			return nil, nil, newError("unknown user identity")
+		}
+	}
+
+	aead := c.AEADAuthCreator(c.sessionKey(user.Account.(*MemoryAccount).Key, header[:8]))
+	b := packet.BytesFrom(offset)
+	body, err := aead.Open(b[:0], header[4:], b, nil)
+	if err != nil {
+		return nil, nil, newError("failed to open packet").Base(err)
+	}
+	packet.Resize(offset, offset+int32(len(body)))
+	return user, header, nil
+}
+
+// readUDPBody2022 reads the header of the body of a packet, leaving the payload in the buffer. Packets from servers
+// carry the session IDs of the clients.
+func readUDPBody2022(body *buf.Buffer, fromServer bool) (uint64, net.Address, net.Port, error) {
+	headerType := byte(headerTypeClient2022)
+	size := int32(1 + 8 + 2)
+	if fromServer {
+		headerType = headerTypeServer2022
+		size += 8
+	}
+	if body.Len() < size {
+		return 0, nil, 0, newError("insufficient data: ", body.Len())
+	}
+	if t := body.Byte(0); t != headerType {
+		return 0, nil, 0, newError("invalid packet header type: ", t)
+	}
+	if err := checkTimestamp(body.BytesRange(1, 9)); err != nil {
+		return 0, nil, 0, newError("invalid packet").Base(err)
+	}
+	var clientID uint64
+	if fromServer {
+		clientID = binary.BigEndian.Uint64(body.BytesRange(9, 17))
+	}
+	paddingLength := int32(binary.BigEndian.Uint16(body.BytesRange(size-2, size)))
+	if body.Len() < size+paddingLength {
+		return 0, nil, 0, newError("invalid padding length: ", paddingLength)
+	}
+	body.Advance(size + paddingLength)
+
+	addr, port, err := addrParser.ReadAddressPort(nil, body)
+	if err != nil {
+		return 0, nil, 0, newError("failed to parse address").Base(err)
+	}
+	return clientID, addr, port, nil
+}
+
+// decode opens a packet from the server to the client of the session, leaving its payload in the buffer.
+func (s *udpSession2022) decode(packet *buf.Buffer) error {
+	_, header, err := openUDPPacket2022(s.user, nil, packet)
+	if err != nil {
+		return err
+	}
+	clientID, _, _, err := readUDPBody2022(packet, true)
+	if err != nil {
+		return err
+	}
+	if clientID != s.id {
+		return newError("packet is not of the session")
+	}
+	return s.checkPacket(binary.BigEndian.Uint64(header), binary.BigEndian.Uint64(header[8:]))
+}
+
+// udpServer2022 keeps the UDP sessions of the clients of a Shadowsocks 2022 server, by their session IDs. Sessions idle
+// for udpSessionTimeout2022 are removed.
+type udpServer2022 struct {
+	user      *protocol.MemoryUser
+	users     IdentityUsers
+	sessions  map[uint64]*udpSession2022
+	lastSeen  map[uint64]time.Time
+	nextSweep time.Time
+}
+
+func newUDPServer2022(user *protocol.MemoryUser, users IdentityUsers) *udpServer2022 {
+	return &udpServer2022{
+		user:     user,
+		users:    users,
+		sessions: make(map[uint64]*udpSession2022),
+		lastSeen: make(map[uint64]time.Time),
+	}
+}
+
+// expire removes the sessions idle for udpSessionTimeout2022 by now. Sessions are swept at most once per timeout.
+func (s *udpServer2022) expire(now time.Time) {
+	if now.Before(s.nextSweep) {
+		return
+	}
+	s.nextSweep = now.Add(udpSessionTimeout2022)
+	for id, lastSeen := range s.lastSeen {
+		if now.Sub(lastSeen) >= udpSessionTimeout2022 {
+			delete(s.sessions, id)
+			delete(s.lastSeen, id)
+		}
+	}
+}
+
+// decode opens a packet from a client, leaving its payload in the buffer, and returns the request and the session of
+// the server for responses.
+func (s *udpServer2022) decode(packet *buf.Buffer) (*protocol.RequestHeader, *udpSession2022, error) {
+	user, header, err := openUDPPacket2022(s.user, s.users, packet)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, addr, port, err := readUDPBody2022(packet, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	s.expire(now)
+	clientID := binary.BigEndian.Uint64(header)
+	session := s.sessions[clientID]
+	if session == nil {
+		session = newUDPSession2022(user)
+		session.remoteID = clientID
+		s.sessions[clientID] = session
+	} else if session.user != user {
+		return nil, nil, newError("session is of another user")
+	}
+	if err := session.checkPacket(clientID, binary.BigEndian.Uint64(header[8:])); err != nil {
+		return nil, nil, err
+	}
+	s.lastSeen[clientID] = now
+
+	return &protocol.RequestHeader{
+		Version: Version,
+		User:    user,
+		Command: protocol.RequestCommandUDP,
+		Address: addr,
+		Port:    port,
+	}, session, nil
+}
+
+type udpSessionKey2022 struct{}
+
+func contextWithUDPSession2022(ctx context.Context, session *udpSession2022) context.Context {
+	return context.WithValue(ctx, udpSessionKey2022{}, session)
+}
+
+func udpSession2022FromContext(ctx context.Context) *udpSession2022 {
+	session, _ := ctx.Value(udpSessionKey2022{}).(*udpSession2022)
+	return session
+}
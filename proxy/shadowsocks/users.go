@@ -0,0 +1,65 @@
+package shadowsocks
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/drain"
+	"github.com/v2fly/v2ray-core/v4/common/protocol"
+)
+
+// readTCPSession reads a TCP session of the user whose key opens the length of its first chunk, and drains the reader
+// on failure with the drainer if any.
+func (s *Server) readTCPSession(reader io.Reader, drainer drain.Drainer) (*protocol.RequestHeader, buf.Reader, error) {
+	var ivLen int32
+	for _, user := range s.users {
+		if size := user.Account.(*MemoryAccount).Cipher.IVSize(); size > ivLen {
+			ivLen = size
+		}
+	}
+
+	// Any session starts with the IV and the sealed length of its first chunk, which is followed by the address.
+	head := make([]byte, ivLen+2+16)
+	n, err := io.ReadFull(reader, head)
+	if err != nil {
+		if drainer == nil {
+			var drainErr error
+			drainer, drainErr = newDrainer(s.users[0].Account.(*MemoryAccount))
+			if drainErr != nil {
+				return nil, nil, newError("failed to initialize drainer").Base(drainErr)
+			}
+		}
+		drainer.AcknowledgeReceive(n)
+		return nil, nil, drain.WithError(drainer, reader, newError("failed to read IV").Base(err))
+	}
+	reader = io.MultiReader(bytes.NewReader(head), reader)
+
+	user := s.users[0]
+	for _, u := range s.users {
+		account := u.Account.(*MemoryAccount)
+		c := account.Cipher.(*AEADCipher)
+		auth := c.createAuthenticator(account.Key, head[:c.IVBytes])
+		if _, err := auth.Open(nil, head[c.IVBytes:c.IVBytes+2+int32(auth.Overhead())]); err == nil {
+			user = u
+			break
+		}
+	}
+	// The session of no user is rejected as the first user's, which is drained in the same way.
+	return readTCPSession(user, reader, drainer)
+}
+
+// decodeUDPPacket decodes a UDP packet of the user whose key opens it.
+func (s *Server) decodeUDPPacket(payload *buf.Buffer) (*protocol.RequestHeader, *buf.Buffer, error) {
+	for _, user := range s.users {
+		packet := buf.New()
+		packet.Write(payload.Bytes())
+		request, data, err := DecodeUDPPacket(user, packet)
+		if err == nil {
+			payload.Release()
+			return request, data, nil
+		}
+		packet.Release()
+	}
+	return nil, nil, newError("no user matches the packet")
+}
@@ -0,0 +1,84 @@
+package shadowsocks
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/buf"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/protocol"
+)
+
+func newMultiUserServer() *Server {
+	return &Server{
+		users: []*protocol.MemoryUser{
+			newTestUser("aes-128@v2fly.org", CipherType_AES_128_GCM, "password-1"),
+			newTestUser("aes-256@v2fly.org", CipherType_AES_256_GCM, "password-2"),
+			newTestUser("chacha@v2fly.org", CipherType_CHACHA20_POLY1305, "password-3"),
+		},
+	}
+}
+
+func TestMultiUserTCPSession(t *testing.T) {
+	s := newMultiUserServer()
+	for _, user := range s.users {
+		stream := new(bytes.Buffer)
+		writer, err := WriteTCPRequest(&protocol.RequestHeader{
+			Version: Version,
+			Command: protocol.RequestCommandTCP,
+			Address: net.LocalHostIP,
+			Port:    1234,
+			User:    user,
+		}, stream)
+		common.Must(err)
+		common.Must(writer.WriteMultiBuffer(buf.MergeBytes(nil, []byte("test string"))))
+
+		request, reader, err := s.readTCPSession(stream, nil)
+		common.Must(err)
+		if request.User != user {
+			t.Error("expected the session of ", user.Email, ", but got ", request.User.Email)
+		}
+		if b := common.Must2(buf.ReadAllToBytes(&buf.BufferedReader{Reader: reader})).([]byte); string(b) != "test string" {
+			t.Error("unexpected payload: ", string(b))
+		}
+	}
+
+	stranger := newTestUser("", CipherType_AES_128_GCM, "password-4")
+	stream := new(bytes.Buffer)
+	writer, err := WriteTCPRequest(&protocol.RequestHeader{
+		Version: Version,
+		Command: protocol.RequestCommandTCP,
+		Address: net.LocalHostIP,
+		Port:    1234,
+		User:    stranger,
+	}, stream)
+	common.Must(err)
+	common.Must(writer.WriteMultiBuffer(buf.MergeBytes(nil, []byte("test string"))))
+	if _, _, err := s.readTCPSession(stream, nil); err == nil {
+		t.Error("expected the session of an unknown user to be rejected")
+	}
+}
+
+func TestMultiUserUDPPacket(t *testing.T) {
+	s := newMultiUserServer()
+	for _, user := range s.users {
+		packet, err := EncodeUDPPacket(&protocol.RequestHeader{
+			Version: Version,
+			Command: protocol.RequestCommandUDP,
+			Address: net.LocalHostIP,
+			Port:    53,
+			User:    user,
+		}, []byte("test string"))
+		common.Must(err)
+
+		request, data, err := s.decodeUDPPacket(packet)
+		common.Must(err)
+		if request.User != user {
+			t.Error("expected the packet of ", user.Email, ", but got ", request.User.Email)
+		}
+		if data.String() != "test string" {
+			t.Error("unexpected payload: ", data.String())
+		}
+	}
+}
@@ -5,7 +5,6 @@ package inbound
 import (
 	"context"
 	"io"
-	"strconv"
 	"time"
 
 	core "github.com/v2fly/v2ray-core/v4"
@@ -15,7 +14,6 @@ import (
 	"github.com/v2fly/v2ray-core/v4/common/log"
 	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/common/protocol"
-	"github.com/v2fly/v2ray-core/v4/common/retry"
 	"github.com/v2fly/v2ray-core/v4/common/serial"
 	"github.com/v2fly/v2ray-core/v4/common/session"
 	"github.com/v2fly/v2ray-core/v4/common/signal"
@@ -24,10 +22,10 @@ import (
 	feature_inbound "github.com/v2fly/v2ray-core/v4/features/inbound"
 	"github.com/v2fly/v2ray-core/v4/features/policy"
 	"github.com/v2fly/v2ray-core/v4/features/routing"
+	"github.com/v2fly/v2ray-core/v4/proxy/fallback"
 	"github.com/v2fly/v2ray-core/v4/proxy/vless"
 	"github.com/v2fly/v2ray-core/v4/proxy/vless/encoding"
 	"github.com/v2fly/v2ray-core/v4/transport/internet"
-	"github.com/v2fly/v2ray-core/v4/transport/internet/tls"
 )
 
 func init() {
@@ -67,8 +65,7 @@ type Handler struct {
 	policyManager         policy.Manager
 	validator             *vless.Validator
 	dns                   dns.Client
-	fallbacks             map[string]map[string]*Fallback // or nil
-	// regexps               map[string]*regexp.Regexp       // or nil
+	fallbacks             fallback.Fallbacks // or nil
 }
 
 // New creates a new VLess inbound handler.
@@ -91,36 +88,17 @@ func New(ctx context.Context, config *Config, dc dns.Client) (*Handler, error) {
 		}
 	}
 
-	if config.Fallbacks != nil {
-		handler.fallbacks = make(map[string]map[string]*Fallback)
-		// handler.regexps = make(map[string]*regexp.Regexp)
-		for _, fb := range config.Fallbacks {
-			if handler.fallbacks[fb.Alpn] == nil {
-				handler.fallbacks[fb.Alpn] = make(map[string]*Fallback)
-			}
-			handler.fallbacks[fb.Alpn][fb.Path] = fb
-			/*
-				if fb.Path != "" {
-					if r, err := regexp.Compile(fb.Path); err != nil {
-						return nil, newError("invalid path regexp").Base(err).AtError()
-					} else {
-						handler.regexps[fb.Path] = r
-					}
-				}
-			*/
-		}
-		if handler.fallbacks[""] != nil {
-			for alpn, pfb := range handler.fallbacks {
-				if alpn != "" { // && alpn != "h2" {
-					for path, fb := range handler.fallbacks[""] {
-						if pfb[path] == nil {
-							pfb[path] = fb
-						}
-					}
-				}
-			}
-		}
+	fallbacks := make([]*fallback.Fallback, 0, len(config.Fallbacks))
+	for _, fb := range config.Fallbacks {
+		fallbacks = append(fallbacks, &fallback.Fallback{
+			Alpn: fb.Alpn,
+			Path: fb.Path,
+			Type: fb.Type,
+			Dest: fb.Dest,
+			Xver: fb.Xver,
+		})
 	}
+	handler.fallbacks = fallback.New(fallbacks)
 
 	return handler, nil
 }
@@ -149,12 +127,6 @@ func (*Handler) Network() []net.Network {
 func (h *Handler) Process(ctx context.Context, network net.Network, connection internet.Connection, dispatcher routing.Dispatcher) error {
 	sid := session.ExportIDToError(ctx)
 
-	iConn := connection
-	statConn, ok := iConn.(*internet.StatCouterConnection)
-	if ok {
-		iConn = statConn.Connection
-	}
-
 	sessionPolicy := h.policyManager.ForLevel(0)
 	if err := connection.SetReadDeadline(time.Now().Add(sessionPolicy.Timeouts.Handshake)); err != nil {
 		return newError("unable to set read deadline").Base(err).AtWarning()
@@ -166,8 +138,14 @@ func (h *Handler) Process(ctx context.Context, network net.Network, connection i
 	firstLen, _ := first.ReadFrom(connection)
 	newError("firstLen = ", firstLen).AtInfo().WriteToLog(sid)
 
+	var connReader buf.Reader = buf.NewReader(connection)
+	var fallbackReader *fallback.Reader
+	if h.fallbacks != nil {
+		fallbackReader = fallback.NewReaderWithHead(first.Bytes(), connReader)
+		connReader = fallbackReader
+	}
 	reader := &buf.BufferedReader{
-		Reader: buf.NewReader(connection),
+		Reader: connReader,
 		Buffer: buf.MultiBuffer{first},
 	}
 
@@ -175,8 +153,7 @@ func (h *Handler) Process(ctx context.Context, network net.Network, connection i
 	var requestAddons *encoding.Addons
 	var err error
 
-	apfb := h.fallbacks
-	isfb := apfb != nil
+	isfb := h.fallbacks != nil
 
 	if isfb && firstLen < 18 {
 		err = newError("fallback directly")
@@ -186,164 +163,8 @@ func (h *Handler) Process(ctx context.Context, network net.Network, connection i
 
 	if err != nil {
 		if isfb {
-			if err := connection.SetReadDeadline(time.Time{}); err != nil {
-				newError("unable to set back read deadline").Base(err).AtWarning().WriteToLog(sid)
-			}
 			newError("fallback starts").Base(err).AtInfo().WriteToLog(sid)
-
-			alpn := ""
-			if len(apfb) > 1 || apfb[""] == nil {
-				if tlsConn, ok := iConn.(*tls.Conn); ok {
-					alpn = tlsConn.ConnectionState().NegotiatedProtocol
-					newError("realAlpn = " + alpn).AtInfo().WriteToLog(sid)
-				}
-				if apfb[alpn] == nil {
-					alpn = ""
-				}
-			}
-			pfb := apfb[alpn]
-			if pfb == nil {
-				return newError(`failed to find the default "alpn" config`).AtWarning()
-			}
-
-			path := ""
-			if len(pfb) > 1 || pfb[""] == nil {
-				/*
-					if lines := bytes.Split(firstBytes, []byte{'\r', '\n'}); len(lines) > 1 {
-						if s := bytes.Split(lines[0], []byte{' '}); len(s) == 3 {
-							if len(s[0]) < 8 && len(s[1]) > 0 && len(s[2]) == 8 {
-								newError("realPath = " + string(s[1])).AtInfo().WriteToLog(sid)
-								for _, fb := range pfb {
-									if fb.Path != "" && h.regexps[fb.Path].Match(s[1]) {
-										path = fb.Path
-										break
-									}
-								}
-							}
-						}
-					}
-				*/
-				if firstLen >= 18 && first.Byte(4) != '*' { // not h2c
-					firstBytes := first.Bytes()
-					for i := 4; i <= 8; i++ { // 5 -> 9
-						if firstBytes[i] == '/' && firstBytes[i-1] == ' ' {
-							search := len(firstBytes)
-							if search > 64 {
-								search = 64 // up to about 60
-							}
-							for j := i + 1; j < search; j++ {
-								k := firstBytes[j]
-								if k == '\r' || k == '\n' { // avoid logging \r or \n
-									break
-								}
-								if k == ' ' {
-									path = string(firstBytes[i:j])
-									newError("realPath = " + path).AtInfo().WriteToLog(sid)
-									if pfb[path] == nil {
-										path = ""
-									}
-									break
-								}
-							}
-							break
-						}
-					}
-				}
-			}
-			fb := pfb[path]
-			if fb == nil {
-				return newError(`failed to find the default "path" config`).AtWarning()
-			}
-
-			ctx, cancel := context.WithCancel(ctx)
-			timer := signal.CancelAfterInactivity(ctx, cancel, sessionPolicy.Timeouts.ConnectionIdle)
-			ctx = policy.ContextWithBufferPolicy(ctx, sessionPolicy.Buffer)
-
-			var conn net.Conn
-			if err := retry.ExponentialBackoff(5, 100).On(func() error {
-				var dialer net.Dialer
-				conn, err = dialer.DialContext(ctx, fb.Type, fb.Dest)
-				if err != nil {
-					return err
-				}
-				return nil
-			}); err != nil {
-				return newError("failed to dial to " + fb.Dest).Base(err).AtWarning()
-			}
-			defer conn.Close()
-
-			serverReader := buf.NewReader(conn)
-			serverWriter := buf.NewWriter(conn)
-
-			postRequest := func() error {
-				defer timer.SetTimeout(sessionPolicy.Timeouts.DownlinkOnly)
-				if fb.Xver != 0 {
-					remoteAddr, remotePort, err := net.SplitHostPort(connection.RemoteAddr().String())
-					if err != nil {
-						return err
-					}
-					localAddr, localPort, err := net.SplitHostPort(connection.LocalAddr().String())
-					if err != nil {
-						return err
-					}
-					ipv4 := true
-					for i := 0; i < len(remoteAddr); i++ {
-						if remoteAddr[i] == ':' {
-							ipv4 = false
-							break
-						}
-					}
-					pro := buf.New()
-					defer pro.Release()
-					switch fb.Xver {
-					case 1:
-						if ipv4 {
-							pro.Write([]byte("PROXY TCP4 " + remoteAddr + " " + localAddr + " " + remotePort + " " + localPort + "\r\n"))
-						} else {
-							pro.Write([]byte("PROXY TCP6 " + remoteAddr + " " + localAddr + " " + remotePort + " " + localPort + "\r\n"))
-						}
-
-					case 2:
-						pro.Write([]byte("\x0D\x0A\x0D\x0A\x00\x0D\x0A\x51\x55\x49\x54\x0A\x21")) // signature + v2 + PROXY
-						if ipv4 {
-							pro.Write([]byte("\x11\x00\x0C")) // AF_INET + STREAM + 12 bytes
-							pro.Write(net.ParseIP(remoteAddr).To4())
-							pro.Write(net.ParseIP(localAddr).To4())
-						} else {
-							pro.Write([]byte("\x21\x00\x24")) // AF_INET6 + STREAM + 36 bytes
-							pro.Write(net.ParseIP(remoteAddr).To16())
-							pro.Write(net.ParseIP(localAddr).To16())
-						}
-						p1, _ := strconv.ParseUint(remotePort, 10, 16)
-						p2, _ := strconv.ParseUint(localPort, 10, 16)
-						pro.Write([]byte{byte(p1 >> 8), byte(p1), byte(p2 >> 8), byte(p2)})
-					}
-					if err := serverWriter.WriteMultiBuffer(buf.MultiBuffer{pro}); err != nil {
-						return newError("failed to set PROXY protocol v", fb.Xver).Base(err).AtWarning()
-					}
-				}
-				if err := buf.Copy(reader, serverWriter, buf.UpdateActivity(timer)); err != nil {
-					return newError("failed to fallback request payload").Base(err).AtInfo()
-				}
-				return nil
-			}
-
-			writer := buf.NewWriter(connection)
-
-			getResponse := func() error {
-				defer timer.SetTimeout(sessionPolicy.Timeouts.UplinkOnly)
-				if err := buf.Copy(serverReader, writer, buf.UpdateActivity(timer)); err != nil {
-					return newError("failed to deliver response payload").Base(err).AtInfo()
-				}
-				return nil
-			}
-
-			if err := task.Run(ctx, task.OnSuccess(postRequest, task.Close(serverWriter)), task.OnSuccess(getResponse, task.Close(writer))); err != nil {
-				common.Interrupt(serverReader)
-				common.Interrupt(serverWriter)
-				return newError("fallback ends").Base(err).AtInfo()
-			}
-			return nil
+			return h.fallbacks.Serve(ctx, sessionPolicy, connection, fallbackReader)
 		}
 
 		if errors.Cause(err) != io.EOF {
@@ -358,6 +179,9 @@ func (h *Handler) Process(ctx context.Context, network net.Network, connection i
 		return err
 	}
 
+	if fallbackReader != nil {
+		fallbackReader.Commit()
+	}
 	if err := connection.SetReadDeadline(time.Time{}); err != nil {
 		newError("unable to set back read deadline").Base(err).AtWarning().WriteToLog(sid)
 	}
@@ -107,6 +107,8 @@ type ServerSession struct {
 	isAEADRequest bool
 
 	isAEADForced bool
+
+	isDrainDisabled bool
 }
 
 // NewServerSession creates a new ServerSession, using the given UserValidator.
@@ -123,6 +125,12 @@ func (s *ServerSession) SetAEADForced(isAEADForced bool) {
 	s.isAEADForced = isAEADForced
 }
 
+// SetDrainDisabled sets isDrainDisabled for a ServerSession, so that the connection is not drained when the request
+// header is invalid, as it is forwarded to a fallback.
+func (s *ServerSession) SetDrainDisabled(isDrainDisabled bool) {
+	s.isDrainDisabled = isDrainDisabled
+}
+
 func parseSecurityType(b byte) protocol.SecurityType {
 	if _, f := protocol.SecurityType_name[int32(b)]; f {
 		st := protocol.SecurityType(b)
@@ -139,9 +147,15 @@ func parseSecurityType(b byte) protocol.SecurityType {
 func (s *ServerSession) DecodeRequestHeader(reader io.Reader) (*protocol.RequestHeader, error) {
 	buffer := buf.New()
 
-	drainer, err := drain.NewBehaviorSeedLimitedDrainer(int64(s.userValidator.GetBehaviorSeed()), 16+38, 3266, 64)
-	if err != nil {
-		return nil, newError("failed to initialize drainer").Base(err)
+	var drainer drain.Drainer
+	if s.isDrainDisabled {
+		drainer = drain.NewNopDrainer()
+	} else {
+		var err error
+		drainer, err = drain.NewBehaviorSeedLimitedDrainer(int64(s.userValidator.GetBehaviorSeed()), 16+38, 3266, 64)
+		if err != nil {
+			return nil, newError("failed to initialize drainer").Base(err)
+		}
 	}
 
 	drainConnection := func(e error) error {
@@ -25,6 +25,7 @@ import (
 	feature_inbound "github.com/v2fly/v2ray-core/v4/features/inbound"
 	"github.com/v2fly/v2ray-core/v4/features/policy"
 	"github.com/v2fly/v2ray-core/v4/features/routing"
+	"github.com/v2fly/v2ray-core/v4/proxy/fallback"
 	"github.com/v2fly/v2ray-core/v4/proxy/vmess"
 	"github.com/v2fly/v2ray-core/v4/proxy/vmess/encoding"
 	"github.com/v2fly/v2ray-core/v4/transport/internet"
@@ -109,6 +110,7 @@ type Handler struct {
 	detours               *DetourConfig
 	sessionHistory        *encoding.SessionHistory
 	secure                bool
+	fallbacks             fallback.Fallbacks
 }
 
 // New creates a new VMess inbound handler.
@@ -122,6 +124,7 @@ func New(ctx context.Context, config *Config) (*Handler, error) {
 		usersByEmail:          newUserByEmail(config.GetDefaultValue()),
 		sessionHistory:        encoding.NewSessionHistory(),
 		secure:                config.SecureEncryptionOnly,
+		fallbacks:             fallback.New(config.Fallbacks),
 	}
 
 	for _, user := range config.User {
@@ -224,9 +227,16 @@ func (h *Handler) Process(ctx context.Context, network net.Network, connection i
 		return newError("unable to set read deadline").Base(err).AtWarning()
 	}
 
-	reader := &buf.BufferedReader{Reader: buf.NewReader(connection)}
+	var connReader buf.Reader = buf.NewReader(connection)
+	var fallbackReader *fallback.Reader
+	if h.fallbacks != nil {
+		fallbackReader = fallback.NewReader(connReader)
+		connReader = fallbackReader
+	}
+	reader := &buf.BufferedReader{Reader: connReader}
 	svrSession := encoding.NewServerSession(h.clients, h.sessionHistory)
 	svrSession.SetAEADForced(aeadForced)
+	svrSession.SetDrainDisabled(fallbackReader != nil)
 	request, err := svrSession.DecodeRequestHeader(reader)
 	if err != nil {
 		if errors.Cause(err) != io.EOF {
@@ -238,8 +248,15 @@ func (h *Handler) Process(ctx context.Context, network net.Network, connection i
 			})
 			err = newError("invalid request from ", connection.RemoteAddr()).Base(err).AtInfo()
 		}
+		if fallbackReader != nil {
+			newError("serving fallback").Base(err).AtInfo().WriteToLog(session.ExportIDToError(ctx))
+			return h.fallbacks.Serve(ctx, sessionPolicy, connection, fallbackReader)
+		}
 		return err
 	}
+	if fallbackReader != nil {
+		fallbackReader.Commit()
+	}
 
 	if h.secure && isInsecureEncryption(request.Security) {
 		log.Record(&log.AccessMessage{
@@ -0,0 +1,425 @@
+package core
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/serial"
+	"github.com/v2fly/v2ray-core/v4/features"
+	"github.com/v2fly/v2ray-core/v4/features/inbound"
+	"github.com/v2fly/v2ray-core/v4/features/outbound"
+)
+
+type appReload struct {
+	current  features.Reloadable
+	next     features.Feature
+	previous *anypb.Any
+}
+
+// Reload applies the given config to the running instance without restarting it.
+//
+// Inbound and outbound handlers are matched by tag. Unchanged handlers, and the connections they carry, are
+// left alone. Removed or modified handlers are closed, and new or modified ones are created from the new config.
+// Changed app settings are applied by the features implementing features.Reloadable, such as the router and DNS.
+//
+// Everything that cannot be applied in place is detected before the instance is touched: adding or removing
+// apps, changing apps that are not reloadable, changing untagged handlers, and changing the tag of the default
+// outbound handler. All new handlers and features are created before any of them is swapped in, and if one of
+// them fails to start, the changes made so far are undone. In these cases, the instance is left running the
+// previous config and an error is returned.
+//
+// v2ray:api:beta
+func (s *Instance) Reload(config *Config) error {
+	s.reloadAccess.Lock()
+	defer s.reloadAccess.Unlock()
+
+	if s.config == nil {
+		return newError("instance was not created from a config")
+	}
+	current := s.config
+
+	if !proto.Equal(current.Transport, config.Transport) {
+		return newError("global transport settings cannot be reloaded")
+	}
+
+	inboundsToRemove, inboundsToAdd, err := diffInbounds(current.Inbound, config.Inbound)
+	if err != nil {
+		return err
+	}
+	outboundsToRemove, outboundsToAdd, err := diffOutbounds(current.Outbound, config.Outbound)
+	if err != nil {
+		return err
+	}
+
+	r := &reload{instance: s, ctx: s.ctx}
+	if r.ctx == nil {
+		r.ctx = context.Background()
+	}
+	if err := r.create(current.App, config.App, inboundsToAdd, outboundsToAdd); err != nil {
+		r.discard()
+		return err
+	}
+	if err := r.apply(current.Inbound, inboundsToRemove); err != nil {
+		r.discard()
+		r.rollback()
+		return newError("config is not reloaded").Base(err)
+	}
+	r.commit(outboundsToRemove)
+
+	s.config = config
+	newError("config reloaded: ", len(r.apps), " app(s) updated, ",
+		len(inboundsToAdd), " inbound(s) added, ", len(inboundsToRemove), " inbound(s) removed, ",
+		len(outboundsToAdd), " outbound(s) added, ", len(outboundsToRemove), " outbound(s) removed").AtWarning().WriteToLog()
+	return nil
+}
+
+// reload is a reload in progress. It keeps what is created for the new config until it is swapped in, and how
+// to undo the swaps made so far.
+type reload struct {
+	instance *Instance
+	ctx      context.Context
+
+	apps      []appReload
+	inbounds  []inbound.Handler
+	outbounds []outbound.Handler
+	// applied is how many of the created apps, inbounds and outbounds are swapped in. The rest are closed by discard.
+	appliedApps, appliedInbounds, appliedOutbounds int
+
+	// replacedOutbounds are the handlers replaced by the new outbounds, which are closed on commit.
+	replacedOutbounds map[string]outbound.Handler
+	undo              []func()
+}
+
+// create creates the features and handlers of the new config, without adding them to the instance.
+func (r *reload) create(currentApps, nextApps []*anypb.Any, inbounds []*InboundHandlerConfig, outbounds []*OutboundHandlerConfig) error {
+	apps, err := r.instance.prepareAppReload(currentApps, nextApps)
+	if err != nil {
+		return err
+	}
+	r.apps = apps
+
+	for _, config := range inbounds {
+		rawHandler, err := CreateObject(r.instance, config)
+		if err != nil {
+			return newError("failed to create inbound ", config.Tag).Base(err)
+		}
+		handler, ok := rawHandler.(inbound.Handler)
+		if !ok {
+			common.Close(rawHandler)
+			return newError("not an InboundHandler: ", config.Tag)
+		}
+		r.inbounds = append(r.inbounds, handler)
+	}
+	for _, config := range outbounds {
+		rawHandler, err := CreateObject(r.instance, config)
+		if err != nil {
+			return newError("failed to create outbound ", config.Tag).Base(err)
+		}
+		handler, ok := rawHandler.(outbound.Handler)
+		if !ok {
+			common.Close(rawHandler)
+			return newError("not an OutboundHandler: ", config.Tag)
+		}
+		r.outbounds = append(r.outbounds, handler)
+	}
+	return nil
+}
+
+// apply swaps in the created outbounds, inbounds and apps, in this order. Removed inbounds are closed before new
+// ones start, as they may listen on the same ports.
+func (r *reload) apply(currentInbounds []*InboundHandlerConfig, inboundsToRemove []string) error {
+	outboundManager := r.instance.GetFeature(outbound.ManagerType()).(outbound.Manager)
+	r.replacedOutbounds = make(map[string]outbound.Handler)
+	for _, handler := range r.outbounds {
+		tag := handler.Tag()
+		previous := outboundManager.GetHandler(tag)
+		r.appliedOutbounds++
+		// The handler is in place even if it fails to start, so it is undone in either case.
+		r.undo = append(r.undo, func() {
+			if previous != nil {
+				if err := outboundManager.AddHandler(r.ctx, previous); err != nil {
+					newError("failed to restore outbound ", tag).Base(err).AtError().WriteToLog()
+				}
+			} else {
+				if err := outboundManager.RemoveHandler(r.ctx, tag); err != nil {
+					newError("failed to remove outbound ", tag).Base(err).AtError().WriteToLog()
+				}
+			}
+			common.Close(handler)
+		})
+		if err := outboundManager.AddHandler(r.ctx, handler); err != nil {
+			return newError("failed to add outbound ", tag).Base(err)
+		}
+		if previous != nil {
+			r.replacedOutbounds[tag] = previous
+		}
+	}
+
+	inboundManager := r.instance.GetFeature(inbound.ManagerType()).(inbound.Manager)
+	currentInboundByTag := make(map[string]*InboundHandlerConfig)
+	for _, config := range currentInbounds {
+		if config.Tag != "" {
+			currentInboundByTag[config.Tag] = config
+		}
+	}
+	for _, tag := range inboundsToRemove {
+		if err := inboundManager.RemoveHandler(r.ctx, tag); err != nil {
+			return newError("failed to remove inbound ", tag).Base(err)
+		}
+		previous := currentInboundByTag[tag]
+		// The removed handler is closed, so a new one is created from its config to restore it.
+		r.undo = append(r.undo, func() {
+			if err := AddInboundHandler(r.instance, previous); err != nil {
+				newError("failed to restore inbound ", previous.Tag).Base(err).AtError().WriteToLog()
+			}
+		})
+	}
+	for _, handler := range r.inbounds {
+		tag := handler.Tag()
+		r.appliedInbounds++
+		r.undo = append(r.undo, func() {
+			if err := inboundManager.RemoveHandler(r.ctx, tag); err != nil {
+				newError("failed to remove inbound ", tag).Base(err).AtError().WriteToLog()
+			}
+		})
+		if err := inboundManager.AddHandler(r.ctx, handler); err != nil {
+			return newError("failed to add inbound ", tag).Base(err)
+		}
+	}
+
+	for _, app := range r.apps {
+		app := app
+		r.appliedApps++
+		if err := app.current.ReplaceWith(app.next); err != nil {
+			// A feature that fails to take the new settings keeps its own.
+			common.Close(app.next)
+			return newError("failed to reload ", serial.V2Type(app.previous)).Base(err)
+		}
+		r.undo = append(r.undo, func() {
+			if err := r.instance.restoreApp(app); err != nil {
+				newError("failed to restore ", serial.V2Type(app.previous)).Base(err).AtError().WriteToLog()
+			}
+		})
+	}
+	return nil
+}
+
+// commit closes the replaced outbounds, and removes those not in the new config.
+func (r *reload) commit(outboundsToRemove []string) {
+	outboundManager := r.instance.GetFeature(outbound.ManagerType()).(outbound.Manager)
+	for _, tag := range outboundsToRemove {
+		handler, replaced := r.replacedOutbounds[tag]
+		if !replaced {
+			handler = outboundManager.GetHandler(tag)
+			if err := outboundManager.RemoveHandler(r.ctx, tag); err != nil {
+				newError("failed to remove outbound ", tag).Base(err).AtWarning().WriteToLog()
+			}
+		}
+		if handler != nil {
+			if err := handler.Close(); err != nil {
+				newError("failed to close outbound ", tag).Base(err).AtWarning().WriteToLog()
+			}
+		}
+	}
+}
+
+// discard closes the created features and handlers that are not swapped in.
+func (r *reload) discard() {
+	for _, app := range r.apps[r.appliedApps:] {
+		common.Close(app.next)
+	}
+	for _, handler := range r.inbounds[r.appliedInbounds:] {
+		common.Close(handler)
+	}
+	for _, handler := range r.outbounds[r.appliedOutbounds:] {
+		common.Close(handler)
+	}
+}
+
+// rollback undoes the swaps made so far, in reverse order.
+func (r *reload) rollback() {
+	for i := len(r.undo) - 1; i >= 0; i-- {
+		r.undo[i]()
+	}
+}
+
+// prepareAppReload creates features for the app settings that changed. App settings are matched by their type.
+// The features created are closed if it fails.
+func (s *Instance) prepareAppReload(current, next []*anypb.Any) ([]appReload, error) {
+	if len(current) != len(next) {
+		return nil, newError("apps cannot be added or removed by reloading")
+	}
+	currentByType := make(map[string]*anypb.Any, len(current))
+	for _, app := range current {
+		if _, found := currentByType[app.TypeUrl]; found {
+			return nil, newError("config with duplicated app ", serial.V2Type(app), " cannot be reloaded")
+		}
+		currentByType[app.TypeUrl] = app
+	}
+
+	var apps []appReload
+	fail := func(err error) ([]appReload, error) {
+		for _, app := range apps {
+			common.Close(app.next)
+		}
+		return nil, err
+	}
+	for _, app := range next {
+		appType := serial.V2Type(app)
+		old, found := currentByType[app.TypeUrl]
+		if !found {
+			return fail(newError("apps cannot be added or removed by reloading: ", appType))
+		}
+		if proto.Equal(old, app) {
+			continue
+		}
+		feature, err := s.createApp(app)
+		if err != nil {
+			return fail(err)
+		}
+		reloadable, ok := s.GetFeature(feature.Type()).(features.Reloadable)
+		if !ok {
+			common.Close(feature)
+			return fail(newError(appType, " cannot be reloaded, restart is required"))
+		}
+		apps = append(apps, appReload{current: reloadable, next: feature, previous: old})
+	}
+	return apps, nil
+}
+
+// createApp creates the feature of the given app settings in the environment of the app.
+func (s *Instance) createApp(app *anypb.Any) (features.Feature, error) {
+	appType := serial.V2Type(app)
+	settings, err := serial.GetInstanceOf(app)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := CreateObjectWithEnvironment(s, settings, s.env.AppEnvironment(app.TypeUrl))
+	if err != nil {
+		return nil, newError("failed to create ", appType).Base(err)
+	}
+	feature, ok := obj.(features.Feature)
+	if !ok {
+		common.Close(obj)
+		return nil, newError(appType, " cannot be reloaded")
+	}
+	return feature, nil
+}
+
+// restoreApp makes the reloaded feature take its previous settings again.
+func (s *Instance) restoreApp(app appReload) error {
+	feature, err := s.createApp(app.previous)
+	if err != nil {
+		return err
+	}
+	if err := app.current.ReplaceWith(feature); err != nil {
+		common.Close(feature)
+		return err
+	}
+	return nil
+}
+
+// diffInbounds returns the tags of handlers to remove and the configs of handlers to add.
+func diffInbounds(current, next []*InboundHandlerConfig) ([]string, []*InboundHandlerConfig, error) {
+	currentByTag := make(map[string]*InboundHandlerConfig)
+	var currentUntagged, nextUntagged []proto.Message
+	for _, handler := range current {
+		if handler.Tag == "" {
+			currentUntagged = append(currentUntagged, handler)
+			continue
+		}
+		currentByTag[handler.Tag] = handler
+	}
+
+	var toRemove []string
+	var toAdd []*InboundHandlerConfig
+	nextTags := make(map[string]bool)
+	for _, handler := range next {
+		if handler.Tag == "" {
+			nextUntagged = append(nextUntagged, handler)
+			continue
+		}
+		nextTags[handler.Tag] = true
+		old, found := currentByTag[handler.Tag]
+		if found && proto.Equal(old, handler) {
+			continue
+		}
+		if found {
+			toRemove = append(toRemove, handler.Tag)
+		}
+		toAdd = append(toAdd, handler)
+	}
+	for _, handler := range current {
+		if handler.Tag != "" && !nextTags[handler.Tag] {
+			toRemove = append(toRemove, handler.Tag)
+		}
+	}
+	if !equalMessages(currentUntagged, nextUntagged) {
+		return nil, nil, newError("untagged inbounds cannot be reloaded, give them tags")
+	}
+	return toRemove, toAdd, nil
+}
+
+// diffOutbounds returns the tags of handlers to remove and the configs of handlers to add.
+func diffOutbounds(current, next []*OutboundHandlerConfig) ([]string, []*OutboundHandlerConfig, error) {
+	if len(current) > 0 && (len(next) == 0 || next[0].Tag != current[0].Tag) {
+		return nil, nil, newError("the default outbound, which is the first one, cannot be changed by reloading")
+	}
+
+	currentByTag := make(map[string]*OutboundHandlerConfig)
+	var currentUntagged, nextUntagged []proto.Message
+	for _, handler := range current {
+		if handler.Tag == "" {
+			currentUntagged = append(currentUntagged, handler)
+			continue
+		}
+		currentByTag[handler.Tag] = handler
+	}
+
+	var toRemove []string
+	var toAdd []*OutboundHandlerConfig
+	nextTags := make(map[string]bool)
+	for i, handler := range next {
+		if handler.Tag == "" {
+			nextUntagged = append(nextUntagged, handler)
+			continue
+		}
+		nextTags[handler.Tag] = true
+		old, found := currentByTag[handler.Tag]
+		if found && proto.Equal(old, handler) {
+			continue
+		}
+		if i == 0 {
+			// Removing the default handler clears it, and there is no way to make the new one default again.
+			return nil, nil, newError("the default outbound, which is the first one, cannot be changed by reloading")
+		}
+		if found {
+			toRemove = append(toRemove, handler.Tag)
+		}
+		toAdd = append(toAdd, handler)
+	}
+	for _, handler := range current {
+		if handler.Tag != "" && !nextTags[handler.Tag] {
+			toRemove = append(toRemove, handler.Tag)
+		}
+	}
+	if !equalMessages(currentUntagged, nextUntagged) {
+		return nil, nil, newError("untagged outbounds cannot be reloaded, give them tags")
+	}
+	return toRemove, toAdd, nil
+}
+
+func equalMessages(a, b []proto.Message) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
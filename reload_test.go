@@ -0,0 +1,196 @@
+package core_test
+
+import (
+	"context"
+	gonet "net"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	. "github.com/v2fly/v2ray-core/v4"
+	"github.com/v2fly/v2ray-core/v4/app/dispatcher"
+	"github.com/v2fly/v2ray-core/v4/app/proxyman"
+	"github.com/v2fly/v2ray-core/v4/app/router"
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/common/serial"
+	"github.com/v2fly/v2ray-core/v4/common/session"
+	"github.com/v2fly/v2ray-core/v4/features/inbound"
+	"github.com/v2fly/v2ray-core/v4/features/outbound"
+	"github.com/v2fly/v2ray-core/v4/features/routing"
+	routing_session "github.com/v2fly/v2ray-core/v4/features/routing/session"
+	"github.com/v2fly/v2ray-core/v4/proxy/blackhole"
+	"github.com/v2fly/v2ray-core/v4/proxy/dokodemo"
+	"github.com/v2fly/v2ray-core/v4/proxy/freedom"
+	"github.com/v2fly/v2ray-core/v4/testing/servers/tcp"
+)
+
+func newReloadTestConfig(port net.Port, ruleTarget string, outboundTags ...string) *Config {
+	config := &Config{
+		App: []*anypb.Any{
+			serial.ToTypedMessage(&dispatcher.Config{}),
+			serial.ToTypedMessage(&proxyman.InboundConfig{}),
+			serial.ToTypedMessage(&proxyman.OutboundConfig{}),
+			serial.ToTypedMessage(&router.Config{
+				Rule: []*router.RoutingRule{
+					{
+						InboundTag: []string{"in"},
+						TargetTag:  &router.RoutingRule_Tag{Tag: ruleTarget},
+					},
+				},
+			}),
+		},
+		Inbound: []*InboundHandlerConfig{
+			{
+				Tag: "in",
+				ReceiverSettings: serial.ToTypedMessage(&proxyman.ReceiverConfig{
+					PortRange: net.SinglePortRange(port),
+					Listen:    net.NewIPOrDomain(net.LocalHostIP),
+				}),
+				ProxySettings: serial.ToTypedMessage(&dokodemo.Config{
+					Address: net.NewIPOrDomain(net.LocalHostIP),
+					Port:    uint32(80),
+					NetworkList: &net.NetworkList{
+						Network: []net.Network{net.Network_TCP},
+					},
+				}),
+			},
+		},
+		Outbound: []*OutboundHandlerConfig{
+			{
+				Tag:           "direct",
+				ProxySettings: serial.ToTypedMessage(&freedom.Config{}),
+			},
+		},
+	}
+	for _, tag := range outboundTags {
+		config.Outbound = append(config.Outbound, &OutboundHandlerConfig{
+			Tag:           tag,
+			ProxySettings: serial.ToTypedMessage(&blackhole.Config{}),
+		})
+	}
+	return config
+}
+
+func pickTestRoute(t *testing.T, r routing.Router) string {
+	ctx := session.ContextWithInbound(context.Background(), &session.Inbound{Tag: "in"})
+	route, err := r.PickRoute(routing_session.AsRoutingContext(ctx))
+	if err != nil {
+		t.Fatal("failed to pick route: ", err)
+	}
+	return route.GetOutboundTag()
+}
+
+func TestInstanceReload(t *testing.T) {
+	port := tcp.PickPort()
+	server, err := New(newReloadTestConfig(port, "block", "block"))
+	common.Must(err)
+	common.Must(server.Start())
+	defer server.Close()
+
+	r := server.GetFeature(routing.RouterType()).(routing.Router)
+	im := server.GetFeature(inbound.ManagerType()).(inbound.Manager)
+	om := server.GetFeature(outbound.ManagerType()).(outbound.Manager)
+
+	if tag := pickTestRoute(t, r); tag != "block" {
+		t.Error("unexpected route before reload: ", tag)
+	}
+	inboundHandler, err := im.GetHandler(context.Background(), "in")
+	common.Must(err)
+	directHandler := om.GetHandler("direct")
+
+	common.Must(server.Reload(newReloadTestConfig(port, "reject", "reject")))
+
+	if tag := pickTestRoute(t, r); tag != "reject" {
+		t.Error("unexpected route after reload: ", tag)
+	}
+	if om.GetHandler("block") != nil {
+		t.Error("removed outbound is still present")
+	}
+	if om.GetHandler("reject") == nil {
+		t.Error("added outbound is missing")
+	}
+	if h := om.GetHandler("direct"); h != directHandler {
+		t.Error("unchanged outbound is recreated")
+	}
+	if h, err := im.GetHandler(context.Background(), "in"); err != nil || h != inboundHandler {
+		t.Error("unchanged inbound is recreated: ", err)
+	}
+}
+
+func TestInstanceReloadRejectsDefaultOutboundChange(t *testing.T) {
+	port := tcp.PickPort()
+	config := newReloadTestConfig(port, "block", "block")
+	server, err := New(config)
+	common.Must(err)
+	common.Must(server.Start())
+	defer server.Close()
+
+	next := proto.Clone(config).(*Config)
+	next.Outbound[0].Tag = "direct2"
+	next.App[3] = serial.ToTypedMessage(&router.Config{})
+	if err := server.Reload(next); err == nil {
+		t.Fatal("expected reload to fail")
+	}
+
+	r := server.GetFeature(routing.RouterType()).(routing.Router)
+	if tag := pickTestRoute(t, r); tag != "block" {
+		t.Error("router is changed by a rejected reload: ", tag)
+	}
+	om := server.GetFeature(outbound.ManagerType()).(outbound.Manager)
+	if om.GetHandler("direct") == nil {
+		t.Error("default outbound is removed by a rejected reload")
+	}
+}
+
+func TestInstanceReloadRollsBackOnFailure(t *testing.T) {
+	port := tcp.PickPort()
+	config := newReloadTestConfig(port, "block", "block")
+	server, err := New(config)
+	common.Must(err)
+	common.Must(server.Start())
+	defer server.Close()
+
+	busyPort := tcp.PickPort()
+	listener, err := gonet.Listen("tcp", net.TCPDestination(net.LocalHostIP, busyPort).NetAddr())
+	common.Must(err)
+	defer listener.Close()
+
+	next := newReloadTestConfig(busyPort, "reject", "block", "reject")
+	if err := server.Reload(next); err == nil {
+		t.Fatal("expected reload to fail")
+	}
+
+	r := server.GetFeature(routing.RouterType()).(routing.Router)
+	if tag := pickTestRoute(t, r); tag != "block" {
+		t.Error("router is changed by a failed reload: ", tag)
+	}
+	om := server.GetFeature(outbound.ManagerType()).(outbound.Manager)
+	if om.GetHandler("reject") != nil {
+		t.Error("outbound added by a failed reload is kept")
+	}
+	if om.GetHandler("block") == nil {
+		t.Error("outbound is removed by a failed reload")
+	}
+	conn, err := gonet.Dial("tcp", net.TCPDestination(net.LocalHostIP, port).NetAddr())
+	if err != nil {
+		t.Fatal("inbound is not restored after a failed reload: ", err)
+	}
+	conn.Close()
+
+	// The failed config is not recorded, so it is applied in full once it can be.
+	common.Must(listener.Close())
+	common.Must(server.Reload(next))
+	if tag := pickTestRoute(t, r); tag != "reject" {
+		t.Error("unexpected route after reload: ", tag)
+	}
+	if om.GetHandler("reject") == nil {
+		t.Error("added outbound is missing")
+	}
+	conn, err = gonet.Dial("tcp", net.TCPDestination(net.LocalHostIP, busyPort).NetAddr())
+	if err != nil {
+		t.Fatal("modified inbound is not listening: ", err)
+	}
+	conn.Close()
+}
@@ -151,6 +151,11 @@ func (v *SimpleSystemDialer) Dial(ctx context.Context, src net.Address, dest net
 	return v.adapter.Dial(dest.Network.SystemString(), dest.NetAddr())
 }
 
+// EffectiveSystemDialer returns the system dialer currently in use.
+func EffectiveSystemDialer() SystemDialer {
+	return effectiveSystemDialer
+}
+
 // UseAlternativeSystemDialer replaces the current system dialer with a given one.
 // Caller must ensure there is no race condition.
 //
@@ -103,6 +103,11 @@ func RegisterListenerController(controller func(network, address string, fd uint
 	return nil
 }
 
+// EffectiveSystemListener returns the system listener currently in use.
+func EffectiveSystemListener() SystemListener {
+	return &effectiveListener
+}
+
 type SystemListener interface {
 	Listen(ctx context.Context, addr net.Addr, sockopt *SocketConfig) (net.Listener, error)
 	ListenPacket(ctx context.Context, addr net.Addr, sockopt *SocketConfig) (net.PacketConn, error)
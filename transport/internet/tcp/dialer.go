@@ -7,6 +7,7 @@ import (
 	"github.com/v2fly/v2ray-core/v4/common"
 	"github.com/v2fly/v2ray-core/v4/common/net"
 	"github.com/v2fly/v2ray-core/v4/common/session"
+	"github.com/v2fly/v2ray-core/v4/features/tracing"
 	"github.com/v2fly/v2ray-core/v4/transport/internet"
 	"github.com/v2fly/v2ray-core/v4/transport/internet/tls"
 )
@@ -29,6 +30,11 @@ func Dial(ctx context.Context, dest net.Destination, streamSettings *internet.Me
 			}
 		*/
 		conn = tls.Client(conn, tlsConfig)
+
+		// The handshake is timed when it takes place, on first read or write, as it does untraced.
+		if span := tracing.SpanFromContext(ctx); span != nil {
+			conn.(*tls.Conn).TraceHandshake(span)
+		}
 	}
 
 	tcpSettings := streamSettings.ProtocolSettings.(*Config)
@@ -3,10 +3,13 @@ package tls
 import (
 	"context"
 	"crypto/tls"
-	"github.com/v2fly/v2ray-core/v4/common"
+	"sync"
 
+	"github.com/v2fly/v2ray-core/v4/common"
 	"github.com/v2fly/v2ray-core/v4/common/buf"
 	"github.com/v2fly/v2ray-core/v4/common/net"
+	"github.com/v2fly/v2ray-core/v4/features/tracing"
+	"github.com/v2fly/v2ray-core/v4/transport/internet"
 )
 
 //go:generate go run github.com/v2fly/v2ray-core/v4/common/errors/errorgen
@@ -15,6 +18,47 @@ var _ buf.Writer = (*Conn)(nil)
 
 type Conn struct {
 	*tls.Conn
+
+	// traced is the span the handshake is timed under, if it is traced.
+	traced      tracing.Span
+	handshaking sync.Once
+}
+
+// TraceHandshake times the handshake under the span. The handshake still takes place when the connection is first
+// read or written, or shakes hands explicitly.
+func (c *Conn) TraceHandshake(span tracing.Span) {
+	c.traced = span
+}
+
+func (c *Conn) traceHandshake() {
+	if c.traced == nil {
+		return
+	}
+	c.handshaking.Do(func() {
+		span := c.traced.StartChild("tls.handshake")
+		err := c.Conn.Handshake()
+		span.SetAttributes(tracing.String("tls.server_name", c.Conn.ConnectionState().ServerName))
+		span.RecordError(err)
+		span.End()
+	})
+}
+
+// Handshake implements tls.Conn.
+func (c *Conn) Handshake() error {
+	c.traceHandshake()
+	return c.Conn.Handshake()
+}
+
+// Read implements net.Conn.
+func (c *Conn) Read(b []byte) (int, error) {
+	c.traceHandshake()
+	return c.Conn.Read(b)
+}
+
+// Write implements net.Conn.
+func (c *Conn) Write(b []byte) (int, error) {
+	c.traceHandshake()
+	return c.Conn.Write(b)
 }
 
 func (c *Conn) WriteMultiBuffer(mb buf.MultiBuffer) error {
@@ -35,6 +79,30 @@ func (c *Conn) HandshakeAddress() net.Address {
 	return net.ParseAddress(state.ServerName)
 }
 
+// ConnectionState returns the state of the connection, if it is a TLS connection or one counted by stats.
+func ConnectionState(conn net.Conn) (tls.ConnectionState, bool) {
+	if statConn, ok := conn.(*internet.StatCouterConnection); ok {
+		conn = statConn.Connection
+	}
+	if tlsConn, ok := conn.(*Conn); ok {
+		return tlsConn.ConnectionState(), true
+	}
+	return tls.ConnectionState{}, false
+}
+
+// NegotiatedProtocol returns the application protocol negotiated with ALPN on the connection, completing its handshake
+// if not done yet, or empty if it is not a TLS connection or the handshake fails.
+func NegotiatedProtocol(conn net.Conn) string {
+	if statConn, ok := conn.(*internet.StatCouterConnection); ok {
+		conn = statConn.Connection
+	}
+	tlsConn, ok := conn.(*Conn)
+	if !ok || tlsConn.Handshake() != nil {
+		return ""
+	}
+	return tlsConn.ConnectionState().NegotiatedProtocol
+}
+
 // Client initiates a TLS client handshake on the given connection.
 func Client(c net.Conn, config *tls.Config) net.Conn {
 	tlsConn := tls.Client(c, config)
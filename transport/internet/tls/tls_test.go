@@ -0,0 +1,110 @@
+package tls_test
+
+import (
+	gotls "crypto/tls"
+	gonet "net"
+	"testing"
+
+	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/protocol/tls/cert"
+	"github.com/v2fly/v2ray-core/v4/features/tracing"
+	"github.com/v2fly/v2ray-core/v4/transport/internet"
+	. "github.com/v2fly/v2ray-core/v4/transport/internet/tls"
+)
+
+func TestNegotiatedProtocol(t *testing.T) {
+	serverConfig := (&Config{
+		Certificate:  []*Certificate{ParseCertificate(cert.MustGenerate(nil, cert.DNSNames("www.v2fly.org")))},
+		NextProtocol: []string{"h2"},
+	}).GetTLSConfig()
+
+	client, server := gonet.Pipe()
+	defer client.Close()
+	go func() {
+		tlsClient := gotls.Client(client, &gotls.Config{
+			ServerName:         "www.v2fly.org",
+			InsecureSkipVerify: true,
+			NextProtos:         []string{"h2", "http/1.1"},
+		})
+		tlsClient.Handshake()
+	}()
+
+	conn := Server(server, serverConfig).(*Conn)
+	common.Must(conn.Handshake())
+	if p := NegotiatedProtocol(conn); p != "h2" {
+		t.Error("unexpected protocol ", p)
+	}
+	if p := NegotiatedProtocol(&internet.StatCouterConnection{Connection: conn}); p != "h2" {
+		t.Error("unexpected protocol of counted connection ", p)
+	}
+	if p := NegotiatedProtocol(server); p != "" {
+		t.Error("unexpected protocol of plain connection ", p)
+	}
+}
+
+type testSpan struct {
+	name     string
+	attrs    []tracing.Attribute
+	ended    bool
+	children []*testSpan
+}
+
+func (s *testSpan) SetAttributes(attributes ...tracing.Attribute) {
+	s.attrs = append(s.attrs, attributes...)
+}
+
+func (s *testSpan) RecordError(err error) {}
+
+func (s *testSpan) End() {
+	s.ended = true
+}
+
+func (s *testSpan) StartChild(name string, attributes ...tracing.Attribute) tracing.Span {
+	child := &testSpan{name: name, attrs: attributes}
+	s.children = append(s.children, child)
+	return child
+}
+
+func TestTraceHandshake(t *testing.T) {
+	serverConfig := (&Config{
+		Certificate: []*Certificate{ParseCertificate(cert.MustGenerate(nil, cert.DNSNames("www.v2fly.org")))},
+	}).GetTLSConfig()
+
+	client, server := gonet.Pipe()
+	defer client.Close()
+	received := make(chan string, 1)
+	go func() {
+		conn := gotls.Server(server, serverConfig)
+		b := make([]byte, 5)
+		n, _ := conn.Read(b)
+		received <- string(b[:n])
+	}()
+
+	conn := Client(client, &gotls.Config{ServerName: "www.v2fly.org", InsecureSkipVerify: true}).(*Conn)
+	parent := &testSpan{}
+	conn.TraceHandshake(parent)
+	if len(parent.children) != 0 {
+		t.Fatal("handshake is started before the connection is used")
+	}
+
+	_, err := conn.Write([]byte("hello"))
+	common.Must(err)
+	if s := <-received; s != "hello" {
+		t.Error("unexpected data ", s)
+	}
+	if len(parent.children) != 1 {
+		t.Fatal("expected one handshake span, but got ", len(parent.children))
+	}
+	span := parent.children[0]
+	if span.name != "tls.handshake" || !span.ended {
+		t.Error("unexpected handshake span ", span.name, " ended: ", span.ended)
+	}
+	if len(span.attrs) != 1 || span.attrs[0].Value != "www.v2fly.org" {
+		t.Error("unexpected attributes ", span.attrs)
+	}
+
+	common.Must(conn.Handshake())
+	if len(parent.children) != 1 {
+		t.Error("handshake is traced more than once")
+	}
+}
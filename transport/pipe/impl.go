@@ -24,6 +24,7 @@ const (
 type pipeOption struct {
 	limit           int32 // maximum buffer size in bytes
 	discardOverflow bool
+	limiters        []*buf.RateLimiter
 }
 
 func (o *pipeOption) isFull(curSize int32) bool {
@@ -137,6 +138,13 @@ func (p *pipe) WriteMultiBuffer(mb buf.MultiBuffer) error {
 		return nil
 	}
 
+	if len(p.option.limiters) > 0 {
+		if err := buf.WaitRateLimiters(p.option.limiters, int64(mb.Len()), p.done.Wait()); err != nil {
+			buf.ReleaseMulti(mb)
+			return err
+		}
+	}
+
 	for {
 		err := p.writeMultiBufferInternal(mb)
 		if err == nil {
@@ -3,6 +3,7 @@ package pipe
 import (
 	"context"
 
+	"github.com/v2fly/v2ray-core/v4/common/buf"
 	"github.com/v2fly/v2ray-core/v4/common/signal"
 	"github.com/v2fly/v2ray-core/v4/common/signal/done"
 	"github.com/v2fly/v2ray-core/v4/features/policy"
@@ -32,6 +33,13 @@ func DiscardOverflow() Option {
 	}
 }
 
+// WithRateLimiter returns an Option for Pipe to limit the throughput of writes by the given limiters.
+func WithRateLimiter(limiters ...*buf.RateLimiter) Option {
+	return func(opt *pipeOption) {
+		opt.limiters = append(opt.limiters, limiters...)
+	}
+}
+
 // OptionsFromContext returns a list of Options from context.
 func OptionsFromContext(ctx context.Context) []Option {
 	var opt []Option
@@ -62,8 +70,8 @@ func New(opts ...Option) (*Reader, *Writer) {
 	}
 
 	return &Reader{
-			pipe: p,
-		}, &Writer{
-			pipe: p,
-		}
+		pipe: p,
+	}, &Writer{
+		pipe: p,
+	}
 }
@@ -151,3 +151,30 @@ func BenchmarkPipeReadWrite(b *testing.B) {
 		c = d
 	}
 }
+
+func TestPipeRateLimit(t *testing.T) {
+	pReader, pWriter := New(WithSizeLimit(-1), WithRateLimiter(buf.NewRateLimiter(buf.Size)))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		b := buf.New()
+		b.Extend(buf.Size)
+		common.Must(pWriter.WriteMultiBuffer(buf.MultiBuffer{b}))
+	}
+	if elapsed := time.Since(start); elapsed < 1500*time.Millisecond {
+		t.Error("unexpected time to write 3 buffers at a buffer per second: ", elapsed)
+	}
+	mb, err := pReader.ReadMultiBuffer()
+	common.Must(err)
+	if mb.Len() != 3*buf.Size {
+		t.Error("unexpected size of data: ", mb.Len())
+	}
+	buf.ReleaseMulti(mb)
+
+	time.AfterFunc(100*time.Millisecond, pReader.Interrupt)
+	b := buf.New()
+	b.Extend(buf.Size)
+	if err := pWriter.WriteMultiBuffer(buf.MultiBuffer{b}); err != io.ErrClosedPipe {
+		t.Error("expected write to interrupted pipe to fail, but got ", err)
+	}
+}
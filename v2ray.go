@@ -6,6 +6,8 @@ import (
 	"sync"
 
 	"github.com/v2fly/v2ray-core/v4/common"
+	"github.com/v2fly/v2ray-core/v4/common/environment"
+	"github.com/v2fly/v2ray-core/v4/common/environment/transientstorageimpl"
 	"github.com/v2fly/v2ray-core/v4/common/serial"
 	"github.com/v2fly/v2ray-core/v4/features"
 	"github.com/v2fly/v2ray-core/v4/features/dns"
@@ -91,7 +93,11 @@ type Instance struct {
 	featureResolutions []resolution
 	running            bool
 
+	reloadAccess sync.Mutex
+	config       *Config
+
 	ctx context.Context
+	env environment.RootEnvironment
 }
 
 func AddInboundHandler(server *Instance, config *InboundHandlerConfig) error {
@@ -186,12 +192,14 @@ func initInstanceWithConfig(config *Config, server *Instance) (bool, error) {
 		return true, err
 	}
 
+	server.env = environment.NewRootEnvImpl(server.ctx, server, transientstorageimpl.NewScopedTransientStorageImpl())
+
 	for _, appSettings := range config.App {
 		settings, err := serial.GetInstanceOf(appSettings)
 		if err != nil {
 			return true, err
 		}
-		obj, err := CreateObject(server, settings)
+		obj, err := CreateObjectWithEnvironment(server, settings, server.env.AppEnvironment(appSettings.TypeUrl))
 		if err != nil {
 			return true, err
 		}
@@ -231,6 +239,7 @@ func initInstanceWithConfig(config *Config, server *Instance) (bool, error) {
 	if err := addOutboundHandlers(server, config.Outbound); err != nil {
 		return true, err
 	}
+	server.config = config
 	return false, nil
 }
 